@@ -0,0 +1,123 @@
+package casts
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// earthRadius adalah radius ekuator WGS84 dalam meter.
+const earthRadius = 6378137.0
+
+// GeoPolygon adalah geometri GeoJSON bertipe Polygon. Ring pertama adalah batas
+// luar, ring berikutnya (jika ada) adalah lubang. Setiap posisi berurutan
+// [longitude, latitude] sesuai RFC 7946.
+type GeoPolygon struct {
+	Type        string         `json:"type" example:"Polygon"`
+	Coordinates [][][2]float64 `json:"coordinates" swaggertype:"array,number"`
+}
+
+// Validate memastikan polygon berbentuk GeoJSON Polygon yang valid: setiap ring
+// minimal 4 posisi, tertutup (posisi awal sama dengan posisi akhir), dan
+// koordinat berada dalam rentang longitude/latitude.
+func (p GeoPolygon) Validate() error {
+	if p.Type != "Polygon" {
+		return fmt.Errorf("tipe geometri %q tidak didukung, gunakan Polygon", p.Type)
+	}
+	if len(p.Coordinates) == 0 {
+		return errors.New("polygon tidak memiliki koordinat")
+	}
+	for i, ring := range p.Coordinates {
+		if len(ring) < 4 {
+			return fmt.Errorf("ring %d minimal memiliki 4 posisi", i)
+		}
+		if ring[0] != ring[len(ring)-1] {
+			return fmt.Errorf("ring %d tidak tertutup", i)
+		}
+		for _, position := range ring {
+			if position[0] < -180 || position[0] > 180 || position[1] < -90 || position[1] > 90 {
+				return fmt.Errorf("koordinat [%v, %v] di luar rentang", position[0], position[1])
+			}
+		}
+	}
+	return nil
+}
+
+// Area menghitung luas polygon di permukaan bumi dalam meter persegi,
+// dikurangi luas lubang.
+func (p GeoPolygon) Area() float64 {
+	if len(p.Coordinates) == 0 {
+		return 0
+	}
+	area := ringArea(p.Coordinates[0])
+	for _, hole := range p.Coordinates[1:] {
+		area -= ringArea(hole)
+	}
+	return math.Max(area, 0)
+}
+
+// AreaHectares menghitung luas polygon dalam hektar, satuan luas_lahan.
+func (p GeoPolygon) AreaHectares() float64 {
+	return p.Area() / 10000
+}
+
+// Centroid mengembalikan rata-rata posisi ring luar sebagai [longitude, latitude].
+func (p GeoPolygon) Centroid() [2]float64 {
+	if len(p.Coordinates) == 0 || len(p.Coordinates[0]) < 2 {
+		return [2]float64{}
+	}
+	ring := p.Coordinates[0][:len(p.Coordinates[0])-1]
+	var lng, lat float64
+	for _, position := range ring {
+		lng += position[0]
+		lat += position[1]
+	}
+	return [2]float64{lng / float64(len(ring)), lat / float64(len(ring))}
+}
+
+// ringArea memakai pendekatan luas sferis dari Chamberlain & Duquette,
+// "Some Algorithms for Polygons on a Sphere" (JPL, 2007).
+func ringArea(ring [][2]float64) float64 {
+	var total float64
+	for i := 0; i < len(ring)-1; i++ {
+		lng1, lat1 := radians(ring[i][0]), radians(ring[i][1])
+		lng2, lat2 := radians(ring[i+1][0]), radians(ring[i+1][1])
+		total += (lng2 - lng1) * (2 + math.Sin(lat1) + math.Sin(lat2))
+	}
+	return math.Abs(total * earthRadius * earthRadius / 2)
+}
+
+func radians(degree float64) float64 {
+	return degree * math.Pi / 180
+}
+
+// Scan mengimplementasikan sql.Scanner untuk kolom JSON.
+func (p *GeoPolygon) Scan(value any) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*p = GeoPolygon{}
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("tidak dapat membaca %T sebagai GeoPolygon", value)
+	}
+	return json.Unmarshal(data, p)
+}
+
+// Value mengimplementasikan driver.Valuer untuk kolom JSON.
+func (p GeoPolygon) Value() (driver.Value, error) {
+	if len(p.Coordinates) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,63 @@
+package casts_test
+
+import (
+	"golang_starter_kit_2025/app/casts"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GeoPolygon", func() {
+	// persegi 0.001 x 0.001 derajat di sekitar ekuator, kurang lebih 1,23 hektar
+	square := casts.GeoPolygon{
+		Type: "Polygon",
+		Coordinates: [][][2]float64{{
+			{119.000, 0.000}, {119.001, 0.000}, {119.001, 0.001}, {119.000, 0.001}, {119.000, 0.000},
+		}},
+	}
+
+	It("should calculate area in hectares", func() {
+		Expect(square.Validate()).To(Succeed())
+		Expect(square.AreaHectares()).To(BeNumerically("~", 1.237, 0.01))
+	})
+
+	It("should subtract holes from area", func() {
+		withHole := casts.GeoPolygon{
+			Type: "Polygon",
+			Coordinates: append(square.Coordinates, [][2]float64{
+				{119.0002, 0.0002}, {119.0004, 0.0002}, {119.0004, 0.0004}, {119.0002, 0.0004}, {119.0002, 0.0002},
+			}),
+		}
+
+		Expect(withHole.AreaHectares()).To(BeNumerically("<", square.AreaHectares()))
+	})
+
+	It("should calculate centroid", func() {
+		centroid := square.Centroid()
+
+		Expect(centroid[0]).To(BeNumerically("~", 119.0005, 1e-9))
+		Expect(centroid[1]).To(BeNumerically("~", 0.0005, 1e-9))
+	})
+
+	It("should reject unclosed ring", func() {
+		polygon := casts.GeoPolygon{
+			Type:        "Polygon",
+			Coordinates: [][][2]float64{{{0, 0}, {1, 0}, {1, 1}, {0, 1}}},
+		}
+
+		Expect(polygon.Validate()).To(HaveOccurred())
+	})
+
+	It("should reject non polygon geometry", func() {
+		Expect(casts.GeoPolygon{Type: "Point"}.Validate()).To(HaveOccurred())
+	})
+
+	It("should round trip through database value", func() {
+		value, err := square.Value()
+		Expect(err).NotTo(HaveOccurred())
+
+		var scanned casts.GeoPolygon
+		Expect(scanned.Scan(value)).To(Succeed())
+		Expect(scanned).To(Equal(square))
+	})
+})
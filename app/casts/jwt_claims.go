@@ -3,25 +3,42 @@ package casts
 import "github.com/golang-jwt/jwt/v5"
 
 type JwtClaims struct {
-	UserID    uint `json:"user_id"`
+	UserID    uint  `json:"user_id"`
+	StoreID   uint  `json:"store_id"`
 	ExpiredAt int64 `json:"expired_at"`
 }
 
 // set JWT claims
 func NewJwtClaims(userID uint, expiredAt int64) jwt.MapClaims {
 	return jwt.MapClaims{
-		"user_id": userID,
+		"user_id":    userID,
 		"expired_at": expiredAt,
 	}
 }
 
+// set JWT claims with the active store of the user
+func NewStoreJwtClaims(userID uint, storeID uint, expiredAt int64) jwt.MapClaims {
+	claims := NewJwtClaims(userID, expiredAt)
+	if storeID != 0 {
+		claims["store_id"] = storeID
+	}
+	return claims
+}
+
 // get JWT claims and parse it
-func ParseJwtClaims(claims jwt.Claims) (JwtClaims) {
+func ParseJwtClaims(claims jwt.Claims) JwtClaims {
 	mapClaims := claims.(jwt.MapClaims)
 	userID := uint(mapClaims["user_id"].(float64))
 	expiredAt := int64(mapClaims["expired_at"].(float64))
+
+	var storeID uint
+	if value, ok := mapClaims["store_id"].(float64); ok {
+		storeID = uint(value)
+	}
+
 	return JwtClaims{
-		UserID: userID,
+		UserID:    userID,
+		StoreID:   storeID,
 		ExpiredAt: expiredAt,
 	}
-}
\ No newline at end of file
+}
@@ -22,6 +22,21 @@ var _ = Describe("NewJwtClaims", func() {
 	})
 })
 
+var _ = Describe("NewStoreJwtClaims", func() {
+	It("should return jwt claims with store id", func() {
+		claims := casts.NewStoreJwtClaims(uint(1), uint(2), time.Now().Unix())
+
+		Expect(claims["user_id"]).To(Equal(uint(1)))
+		Expect(claims["store_id"]).To(Equal(uint(2)))
+	})
+
+	It("should omit store id when no store is selected", func() {
+		claims := casts.NewStoreJwtClaims(uint(1), 0, time.Now().Unix())
+
+		Expect(claims).NotTo(HaveKey("store_id"))
+	})
+})
+
 var _ = Describe("ParseJwtClaims", func() {
 	Context("when parse JWT claims", func() {
 		It("should parse valid JWT claims", func() {
@@ -36,9 +51,20 @@ var _ = Describe("ParseJwtClaims", func() {
 			parsedClaims := casts.ParseJwtClaims(claims)
 
 			Expect(parsedClaims.UserID).To(Equal(userID))
+			Expect(parsedClaims.StoreID).To(BeZero())
 			Expect(parsedClaims.ExpiredAt).To(Equal(expiredAt))
 		})
 
+		It("should parse store id when present", func() {
+			claims := jwt.MapClaims{
+				"user_id":    float64(123),
+				"store_id":   float64(7),
+				"expired_at": float64(time.Now().Add(time.Hour).Unix()),
+			}
+
+			Expect(casts.ParseJwtClaims(claims).StoreID).To(Equal(uint(7)))
+		})
+
 		It("should handle invalid JWT claims", func() {
 			claims := jwt.MapClaims{
 				"user_id":    "invalid_user_id",
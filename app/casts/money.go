@@ -0,0 +1,239 @@
+package casts
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency adalah kode mata uang ISO 4217 yang dipakai jika currency kosong.
+const DefaultCurrency = "IDR"
+
+// moneyScale adalah jumlah satuan terkecil dalam satu unit mata uang (dua angka desimal).
+const moneyScale = 100
+
+var ErrInvalidMoney = errors.New("format nominal uang tidak valid")
+
+// Money adalah nominal uang fixed-point dengan dua angka desimal, disimpan
+// sebagai jumlah sen sehingga penjumlahan dan perkalian tidak mengalami
+// galat pembulatan float. Disimpan ke kolom DECIMAL dan di-marshal ke JSON
+// sebagai number, unmarshal menerima number maupun string.
+type Money int64
+
+// NewMoney mengonversi float ke Money dengan pembulatan half away from zero.
+func NewMoney(value float64) Money {
+	return Money(math.Round(value * moneyScale))
+}
+
+// ParseMoney mengurai nominal desimal seperti "1250000", "-12.5" atau "99.995".
+// Angka desimal lebih dari dua digit dibulatkan half away from zero.
+func ParseMoney(value string) (Money, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, ErrInvalidMoney
+	}
+
+	negative := false
+	switch value[0] {
+	case '-':
+		negative = true
+		value = value[1:]
+	case '+':
+		value = value[1:]
+	}
+
+	whole, fraction, _ := strings.Cut(value, ".")
+	if whole == "" && fraction == "" {
+		return 0, ErrInvalidMoney
+	}
+	for _, digits := range []string{whole, fraction} {
+		for _, r := range digits {
+			if r < '0' || r > '9' {
+				return 0, ErrInvalidMoney
+			}
+		}
+	}
+
+	var units int64
+	if whole != "" {
+		parsed, err := strconv.ParseInt(whole, 10, 64)
+		if err != nil || parsed > math.MaxInt64/moneyScale-1 {
+			return 0, ErrInvalidMoney
+		}
+		units = parsed
+	}
+
+	padded := fraction + "000"
+	cents := int64(padded[0]-'0')*10 + int64(padded[1]-'0')
+	if padded[2] >= '5' {
+		cents++
+	}
+
+	amount := units*moneyScale + cents
+	if negative {
+		amount = -amount
+	}
+	return Money(amount), nil
+}
+
+// Float64 mengembalikan nominal sebagai float, hanya untuk tampilan atau
+// perbandingan kasar, bukan untuk perhitungan.
+func (m Money) Float64() float64 {
+	return float64(m) / moneyScale
+}
+
+// String mengembalikan nominal desimal dengan dua angka di belakang koma, contoh "1250000.50".
+func (m Money) String() string {
+	amount := int64(m)
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, amount/moneyScale, amount%moneyScale)
+}
+
+// Format mengembalikan nominal dengan kode mata uang, contoh "IDR 1250000.50".
+func (m Money) Format(currency string) string {
+	return NormalizeCurrency(currency) + " " + m.String()
+}
+
+func (m Money) Add(other Money) Money {
+	return m + other
+}
+
+func (m Money) Sub(other Money) Money {
+	return m - other
+}
+
+// Mul mengalikan nominal dengan kuantitas. Kuantitas dibulatkan ke dua angka
+// desimal lebih dulu sehingga hasilnya sama dengan perkalian DECIMAL di database.
+func (m Money) Mul(quantity float64) Money {
+	product := int64(m) * int64(math.Round(quantity*moneyScale))
+	result := product / moneyScale
+	if remainder := product % moneyScale; remainder*2 >= moneyScale {
+		result++
+	} else if remainder*2 <= -moneyScale {
+		result--
+	}
+	return Money(result)
+}
+
+// MulDiv mengembalikan m * numerator / denominator dengan pembulatan half
+// away from zero, dipakai untuk pajak dan pembagian proporsional tanpa float.
+func (m Money) MulDiv(numerator int64, denominator int64) Money {
+	if denominator == 0 {
+		return 0
+	}
+	product := new(big.Int).Mul(big.NewInt(int64(m)), big.NewInt(numerator))
+	den := big.NewInt(denominator)
+	quotient, remainder := new(big.Int).QuoRem(product, den, new(big.Int))
+
+	// |remainder| * 2 >= |denominator| dibulatkan menjauhi nol
+	if new(big.Int).Mul(new(big.Int).Abs(remainder), big.NewInt(2)).Cmp(new(big.Int).Abs(den)) >= 0 {
+		if product.Sign()*den.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+	return Money(quotient.Int64())
+}
+
+// Percent mengembalikan persentase nominal terhadap base, 0 jika base nol.
+func (m Money) Percent(base Money) float64 {
+	if base == 0 {
+		return 0
+	}
+	return math.Round(float64(m)/float64(base)*10000) / 100
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*m = 0
+		return nil
+	}
+	if len(data) > 1 && data[0] == '"' {
+		unquoted, err := strconv.Unquote(string(data))
+		if err != nil {
+			return ErrInvalidMoney
+		}
+		data = []byte(unquoted)
+	}
+
+	value := string(data)
+	if strings.ContainsAny(value, "eE") {
+		// notasi ilmiah, contoh 1e6
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return ErrInvalidMoney
+		}
+		*m = NewMoney(parsed)
+		return nil
+	}
+
+	parsed, err := ParseMoney(value)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// UnmarshalParam dipakai gin saat binding query dan form.
+func (m *Money) UnmarshalParam(param string) error {
+	return m.UnmarshalJSON([]byte(param))
+}
+
+// Scan implements the sql.Scanner interface.
+func (m *Money) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*m = 0
+		return nil
+	case []byte:
+		parsed, err := ParseMoney(string(v))
+		if err != nil {
+			return err
+		}
+		*m = parsed
+	case string:
+		parsed, err := ParseMoney(v)
+		if err != nil {
+			return err
+		}
+		*m = parsed
+	case int64:
+		*m = Money(v * moneyScale)
+	case float64:
+		*m = NewMoney(v)
+	default:
+		return fmt.Errorf("tipe %T tidak dapat dikonversi ke Money", value)
+	}
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+// NormalizeCurrency mengembalikan kode mata uang dalam huruf besar, atau
+// DefaultCurrency jika kosong.
+func NormalizeCurrency(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return DefaultCurrency
+	}
+	return code
+}
@@ -0,0 +1,95 @@
+package casts_test
+
+import (
+	"encoding/json"
+
+	"golang_starter_kit_2025/app/casts"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Money", func() {
+	It("should parse decimal strings exactly", func() {
+		money, err := casts.ParseMoney("1250000.50")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(money).To(Equal(casts.Money(125000050)))
+
+		money, err = casts.ParseMoney("-0.5")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(money.String()).To(Equal("-0.50"))
+	})
+
+	It("should round more than two decimals half away from zero", func() {
+		Expect(casts.ParseMoney("99.995")).To(Equal(casts.Money(10000)))
+		Expect(casts.ParseMoney("-1.005")).To(Equal(casts.Money(-101)))
+		Expect(casts.ParseMoney("1.004")).To(Equal(casts.Money(100)))
+	})
+
+	It("should reject invalid input", func() {
+		for _, value := range []string{"", ".", "1,5", "abc", "1.2.3"} {
+			_, err := casts.ParseMoney(value)
+			Expect(err).To(MatchError(casts.ErrInvalidMoney), value)
+		}
+	})
+
+	It("should add without float rounding errors", func() {
+		var total casts.Money
+		for i := 0; i < 10; i++ {
+			total = total.Add(casts.NewMoney(0.1))
+		}
+
+		Expect(total.String()).To(Equal("1.00"))
+	})
+
+	It("should multiply by quantity", func() {
+		Expect(casts.NewMoney(19.99).Mul(3).String()).To(Equal("59.97"))
+		Expect(casts.NewMoney(10.01).Mul(0.5).String()).To(Equal("5.01"))
+		Expect(casts.NewMoney(-10.01).Mul(0.5).String()).To(Equal("-5.01"))
+	})
+
+	It("should multiply and divide proportionally", func() {
+		// PPN 11% dari 10.000,05
+		Expect(casts.NewMoney(10000.05).MulDiv(1100, 10000).String()).To(Equal("1100.01"))
+		Expect(casts.NewMoney(100).MulDiv(1, 3).String()).To(Equal("33.33"))
+		Expect(casts.NewMoney(-0.05).MulDiv(1, 2).String()).To(Equal("-0.03"))
+		Expect(casts.NewMoney(100).MulDiv(1, 0)).To(BeZero())
+	})
+
+	It("should marshal JSON as number and unmarshal number or string", func() {
+		data, err := json.Marshal(map[string]casts.Money{"price": casts.NewMoney(1500.5)})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(`{"price":1500.50}`))
+
+		var payload struct {
+			Number casts.Money `json:"number"`
+			Text   casts.Money `json:"text"`
+			Empty  casts.Money `json:"empty"`
+		}
+		Expect(json.Unmarshal([]byte(`{"number":1500.5,"text":"2500.25","empty":null}`), &payload)).To(Succeed())
+		Expect(payload.Number.String()).To(Equal("1500.50"))
+		Expect(payload.Text.String()).To(Equal("2500.25"))
+		Expect(payload.Empty).To(BeZero())
+	})
+
+	It("should scan database values", func() {
+		var money casts.Money
+
+		Expect(money.Scan([]byte("12.30"))).To(Succeed())
+		Expect(money).To(Equal(casts.Money(1230)))
+		Expect(money.Scan(int64(7))).To(Succeed())
+		Expect(money).To(Equal(casts.Money(700)))
+		Expect(money.Scan(nil)).To(Succeed())
+		Expect(money).To(BeZero())
+
+		value, err := casts.NewMoney(12.3).Value()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(value).To(Equal("12.30"))
+	})
+
+	It("should default currency to IDR", func() {
+		Expect(casts.NormalizeCurrency("")).To(Equal("IDR"))
+		Expect(casts.NormalizeCurrency("usd")).To(Equal("USD"))
+		Expect(casts.NewMoney(1000).Format("")).To(Equal("IDR 1000.00"))
+	})
+})
@@ -0,0 +1,19 @@
+package casts
+
+const (
+	GenderMale   = "pria"
+	GenderFemale = "wanita"
+)
+
+// NIKData adalah hasil decode Nomor Induk Kependudukan (NIK).
+type NIKData struct {
+	ProvinceCode string `json:"province_code"`
+	Province     string `json:"province"`
+	RegencyCode  string `json:"regency_code"`
+	Regency      string `json:"regency"`
+	DistrictCode string `json:"district_code"`
+	District     string `json:"district"`
+	BirthDate    string `json:"birth_date" example:"1990-01-31"`
+	Gender       string `json:"gender" enums:"pria,wanita"`
+	Serial       string `json:"serial"`
+}
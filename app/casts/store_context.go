@@ -0,0 +1,22 @@
+package casts
+
+// StoreContext menyimpan toko yang boleh diakses user pada request berjalan.
+type StoreContext struct {
+	UserID   uint   `json:"user_id"`
+	StoreID  uint   `json:"store_id"`  // toko aktif dari header X-Store-ID atau claim store_id, 0 jika tidak dipilih
+	StoreIDs []uint `json:"store_ids"` // toko tempat user terdaftar
+	IsAdmin  bool   `json:"is_admin"`  // admin dapat melihat seluruh toko
+}
+
+// CanAccess mengecek apakah user boleh mengakses toko storeID.
+func (s StoreContext) CanAccess(storeID uint) bool {
+	if s.IsAdmin {
+		return true
+	}
+	for _, id := range s.StoreIDs {
+		if id == storeID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,28 @@
+package casts_test
+
+import (
+	"golang_starter_kit_2025/app/casts"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StoreContext", func() {
+	Context("when user is a member of some stores", func() {
+		It("should only access those stores", func() {
+			store := casts.StoreContext{UserID: 1, StoreIDs: []uint{2, 3}}
+
+			Expect(store.CanAccess(2)).To(BeTrue())
+			Expect(store.CanAccess(3)).To(BeTrue())
+			Expect(store.CanAccess(4)).To(BeFalse())
+		})
+	})
+
+	Context("when user is admin", func() {
+		It("should access all stores", func() {
+			store := casts.StoreContext{UserID: 1, IsAdmin: true}
+
+			Expect(store.CanAccess(99)).To(BeTrue())
+		})
+	})
+})
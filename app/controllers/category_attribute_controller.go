@@ -0,0 +1,122 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"golang_starter_kit_2025/app/helpers"
+	"golang_starter_kit_2025/app/models"
+	"golang_starter_kit_2025/app/requests"
+	"golang_starter_kit_2025/app/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+type CategoryAttributeController struct {
+	service *services.CategoryAttributeService
+}
+
+func NewCategoryAttributeController() *CategoryAttributeController {
+	return &CategoryAttributeController{
+		service: services.NewCategoryAttributeService(),
+	}
+}
+
+// categoryAttributeErrorCode memetakan error dari CategoryAttributeService ke HTTP status code.
+func categoryAttributeErrorCode(err error, fallback int) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrInvalidAttribute):
+		return http.StatusUnprocessableEntity
+	}
+	return fallback
+}
+
+// @Summary		Get category attributes
+// @Description	API untuk mendapatkan daftar atribut varian pada kategori
+// @Tags			categories
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int	true	"Category ID"
+// @Success		200	{object}	helpers.ResponseParams[models.CategoryAttribute]{data=[]models.CategoryAttribute}
+// @Router			/categories/{id}/attributes [get]
+func (c *CategoryAttributeController) List(ctx *gin.Context) {
+	attributes, err := c.service.GetAll(ctx.Param("id"))
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mendapatkan daftar atribut kategori",
+			Reference: "ERROR-2",
+			Errors:    map[string]string{"error": err.Error()},
+		}, categoryAttributeErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.CategoryAttribute]{Data: &attributes}, http.StatusOK)
+}
+
+// @Summary		Create/Update category attribute
+// @Description	API untuk membuat atau mengupdate atribut varian pada kategori
+// @Tags			categories
+// @Accept			json
+// @Produce		json
+// @Param			id			path		int									true	"Category ID"
+// @Param			attribute	body		requests.CategoryAttributeRequest	true	"Category attribute request body"
+// @Success		200			{object}	helpers.ResponseParams[models.CategoryAttribute]{item=models.CategoryAttribute}
+// @Router			/categories/{id}/attributes [put]
+func (c *CategoryAttributeController) Put(ctx *gin.Context) {
+	var request requests.CategoryAttributeRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		var verr validator.ValidationErrors
+		if errors.As(err, &verr) {
+			helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+				Message:   "Periksa kembali form anda",
+				Errors:    helpers.ValidationError(verr),
+				Reference: "ERROR-4",
+			}, http.StatusBadRequest)
+			return
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Errors:    map[string]string{"error": err.Error()},
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	attribute, err := c.service.Put(ctx.Param("id"), request)
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal membuat atau mengupdate atribut kategori",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, categoryAttributeErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.CategoryAttribute]{Item: attribute}, http.StatusOK)
+}
+
+// @Summary		Delete category attribute
+// @Description	API untuk menghapus atribut kategori beserta nilainya pada produk
+// @Tags			categories
+// @Accept			json
+// @Produce		json
+// @Param			id				path		int	true	"Category ID"
+// @Param			attribute_id	path		int	true	"Category attribute ID"
+// @Success		200				{object}	helpers.ResponseParams[any]
+// @Router			/categories/{id}/attributes/{attribute_id} [delete]
+func (c *CategoryAttributeController) Delete(ctx *gin.Context) {
+	if err := c.service.Delete(ctx.Param("id"), ctx.Param("attribute_id")); err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal menghapus atribut kategori",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, categoryAttributeErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[any]{}, http.StatusOK)
+}
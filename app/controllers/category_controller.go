@@ -1,16 +1,35 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 	"time"
 
+	"golang_starter_kit_2025/app/helpers"
 	"golang_starter_kit_2025/app/models"
 	"golang_starter_kit_2025/app/requests"
 	"golang_starter_kit_2025/app/services"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
+// categoryErrorCode memetakan error dari CategoryService ke HTTP status code.
+func categoryErrorCode(err error, fallback int) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrInvalidCategory):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, services.ErrCategoryInUse):
+		return http.StatusConflict
+	case errors.Is(err, services.ErrVersionRequired):
+		return http.StatusPreconditionRequired
+	}
+	return fallback
+}
+
 type CategoryController struct {
 	service services.CategoryService
 }
@@ -37,11 +56,11 @@ func (c *CategoryController) List(ctx *gin.Context) {
 }
 
 // @Summary		Get a category by ID
-// @Description	Retrieve a category by its ID, including related products
+// @Description	Retrieve a category by its ID or slug, including parent, children and product count
 // @Tags			categories
 // @Security		Bearer
 // @Produce		json
-// @Param			id	path		string				true	"Category ID"
+// @Param			id	path		string				true	"Category ID or slug"
 // @Success		200	{object}	models.Category		"Category with products"
 // @Failure		404	{object}	map[string]string	"Category not found"
 // @Router			/categories/{id} [get]
@@ -52,11 +71,98 @@ func (c *CategoryController) Get(ctx *gin.Context) {
 		ctx.JSON(http.StatusNotFound, gin.H{"error": "Category not found"})
 		return
 	}
+	helpers.SetETag(ctx, category.Version)
 	ctx.JSON(http.StatusOK, category)
 }
 
+// @Summary		Create a category
+// @Description	Create a new category, slug defaults to the category name
+// @Tags			categories
+// @Security		Bearer
+// @Accept			json
+// @Produce		json
+// @Param			category	body		requests.CategoryRequest	true	"Category Data"
+// @Success		201			{object}	models.Category				"Created category"
+// @Failure		400			{object}	map[string]string			"Invalid input data"
+// @Failure		500			{object}	map[string]string			"Internal Server Error"
+// @Router			/categories [post]
+func (c *CategoryController) Create(ctx *gin.Context) {
+	var req requests.CategoryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	req.ID = 0
+	c.save(ctx, req, http.StatusCreated)
+}
+
+// @Summary		Replace a category
+// @Description	Replace all fields of a category, an empty slug is generated from the name and a changed parent moves the category
+// @Tags			categories
+// @Security		Bearer
+// @Accept			json
+// @Produce		json
+// @Param			id			path		int							true	"Category ID"
+// @Param			If-Match	header		string						false	"Category version, e.g. W/\"3\""
+// @Param			category	body		requests.CategoryRequest	true	"Category Data"
+// @Success		200			{object}	models.Category				"Replaced category"
+// @Failure		400			{object}	map[string]string			"Invalid input data"
+// @Failure		404			{object}	map[string]string			"Category not found"
+// @Failure		409			{object}	map[string]interface{}		"Category was changed by another request, item contains the current category"
+// @Failure		428			{object}	map[string]string			"Version or If-Match header is required on update"
+// @Router			/categories/{id} [put]
+func (c *CategoryController) Replace(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var req requests.CategoryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	req.ID = uint(id)
+	c.save(ctx, req, http.StatusOK)
+}
+
+// @Summary		Patch a category
+// @Description	Update some fields of a category with JSON Merge Patch (RFC 7396), {"parent_id": null} moves the category to the root
+// @Tags			categories
+// @Security		Bearer
+// @Accept			application/merge-patch+json
+// @Produce		json
+// @Param			id			path		string						true	"Category ID or slug"
+// @Param			If-Match	header		string						false	"Category version, e.g. W/\"3\""
+// @Param			category	body		requests.CategoryRequest	true	"Changed category fields"
+// @Success		200			{object}	models.Category				"Patched category"
+// @Failure		400			{object}	map[string]string			"Invalid input data"
+// @Failure		404			{object}	map[string]string			"Category not found"
+// @Failure		409			{object}	map[string]interface{}		"Category was changed by another request, item contains the current category"
+// @Failure		415			{object}	map[string]string			"Unsupported content type"
+// @Failure		428			{object}	map[string]string			"Version or If-Match header is required on update"
+// @Router			/categories/{id} [patch]
+func (c *CategoryController) Patch(ctx *gin.Context) {
+	category, err := c.service.GetCategoryByID(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Category not found"})
+		return
+	}
+
+	req := requests.NewCategoryRequest(category)
+	if err := helpers.BindMergePatch(ctx, &req); err != nil {
+		ctx.JSON(bindErrorCode(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	req.ID = category.ID
+	c.save(ctx, req, http.StatusOK)
+}
+
 // @Summary		Create or update a category
-// @Description	Create a new category or update an existing one by ID
+// @Description	Create a new category or replace an existing one by ID, use POST, PUT /categories/{id} or PATCH /categories/{id} instead
 // @Tags			categories
 // @Security		Bearer
 // @Accept			json
@@ -64,7 +170,10 @@ func (c *CategoryController) Get(ctx *gin.Context) {
 // @Param			category	body		requests.CategoryRequest	true	"Category Data"
 // @Success		200			{object}	models.Category				"Created or updated category"
 // @Failure		400			{object}	map[string]string			"Invalid input data"
+// @Failure		409			{object}	map[string]interface{}		"Category was changed by another request, item contains the current category"
+// @Failure		428			{object}	map[string]string			"Version or If-Match header is required on update"
 // @Failure		500			{object}	map[string]string			"Internal Server Error"
+// @Deprecated
 // @Router			/categories [put]
 func (c *CategoryController) Put(ctx *gin.Context) {
 	var req requests.CategoryRequest
@@ -73,20 +182,39 @@ func (c *CategoryController) Put(ctx *gin.Context) {
 		return
 	}
 
+	c.save(ctx, req, http.StatusOK)
+}
+
+// save menjalankan create atau replace kategori dan memetakan error service ke response.
+func (c *CategoryController) save(ctx *gin.Context, req requests.CategoryRequest, status int) {
 	// Convert request to model
 	category := models.Category{
 		ID:        req.ID,
+		ParentID:  req.ParentID,
 		Category:  req.Category,
+		Slug:      req.Slug,
+		Version:   req.Version,
 		UpdatedAt: time.Now(),
 	}
+	if category.Version == 0 {
+		category.Version = helpers.IfMatchVersion(ctx)
+	}
 
 	updatedCategory, err := c.service.PutCategory(category)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if errors.Is(err, services.ErrVersionConflict) {
+			if current, findErr := c.service.GetCategoryByID(strconv.FormatUint(uint64(req.ID), 10)); findErr == nil {
+				helpers.SetETag(ctx, current.Version)
+				ctx.JSON(http.StatusConflict, gin.H{"error": err.Error(), "item": current})
+				return
+			}
+		}
+		ctx.JSON(categoryErrorCode(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
 		return
 	}
 
-	ctx.JSON(http.StatusOK, updatedCategory)
+	helpers.SetETag(ctx, updatedCategory.Version)
+	ctx.JSON(status, updatedCategory)
 }
 
 // @Summary		Delete a category by ID
@@ -97,13 +225,88 @@ func (c *CategoryController) Put(ctx *gin.Context) {
 // @Param			id	path		string				true	"Category ID"
 // @Success		200	{object}	map[string]string	"Category deleted successfully"
 // @Failure		404	{object}	map[string]string	"Category not found"
+// @Failure		409	{object}	map[string]string	"Category still has products or sub categories"
 // @Failure		500	{object}	map[string]string	"Internal Server Error"
 // @Router			/categories/{id} [delete]
 func (c *CategoryController) Delete(ctx *gin.Context) {
 	id := ctx.Param("id")
 	if err := c.service.DeleteCategory(id); err != nil {
+		if errors.Is(err, services.ErrCategoryInUse) {
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		ctx.JSON(http.StatusNotFound, gin.H{"error": "Category not found"})
 		return
 	}
 	ctx.JSON(http.StatusOK, gin.H{"message": "Category deleted"})
 }
+
+// @Summary		Get category tree
+// @Description	Retrieve all categories as a tree, product counts include descendant categories
+// @Tags			categories
+// @Security		Bearer
+// @Produce		json
+// @Success		200	{array}		models.Category		"Root categories with children"
+// @Failure		500	{object}	map[string]string	"Internal Server Error"
+// @Router			/categories/tree [get]
+func (c *CategoryController) Tree(ctx *gin.Context) {
+	tree, err := c.service.Tree()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, tree)
+}
+
+// @Summary		Move a category
+// @Description	Move a category and its descendants to another parent and position
+// @Tags			categories
+// @Security		Bearer
+// @Accept			json
+// @Produce		json
+// @Param			id		path		string							true	"Category ID"
+// @Param			move	body		requests.CategoryMoveRequest	true	"Target parent and position"
+// @Success		200		{object}	models.Category					"Moved category"
+// @Failure		400		{object}	map[string]string				"Invalid input data"
+// @Failure		404		{object}	map[string]string				"Category not found"
+// @Failure		422		{object}	map[string]string				"Invalid target parent"
+// @Router			/categories/{id}/move [post]
+func (c *CategoryController) Move(ctx *gin.Context) {
+	var req requests.CategoryMoveRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	category, err := c.service.Move(ctx.Param("id"), req.ParentID, req.Position)
+	if err != nil {
+		ctx.JSON(categoryErrorCode(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, category)
+}
+
+// @Summary		Reorder sibling categories
+// @Description	Set the order of categories under the same parent, unlisted categories are kept at the end
+// @Tags			categories
+// @Security		Bearer
+// @Accept			json
+// @Produce		json
+// @Param			reorder	body		requests.CategoryReorderRequest	true	"Parent and ordered category IDs"
+// @Success		200		{object}	map[string]string				"Categories reordered"
+// @Failure		400		{object}	map[string]string				"Invalid input data"
+// @Failure		422		{object}	map[string]string				"Category is not a child of the parent"
+// @Router			/categories/reorder [post]
+func (c *CategoryController) Reorder(ctx *gin.Context) {
+	var req requests.CategoryReorderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.service.Reorder(req.ParentID, req.IDs); err != nil {
+		ctx.JSON(categoryErrorCode(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Categories reordered"})
+}
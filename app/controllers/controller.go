@@ -1,7 +1,10 @@
 package controllers
 
 import (
+	"errors"
 	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"golang_starter_kit_2025/app/helpers"
 	"net/http"
 )
 
@@ -16,3 +19,42 @@ type Controller struct{}
 func (*Controller) HelloWorld(ctx *gin.Context) {
 	ctx.IndentedJSON(http.StatusOK, "Hello World")
 }
+
+// respondVersionConflict mengirim 409 beserta data terbaru dan ETag-nya
+// sehingga client dapat menggabungkan perubahan lalu mengirim ulang.
+func respondVersionConflict(ctx *gin.Context, message string, err error, current any, version uint) {
+	helpers.SetETag(ctx, version)
+	helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+		Message:   message,
+		Reference: "ERROR-3",
+		Errors:    map[string]string{"error": err.Error()},
+		Item:      &current,
+	}, http.StatusConflict)
+}
+
+// respondBindError mengirim error binding body atau merge patch. Content type
+// PATCH yang tidak didukung dijawab 415.
+func respondBindError(ctx *gin.Context, err error) {
+	var verr validator.ValidationErrors
+	if errors.As(err, &verr) {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Periksa kembali form anda",
+			Errors:    helpers.ValidationError(verr),
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+		Message:   "Parameter tidak valid",
+		Errors:    map[string]string{"error": err.Error()},
+		Reference: "ERROR-4",
+	}, bindErrorCode(err))
+}
+
+func bindErrorCode(err error) int {
+	if errors.Is(err, helpers.ErrMergePatchContentType) {
+		return http.StatusUnsupportedMediaType
+	}
+	return http.StatusBadRequest
+}
@@ -0,0 +1,499 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"golang_starter_kit_2025/app/helpers"
+	"golang_starter_kit_2025/app/models"
+	"golang_starter_kit_2025/app/requests"
+	"golang_starter_kit_2025/app/responses"
+	"golang_starter_kit_2025/app/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+type CostBudgetPlanController struct {
+	service            *services.CostBudgetPlanService
+	workflowService    *services.CostBudgetPlanWorkflowService
+	realisationService *services.CostBudgetPlanRealisationService
+}
+
+func NewCostBudgetPlanController() *CostBudgetPlanController {
+	return &CostBudgetPlanController{
+		service:            services.NewCostBudgetPlanService(),
+		workflowService:    services.NewCostBudgetPlanWorkflowService(),
+		realisationService: services.NewCostBudgetPlanRealisationService(),
+	}
+}
+
+// costBudgetPlanErrorCode memetakan error dari CostBudgetPlanService ke HTTP status code.
+func costBudgetPlanErrorCode(err error, fallback int) int {
+	switch {
+	case errors.Is(err, services.ErrStoreForbidden), errors.Is(err, services.ErrPermissionDenied):
+		return http.StatusForbidden
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrPlanNotEditable), errors.Is(err, models.ErrInvalidTransition), errors.Is(err, services.ErrWizardStep), errors.Is(err, services.ErrPlanNotRealisable), errors.Is(err, services.ErrInsufficientStock):
+		return http.StatusConflict
+	case errors.Is(err, services.ErrWizardExpired):
+		return http.StatusGone
+	case errors.Is(err, services.ErrInvalidPlan), errors.Is(err, services.ErrOfferingInactive), errors.Is(err, services.ErrCommentRequired), errors.Is(err, services.ErrCurrencyMismatch):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, services.ErrVersionRequired):
+		return http.StatusPreconditionRequired
+	}
+	return fallback
+}
+
+// @Summary		Get all cost budget plans
+// @Description	API untuk mendapatkan daftar RAB, dapat difilter per member, toko dan status
+// @Tags			CostBudgetPlan
+// @Accept			json
+// @Produce		json
+// @Param			request	query		requests.CostBudgetPlanFilterRequest	false	"Filter request"
+// @Success		200		{object}	helpers.ResponseParams[models.CostBudgetPlan]{data=[]models.CostBudgetPlan}
+// @Router			/cost-budget-plans [get]
+func (c *CostBudgetPlanController) List(ctx *gin.Context) {
+	var filters requests.CostBudgetPlanFilterRequest
+	if err := ctx.ShouldBindQuery(&filters); err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	plans, total, err := c.service.GetAll(helpers.GetStoreContext(ctx), filters)
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mendapatkan daftar RAB",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.CostBudgetPlan]{Data: &plans, Total: &total}, http.StatusOK)
+}
+
+// @Summary		Get cost budget plan by ID
+// @Description	API untuk mendapatkan RAB beserta item per fase
+// @Tags			CostBudgetPlan
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int	true	"Cost budget plan ID"
+// @Success		200	{object}	helpers.ResponseParams[models.CostBudgetPlan]{item=models.CostBudgetPlan}
+// @Router			/cost-budget-plans/{id} [get]
+func (c *CostBudgetPlanController) Get(ctx *gin.Context) {
+	plan, err := c.service.GetByID(helpers.GetStoreContext(ctx), ctx.Param("id"))
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mendapatkan RAB",
+			Reference: "ERROR-2",
+			Errors:    map[string]string{"error": err.Error()},
+		}, costBudgetPlanErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.SetETag(ctx, plan.Version)
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.CostBudgetPlan]{Item: &plan}, http.StatusOK)
+}
+
+// @Summary		Create/Update cost budget plan
+// @Description	API untuk membuat atau mengupdate RAB member
+// @Tags			CostBudgetPlan
+// @Accept			json
+// @Produce		json
+// @Param			If-Match	header		string								false	"Version RAB saat update, contoh W/\"3\""
+// @Param			plan		body		requests.CostBudgetPlanRequestPut	true	"Cost budget plan request body"
+// @Success		200			{object}	helpers.ResponseParams[models.CostBudgetPlan]{item=models.CostBudgetPlan}
+// @Router			/cost-budget-plans [put]
+func (c *CostBudgetPlanController) Put(ctx *gin.Context) {
+	var request requests.CostBudgetPlanRequestPut
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		var verr validator.ValidationErrors
+		if errors.As(err, &verr) {
+			helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+				Message:   "Periksa kembali form anda",
+				Errors:    helpers.ValidationError(verr),
+				Reference: "ERROR-4",
+			}, http.StatusBadRequest)
+			return
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Errors:    map[string]string{"error": err.Error()},
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	if request.Version == 0 {
+		request.Version = helpers.IfMatchVersion(ctx)
+	}
+
+	store := helpers.GetStoreContext(ctx)
+	plan, err := c.service.Put(store, request)
+	if err != nil {
+		if errors.Is(err, services.ErrVersionConflict) {
+			if current, findErr := c.service.GetByID(store, strconv.FormatUint(uint64(request.ID), 10)); findErr == nil {
+				respondVersionConflict(ctx, "RAB sudah diubah oleh user lain", err, current, current.Version)
+				return
+			}
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal membuat atau mengupdate RAB",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, costBudgetPlanErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.SetETag(ctx, plan.Version)
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.CostBudgetPlan]{Item: plan}, http.StatusOK)
+}
+
+// @Summary		Delete cost budget plan
+// @Description	API untuk menghapus RAB yang masih draft
+// @Tags			CostBudgetPlan
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int	true	"Cost budget plan ID"
+// @Success		200	{object}	helpers.ResponseParams[any]
+// @Router			/cost-budget-plans/{id} [delete]
+func (c *CostBudgetPlanController) Delete(ctx *gin.Context) {
+	if err := c.service.Delete(helpers.GetStoreContext(ctx), ctx.Param("id")); err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal menghapus RAB",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, costBudgetPlanErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[any]{}, http.StatusOK)
+}
+
+// @Summary		Create/Update cost budget plan item
+// @Description	API untuk menambah atau mengubah item RAB. Subtotal dihitung dari harga product offering dikali quantity
+// @Tags			CostBudgetPlan
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int										true	"Cost budget plan ID"
+// @Param			item	body		requests.CostBudgetPlanItemRequestPut	true	"Cost budget plan item request body"
+// @Success		200		{object}	helpers.ResponseParams[models.CostBudgetPlan]{item=models.CostBudgetPlan}
+// @Router			/cost-budget-plans/{id}/items [put]
+func (c *CostBudgetPlanController) PutItem(ctx *gin.Context) {
+	var request requests.CostBudgetPlanItemRequestPut
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		var verr validator.ValidationErrors
+		if errors.As(err, &verr) {
+			helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+				Message:   "Periksa kembali form anda",
+				Errors:    helpers.ValidationError(verr),
+				Reference: "ERROR-4",
+			}, http.StatusBadRequest)
+			return
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Errors:    map[string]string{"error": err.Error()},
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	plan, err := c.service.PutItem(helpers.GetStoreContext(ctx), ctx.Param("id"), request)
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal menyimpan item RAB",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, costBudgetPlanErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.CostBudgetPlan]{Item: plan}, http.StatusOK)
+}
+
+// @Summary		Delete cost budget plan item
+// @Description	API untuk menghapus item RAB
+// @Tags			CostBudgetPlan
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int	true	"Cost budget plan ID"
+// @Param			item_id	path		int	true	"Cost budget plan item ID"
+// @Success		200		{object}	helpers.ResponseParams[models.CostBudgetPlan]{item=models.CostBudgetPlan}
+// @Router			/cost-budget-plans/{id}/items/{item_id} [delete]
+func (c *CostBudgetPlanController) DeleteItem(ctx *gin.Context) {
+	plan, err := c.service.DeleteItem(helpers.GetStoreContext(ctx), ctx.Param("id"), ctx.Param("item_id"))
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal menghapus item RAB",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, costBudgetPlanErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.CostBudgetPlan]{Item: plan}, http.StatusOK)
+}
+
+// @Summary		Get phases
+// @Description	API untuk mendapatkan daftar fase budidaya untuk item RAB
+// @Tags			CostBudgetPlan
+// @Accept			json
+// @Produce		json
+// @Success		200	{object}	helpers.ResponseParams[models.Phase]{data=[]models.Phase}
+// @Router			/phases [get]
+func (c *CostBudgetPlanController) Phases(ctx *gin.Context) {
+	phases, err := c.service.GetPhases()
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mendapatkan daftar fase",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.Phase]{Data: &phases}, http.StatusOK)
+}
+
+// @Summary		Transition cost budget plan status
+// @Description	API untuk menjalankan aksi workflow RAB: submit, approve, reject (wajib komentar), revise, disburse, close
+// @Tags			CostBudgetPlan
+// @Accept			json
+// @Produce		json
+// @Param			id			path		int											true	"Cost budget plan ID"
+// @Param			transition	body		requests.CostBudgetPlanTransitionRequest	true	"Transition request body"
+// @Success		200			{object}	helpers.ResponseParams[models.CostBudgetPlan]{item=models.CostBudgetPlan}
+// @Router			/cost-budget-plans/{id}/transitions [post]
+func (c *CostBudgetPlanController) Transition(ctx *gin.Context) {
+	var request requests.CostBudgetPlanTransitionRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		var verr validator.ValidationErrors
+		if errors.As(err, &verr) {
+			helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+				Message:   "Periksa kembali form anda",
+				Errors:    helpers.ValidationError(verr),
+				Reference: "ERROR-4",
+			}, http.StatusBadRequest)
+			return
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Errors:    map[string]string{"error": err.Error()},
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	plan, err := c.workflowService.Transition(helpers.GetStoreContext(ctx), ctx.Param("id"), request)
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mengubah status RAB",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, costBudgetPlanErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.CostBudgetPlan]{Item: plan}, http.StatusOK)
+}
+
+// @Summary		Get cost budget plan transition history
+// @Description	API untuk mendapatkan riwayat perubahan status RAB
+// @Tags			CostBudgetPlan
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int	true	"Cost budget plan ID"
+// @Success		200	{object}	helpers.ResponseParams[models.CostBudgetPlanTransition]{data=[]models.CostBudgetPlanTransition}
+// @Router			/cost-budget-plans/{id}/transitions [get]
+func (c *CostBudgetPlanController) Transitions(ctx *gin.Context) {
+	transitions, err := c.workflowService.History(helpers.GetStoreContext(ctx), ctx.Param("id"))
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mendapatkan riwayat RAB",
+			Reference: "ERROR-2",
+			Errors:    map[string]string{"error": err.Error()},
+		}, costBudgetPlanErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.CostBudgetPlanTransition]{Data: &transitions}, http.StatusOK)
+}
+
+// @Summary		Get store approval levels
+// @Description	API untuk mendapatkan konfigurasi level approval RAB toko
+// @Tags			Store
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int	true	"Store ID"
+// @Success		200	{object}	helpers.ResponseParams[models.CostBudgetPlanApprovalLevel]{data=[]models.CostBudgetPlanApprovalLevel}
+// @Router			/stores/{id}/approval-levels [get]
+func (c *CostBudgetPlanController) ApprovalLevels(ctx *gin.Context) {
+	storeID, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	levels, err := c.workflowService.GetApprovalLevels(helpers.GetStoreContext(ctx), uint(storeID))
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mendapatkan level approval",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, costBudgetPlanErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.CostBudgetPlanApprovalLevel]{Data: &levels}, http.StatusOK)
+}
+
+// @Summary		Update store approval levels
+// @Description	API untuk mengganti level approval RAB toko. Setiap level berlaku untuk RAB dengan total minimal min_amount dan disetujui oleh role_id
+// @Tags			Store
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int												true	"Store ID"
+// @Param			levels	body		requests.CostBudgetPlanApprovalLevelRequestPut	true	"Approval levels"
+// @Success		200		{object}	helpers.ResponseParams[models.CostBudgetPlanApprovalLevel]{data=[]models.CostBudgetPlanApprovalLevel}
+// @Router			/stores/{id}/approval-levels [put]
+func (c *CostBudgetPlanController) PutApprovalLevels(ctx *gin.Context) {
+	storeID, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	var request requests.CostBudgetPlanApprovalLevelRequestPut
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		var verr validator.ValidationErrors
+		if errors.As(err, &verr) {
+			helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+				Message:   "Periksa kembali form anda",
+				Errors:    helpers.ValidationError(verr),
+				Reference: "ERROR-4",
+			}, http.StatusBadRequest)
+			return
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Errors:    map[string]string{"error": err.Error()},
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	levels, err := c.workflowService.PutApprovalLevels(helpers.GetStoreContext(ctx), uint(storeID), request)
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal menyimpan level approval",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, costBudgetPlanErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.CostBudgetPlanApprovalLevel]{Data: &levels}, http.StatusOK)
+}
+
+// @Summary		Create/Update cost budget plan item realisation
+// @Description	API untuk mencatat atau mengoreksi realisasi item RAB (produk yang diberikan, jumlah dan tanggal). RAB harus berstatus approved atau disbursed dan produk harus penyusun product offering item, biaya dihitung dari harga offering pada tanggal realisasi. Quantity dalam satuan produk dan mengurangi stok produk (koreksi memposting selisihnya)
+// @Tags			CostBudgetPlan
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int											true	"Cost budget plan ID"
+// @Param			item_id	path		int											true	"Cost budget plan item ID"
+// @Param			history	body		requests.CostBudgetPlanDetailHistoryRequest	true	"Realisation request body"
+// @Success		200		{object}	helpers.ResponseParams[models.CostBudgetPlanItemHistory]{item=models.CostBudgetPlanItemHistory}
+// @Router			/cost-budget-plans/{id}/items/{item_id}/histories [put]
+func (c *CostBudgetPlanController) PutHistory(ctx *gin.Context) {
+	var request requests.CostBudgetPlanDetailHistoryRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		var verr validator.ValidationErrors
+		if errors.As(err, &verr) {
+			helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+				Message:   "Periksa kembali form anda",
+				Errors:    helpers.ValidationError(verr),
+				Reference: "ERROR-4",
+			}, http.StatusBadRequest)
+			return
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Errors:    map[string]string{"error": err.Error()},
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	history, err := c.realisationService.PutHistory(helpers.GetStoreContext(ctx), ctx.Param("id"), ctx.Param("item_id"), request)
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal menyimpan realisasi item RAB",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, costBudgetPlanErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.CostBudgetPlanItemHistory]{Item: history}, http.StatusOK)
+}
+
+// @Summary		Get cost budget plan item realisations
+// @Description	API untuk mendapatkan riwayat realisasi item RAB
+// @Tags			CostBudgetPlan
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int	true	"Cost budget plan ID"
+// @Param			item_id	path		int	true	"Cost budget plan item ID"
+// @Success		200		{object}	helpers.ResponseParams[models.CostBudgetPlanItemHistory]{data=[]models.CostBudgetPlanItemHistory}
+// @Router			/cost-budget-plans/{id}/items/{item_id}/histories [get]
+func (c *CostBudgetPlanController) Histories(ctx *gin.Context) {
+	histories, err := c.realisationService.Histories(helpers.GetStoreContext(ctx), ctx.Param("id"), ctx.Param("item_id"))
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mendapatkan realisasi item RAB",
+			Reference: "ERROR-2",
+			Errors:    map[string]string{"error": err.Error()},
+		}, costBudgetPlanErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.CostBudgetPlanItemHistory]{Data: &histories}, http.StatusOK)
+}
+
+// @Summary		Get cost budget plan realisation
+// @Description	API untuk mendapatkan perbandingan rencana dan realisasi RAB per fase
+// @Tags			CostBudgetPlan
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int	true	"Cost budget plan ID"
+// @Success		200	{object}	helpers.ResponseParams[responses.CostBudgetPlanRealisation]{item=responses.CostBudgetPlanRealisation}
+// @Router			/cost-budget-plans/{id}/realisation [get]
+func (c *CostBudgetPlanController) Realisation(ctx *gin.Context) {
+	realisation, err := c.realisationService.Breakdown(helpers.GetStoreContext(ctx), ctx.Param("id"))
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mendapatkan realisasi RAB",
+			Reference: "ERROR-2",
+			Errors:    map[string]string{"error": err.Error()},
+		}, costBudgetPlanErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[responses.CostBudgetPlanRealisation]{Item: realisation}, http.StatusOK)
+}
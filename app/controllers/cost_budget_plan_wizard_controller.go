@@ -0,0 +1,151 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"golang_starter_kit_2025/app/helpers"
+	"golang_starter_kit_2025/app/models"
+	"golang_starter_kit_2025/app/requests"
+	"golang_starter_kit_2025/app/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type CostBudgetPlanWizardController struct {
+	service *services.CostBudgetPlanWizardService
+}
+
+func NewCostBudgetPlanWizardController() *CostBudgetPlanWizardController {
+	return &CostBudgetPlanWizardController{
+		service: services.NewCostBudgetPlanWizardService(),
+	}
+}
+
+// @Summary		Get cost budget plan wizards
+// @Description	API untuk mendapatkan draft wizard RAB milik user yang belum kedaluwarsa
+// @Tags			CostBudgetPlan
+// @Accept			json
+// @Produce		json
+// @Success		200	{object}	helpers.ResponseParams[models.CostBudgetPlanWizard]{data=[]models.CostBudgetPlanWizard}
+// @Router			/cost-budget-plan-wizards [get]
+func (c *CostBudgetPlanWizardController) List(ctx *gin.Context) {
+	wizards, err := c.service.GetAll(helpers.GetStoreContext(ctx))
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mendapatkan draft RAB",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.CostBudgetPlanWizard]{Data: &wizards}, http.StatusOK)
+}
+
+// @Summary		Get cost budget plan wizard by ID
+// @Description	API untuk melanjutkan draft wizard RAB
+// @Tags			CostBudgetPlan
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int	true	"Wizard ID"
+// @Success		200	{object}	helpers.ResponseParams[models.CostBudgetPlanWizard]{item=models.CostBudgetPlanWizard}
+// @Router			/cost-budget-plan-wizards/{id} [get]
+func (c *CostBudgetPlanWizardController) Get(ctx *gin.Context) {
+	wizard, err := c.service.GetByID(helpers.GetStoreContext(ctx), ctx.Param("id"))
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mendapatkan draft RAB",
+			Reference: "ERROR-2",
+			Errors:    map[string]string{"error": err.Error()},
+		}, costBudgetPlanErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.CostBudgetPlanWizard]{Item: &wizard}, http.StatusOK)
+}
+
+// @Summary		Save cost budget plan wizard step
+// @Description	API untuk menyimpan satu langkah wizard RAB (member, land, crop, items, review). Kosongkan id untuk memulai wizard baru
+// @Tags			CostBudgetPlan
+// @Accept			json
+// @Produce		json
+// @Param			wizard	body		requests.CostBudgetPlanWizardRequestPut	true	"Wizard step request body"
+// @Success		200		{object}	helpers.ResponseParams[models.CostBudgetPlanWizard]{item=models.CostBudgetPlanWizard}
+// @Router			/cost-budget-plan-wizards [put]
+func (c *CostBudgetPlanWizardController) Put(ctx *gin.Context) {
+	var request requests.CostBudgetPlanWizardRequestPut
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		var verr validator.ValidationErrors
+		if errors.As(err, &verr) {
+			helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+				Message:   "Periksa kembali form anda",
+				Errors:    helpers.ValidationError(verr),
+				Reference: "ERROR-4",
+			}, http.StatusBadRequest)
+			return
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Errors:    map[string]string{"error": err.Error()},
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	wizard, err := c.service.Put(helpers.GetStoreContext(ctx), request)
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal menyimpan draft RAB",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, costBudgetPlanErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.CostBudgetPlanWizard]{Item: wizard}, http.StatusOK)
+}
+
+// @Summary		Finalize cost budget plan wizard
+// @Description	API untuk membuat RAB dari draft wizard yang sudah sampai langkah review
+// @Tags			CostBudgetPlan
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int	true	"Wizard ID"
+// @Success		201	{object}	helpers.ResponseParams[models.CostBudgetPlan]{item=models.CostBudgetPlan}
+// @Router			/cost-budget-plan-wizards/{id}/finalize [post]
+func (c *CostBudgetPlanWizardController) Finalize(ctx *gin.Context) {
+	plan, err := c.service.Finalize(helpers.GetStoreContext(ctx), ctx.Param("id"))
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal memfinalisasi draft RAB",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, costBudgetPlanErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.CostBudgetPlan]{Item: plan}, http.StatusCreated)
+}
+
+// @Summary		Delete cost budget plan wizard
+// @Description	API untuk membatalkan draft wizard RAB
+// @Tags			CostBudgetPlan
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int	true	"Wizard ID"
+// @Success		200	{object}	helpers.ResponseParams[any]
+// @Router			/cost-budget-plan-wizards/{id} [delete]
+func (c *CostBudgetPlanWizardController) Delete(ctx *gin.Context) {
+	if err := c.service.Delete(helpers.GetStoreContext(ctx), ctx.Param("id")); err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal menghapus draft RAB",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, costBudgetPlanErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[any]{}, http.StatusOK)
+}
@@ -0,0 +1,369 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"golang_starter_kit_2025/app/helpers"
+	"golang_starter_kit_2025/app/models"
+	"golang_starter_kit_2025/app/requests"
+	"golang_starter_kit_2025/app/responses"
+	"golang_starter_kit_2025/app/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+type MemberController struct {
+	service *services.MemberService
+}
+
+func NewMemberController() *MemberController {
+	return &MemberController{
+		service: services.NewMemberService(),
+	}
+}
+
+// memberErrorCode memetakan error dari MemberService ke HTTP status code.
+func memberErrorCode(err error, fallback int) int {
+	switch {
+	case errors.Is(err, services.ErrStoreForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrVersionRequired):
+		return http.StatusPreconditionRequired
+	case errors.Is(err, services.ErrNIKRegistered):
+		return http.StatusConflict
+	case errors.Is(err, services.ErrInvalidGeoFilter):
+		return http.StatusBadRequest
+	case errors.Is(err, services.ErrLandAreaMismatch), errors.Is(err, services.ErrInvalidBoundary), errors.Is(err, services.ErrStoreRequired):
+		return http.StatusUnprocessableEntity
+	}
+	return fallback
+}
+
+// @Summary		Get all members
+// @Description	API untuk mendapatkan semua member (petani) pada toko yang dapat diakses
+// @Tags			Member
+// @Accept			json
+// @Produce		json
+// @Param			request	query		requests.FilterRequest	false	"Filter request"
+// @Success		200		{object}	helpers.ResponseParams[models.Member]{data=[]models.Member}
+// @Router			/members [get]
+func (c *MemberController) List(ctx *gin.Context) {
+	var filters requests.FilterRequest
+	if err := ctx.ShouldBindQuery(&filters); err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	members, total, err := c.service.GetAll(helpers.GetStoreContext(ctx), filters)
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mendapatkan daftar member",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.Member]{Data: &members, Total: &total}, http.StatusOK)
+}
+
+// @Summary		Get member by ID
+// @Description	API untuk mendapatkan member berdasarkan ID
+// @Tags			Member
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int	true	"Member ID"
+// @Success		200	{object}	helpers.ResponseParams[models.Member]{item=models.Member}
+// @Router			/members/{id} [get]
+func (c *MemberController) Get(ctx *gin.Context) {
+	member, err := c.service.GetByID(helpers.GetStoreContext(ctx), ctx.Param("id"))
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mendapatkan member",
+			Reference: "ERROR-2",
+			Errors:    map[string]string{"error": err.Error()},
+		}, memberErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.SetETag(ctx, member.Version)
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.Member]{Item: &member}, http.StatusOK)
+}
+
+// @Summary		Create member
+// @Description	API untuk membuat member
+// @Tags			Member
+// @Accept			json
+// @Produce		json
+// @Param			member	body		requests.MemberRequestPut	true	"Member request body"
+// @Success		201		{object}	helpers.ResponseParams[models.Member]{item=models.Member}
+// @Router			/members [post]
+func (c *MemberController) Create(ctx *gin.Context) {
+	var request requests.MemberRequestPut
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	request.ID = 0
+	c.save(ctx, request, http.StatusCreated)
+}
+
+// @Summary		Replace member
+// @Description	API untuk mengganti seluruh data member, field yang tidak dikirim dikosongkan
+// @Tags			Member
+// @Accept			json
+// @Produce		json
+// @Param			id			path		int							true	"Member ID"
+// @Param			If-Match	header		string						false	"Version member, contoh W/\"3\""
+// @Param			member		body		requests.MemberRequestPut	true	"Member request body"
+// @Success		200			{object}	helpers.ResponseParams[models.Member]{item=models.Member}
+// @Router			/members/{id} [put]
+func (c *MemberController) Replace(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+	var request requests.MemberRequestPut
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	request.ID = uint(id)
+	c.save(ctx, request, http.StatusOK)
+}
+
+// @Summary		Patch member
+// @Description	API untuk mengubah sebagian data member dengan JSON Merge Patch (RFC 7396), null mengosongkan field
+// @Tags			Member
+// @Accept			application/merge-patch+json
+// @Produce		json
+// @Param			id			path		int							true	"Member ID"
+// @Param			If-Match	header		string						false	"Version member, contoh W/\"3\""
+// @Param			member		body		requests.MemberRequestPut	true	"Field member yang diubah"
+// @Success		200			{object}	helpers.ResponseParams[models.Member]{item=models.Member}
+// @Router			/members/{id} [patch]
+func (c *MemberController) Patch(ctx *gin.Context) {
+	current, err := c.service.GetByID(helpers.GetStoreContext(ctx), ctx.Param("id"))
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mendapatkan member",
+			Reference: "ERROR-2",
+			Errors:    map[string]string{"error": err.Error()},
+		}, memberErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	request := requests.NewMemberRequest(current)
+	if err := helpers.BindMergePatch(ctx, &request); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	request.ID = current.ID
+	c.save(ctx, request, http.StatusOK)
+}
+
+// @Summary		Create/Update member
+// @Description	API untuk membuat atau mengupdate member berdasarkan id pada body, gunakan POST, PUT /members/{id} atau PATCH /members/{id}, foto KTP dikirim dalam format base64
+// @Tags			Member
+// @Accept			json
+// @Produce		json
+// @Param			member	body		requests.MemberRequestPut	true	"Member request body"
+// @Success		200		{object}	helpers.ResponseParams[models.Member]{item=models.Member}
+// @Deprecated
+// @Router			/members [put]
+func (c *MemberController) Put(ctx *gin.Context) {
+	var request requests.MemberRequestPut
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		var verr validator.ValidationErrors
+		if errors.As(err, &verr) {
+			helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+				Message:   "Periksa kembali form anda",
+				Errors:    helpers.ValidationError(verr),
+				Reference: "ERROR-4",
+			}, http.StatusBadRequest)
+			return
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Errors:    map[string]string{"error": err.Error()},
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	c.save(ctx, request, http.StatusOK)
+}
+
+// save menjalankan create atau replace member dan memetakan error service ke response.
+func (c *MemberController) save(ctx *gin.Context, request requests.MemberRequestPut, status int) {
+	if request.Version == 0 {
+		request.Version = helpers.IfMatchVersion(ctx)
+	}
+
+	store := helpers.GetStoreContext(ctx)
+	member, err := c.service.Put(store, request)
+	if err != nil {
+		if errors.Is(err, services.ErrVersionConflict) {
+			if current, findErr := c.service.GetByID(store, strconv.FormatUint(uint64(request.ID), 10)); findErr == nil {
+				respondVersionConflict(ctx, "Member sudah diubah oleh user lain", err, current, current.Version)
+				return
+			}
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal membuat atau mengupdate member",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, memberErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.SetETag(ctx, member.Version)
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.Member]{Item: member}, status)
+}
+
+// @Summary		Delete member
+// @Description	API untuk menghapus member
+// @Tags			Member
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int	true	"Member ID"
+// @Success		200	{object}	helpers.ResponseParams[any]
+// @Router			/members/{id} [delete]
+func (c *MemberController) Delete(ctx *gin.Context) {
+	if err := c.service.Delete(helpers.GetStoreContext(ctx), ctx.Param("id")); err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal menghapus member",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, memberErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[any]{}, http.StatusOK)
+}
+
+// @Summary		Check NIK
+// @Description	API untuk mengecek apakah NIK sudah terdaftar sebagai member dan terdaftar di toko. NIK milik member yang sudah dihapus tetap dianggap terdaftar dengan is_trashed true
+// @Tags			Member
+// @Accept			json
+// @Produce		json
+// @Param			request	body		requests.MemberRequestCheckNIK	true	"Check NIK request body"
+// @Success		200		{object}	helpers.ResponseParams[responses.CheckNIK]{item=responses.CheckNIK}
+// @Router			/members/check-nik [post]
+func (c *MemberController) CheckNIK(ctx *gin.Context) {
+	var request requests.MemberRequestCheckNIK
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		var verr validator.ValidationErrors
+		if errors.As(err, &verr) {
+			helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+				Message:   "Periksa kembali form anda",
+				Errors:    helpers.ValidationError(verr),
+				Reference: "ERROR-4",
+			}, http.StatusBadRequest)
+			return
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Errors:    map[string]string{"error": err.Error()},
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	result, err := c.service.CheckNIK(helpers.GetStoreContext(ctx), request)
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mengecek NIK",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, memberErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[responses.CheckNIK]{Item: result}, http.StatusOK)
+}
+
+// @Summary		Attach member to store
+// @Description	API untuk mendaftarkan member ke toko
+// @Tags			Store
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int							true	"Store ID"
+// @Param			member	body		requests.StoreRequestMember	true	"Member ID"
+// @Success		200		{object}	helpers.ResponseParams[any]
+// @Router			/stores/{id}/members [post]
+func (c *MemberController) AttachStore(ctx *gin.Context) {
+	storeID, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	var request requests.StoreRequestMember
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Errors:    map[string]string{"error": err.Error()},
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	if err := c.service.AttachStore(helpers.GetStoreContext(ctx), uint(storeID), request); err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mendaftarkan member ke toko",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, memberErrorCode(err, http.StatusBadRequest))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[any]{Message: "Member didaftarkan ke toko"}, http.StatusOK)
+}
+
+// @Summary		Detach member from store
+// @Description	API untuk mengeluarkan member dari toko
+// @Tags			Store
+// @Accept			json
+// @Produce		json
+// @Param			id			path		int	true	"Store ID"
+// @Param			member_id	path		int	true	"Member ID"
+// @Success		200			{object}	helpers.ResponseParams[any]
+// @Router			/stores/{id}/members/{member_id} [delete]
+func (c *MemberController) DetachStore(ctx *gin.Context) {
+	storeID, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	if err := c.service.DetachStore(helpers.GetStoreContext(ctx), uint(storeID), ctx.Param("member_id")); err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mengeluarkan member dari toko",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, memberErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[any]{Message: "Member dikeluarkan dari toko"}, http.StatusOK)
+}
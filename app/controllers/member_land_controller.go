@@ -0,0 +1,166 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"golang_starter_kit_2025/app/helpers"
+	"golang_starter_kit_2025/app/models"
+	"golang_starter_kit_2025/app/requests"
+	"golang_starter_kit_2025/app/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type MemberLandController struct {
+	service *services.MemberLandService
+}
+
+func NewMemberLandController() *MemberLandController {
+	return &MemberLandController{
+		service: services.NewMemberLandService(),
+	}
+}
+
+// @Summary		Search member lands
+// @Description	API untuk mencari lahan member berdasarkan jarak dari titik (near, radius_km) atau bounding box (bbox)
+// @Tags			Member
+// @Accept			json
+// @Produce		json
+// @Param			request	query		requests.MemberLandFilterRequest	false	"Filter request"
+// @Success		200		{object}	helpers.ResponseParams[models.MemberLand]{data=[]models.MemberLand}
+// @Router			/member-lands [get]
+func (c *MemberLandController) Search(ctx *gin.Context) {
+	var filters requests.MemberLandFilterRequest
+	if err := ctx.ShouldBindQuery(&filters); err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	lands, total, err := c.service.GetAll(helpers.GetStoreContext(ctx), filters)
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mendapatkan daftar lahan",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, memberErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.MemberLand]{Data: &lands, Total: &total}, http.StatusOK)
+}
+
+// @Summary		Export member lands as GeoJSON
+// @Description	API untuk mengekspor lahan member sebagai GeoJSON FeatureCollection, menerima filter yang sama dengan /member-lands tanpa paginasi
+// @Tags			Member
+// @Accept			json
+// @Produce		json
+// @Param			request	query		requests.MemberLandFilterRequest	false	"Filter request"
+// @Success		200		{object}	responses.FeatureCollection
+// @Router			/member-lands.geojson [get]
+func (c *MemberLandController) GeoJSON(ctx *gin.Context) {
+	var filters requests.MemberLandFilterRequest
+	if err := ctx.ShouldBindQuery(&filters); err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	collection, err := c.service.GeoJSON(helpers.GetStoreContext(ctx), filters)
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mengekspor lahan",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, memberErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	// FeatureCollection dikirim apa adanya agar dapat langsung dibaca aplikasi peta
+	ctx.Header("Content-Type", "application/geo+json")
+	ctx.JSON(http.StatusOK, collection)
+}
+
+// @Summary		Get member lands
+// @Description	API untuk mendapatkan daftar lahan milik member
+// @Tags			Member
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int	true	"Member ID"
+// @Success		200	{object}	helpers.ResponseParams[models.MemberLand]{data=[]models.MemberLand}
+// @Router			/members/{id}/lands [get]
+func (c *MemberLandController) List(ctx *gin.Context) {
+	lands, err := c.service.GetByMember(helpers.GetStoreContext(ctx), ctx.Param("id"))
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mendapatkan lahan member",
+			Reference: "ERROR-2",
+			Errors:    map[string]string{"error": err.Error()},
+		}, memberErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.MemberLand]{Data: &lands}, http.StatusOK)
+}
+
+// @Summary		Create/Update member lands
+// @Description	API untuk menyimpan seluruh lahan member. Lahan dengan ID diupdate (wajib menyertakan version), lahan tanpa ID dibuat, lahan yang tidak dikirim dihapus. Sertifikat dikirim dalam format base64
+// @Tags			Member
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int								true	"Member ID"
+// @Param			lands	body		requests.MemberRequestPutLands	true	"Member lands request body"
+// @Success		200		{object}	helpers.ResponseParams[models.MemberLand]{data=[]models.MemberLand}
+// @Router			/members/{id}/lands [put]
+func (c *MemberLandController) Put(ctx *gin.Context) {
+	var request requests.MemberRequestPutLands
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		var verr validator.ValidationErrors
+		if errors.As(err, &verr) {
+			helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+				Message:   "Periksa kembali form anda",
+				Errors:    helpers.ValidationError(verr),
+				Reference: "ERROR-4",
+			}, http.StatusBadRequest)
+			return
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Errors:    map[string]string{"error": err.Error()},
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	store := helpers.GetStoreContext(ctx)
+	lands, err := c.service.PutLands(store, ctx.Param("id"), request)
+	if err != nil {
+		if errors.Is(err, services.ErrVersionConflict) {
+			if current, findErr := c.service.GetByMember(store, ctx.Param("id")); findErr == nil {
+				// version dikirim per lahan sehingga tidak ada ETag untuk daftar lahan
+				var item any = current
+				helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+					Message:   "Lahan member sudah diubah oleh user lain",
+					Reference: "ERROR-3",
+					Errors:    map[string]string{"error": err.Error()},
+					Item:      &item,
+				}, http.StatusConflict)
+				return
+			}
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal menyimpan lahan member",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, memberErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.MemberLand]{Data: &lands}, http.StatusOK)
+}
@@ -0,0 +1,80 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"golang_starter_kit_2025/app/helpers"
+	"golang_starter_kit_2025/app/models"
+	"golang_starter_kit_2025/app/requests"
+	"golang_starter_kit_2025/app/services"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type NotificationController struct {
+	service *services.NotificationService
+}
+
+func NewNotificationController() *NotificationController {
+	return &NotificationController{
+		service: services.NewNotificationService(),
+	}
+}
+
+// @Summary		Get notifications
+// @Description	API untuk mendapatkan notifikasi user yang sedang login
+// @Tags			Notification
+// @Accept			json
+// @Produce		json
+// @Param			request	query		requests.FilterRequest	false	"Filter request"
+// @Success		200		{object}	helpers.ResponseParams[models.Notification]{data=[]models.Notification}
+// @Router			/notifications [get]
+func (c *NotificationController) List(ctx *gin.Context) {
+	var filters requests.FilterRequest
+	if err := ctx.ShouldBindQuery(&filters); err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	notifications, total, err := c.service.GetAll(ctx.GetUint("user_id"), filters)
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mendapatkan notifikasi",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.Notification]{Data: &notifications, Total: &total}, http.StatusOK)
+}
+
+// @Summary		Mark notification as read
+// @Description	API untuk menandai notifikasi sudah dibaca
+// @Tags			Notification
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int	true	"Notification ID"
+// @Success		200	{object}	helpers.ResponseParams[any]
+// @Router			/notifications/{id}/read [put]
+func (c *NotificationController) Read(ctx *gin.Context) {
+	if err := c.service.MarkRead(ctx.GetUint("user_id"), ctx.Param("id")); err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			code = http.StatusNotFound
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal menandai notifikasi",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, code)
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[any]{}, http.StatusOK)
+}
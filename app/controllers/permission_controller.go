@@ -2,13 +2,15 @@ package controllers
 
 import (
 	"errors"
+	"strconv"
 
 	"golang_starter_kit_2025/app/helpers"
 	"golang_starter_kit_2025/app/models"
+	"golang_starter_kit_2025/app/requests"
 	"golang_starter_kit_2025/app/services"
 
 	"github.com/gin-gonic/gin"
-	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
 )
 
 type PermissionController struct {
@@ -40,45 +42,131 @@ func (c *PermissionController) List(ctx *gin.Context) {
 	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.Permission]{Data: &permissions}, 200)
 }
 
+// @Summary		Create Permission
+// @Description	API untuk membuat Permission
+// @Tags			Permission
+// @Accept			json
+// @Produce		json
+// @Param			permission	body		requests.PermissionRequest	true	"Permission Data"
+// @Success		201			{object}	helpers.ResponseParams[models.Permission]{item=models.Permission}
+// @Router			/permissions [post]
+func (c *PermissionController) Create(ctx *gin.Context) {
+	var request requests.PermissionRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	request.ID = 0
+	c.save(ctx, request, 201)
+}
+
+// @Summary		Replace Permission
+// @Description	API untuk mengganti seluruh data Permission
+// @Tags			Permission
+// @Accept			json
+// @Produce		json
+// @Param			id			path		int							true	"Permission ID"
+// @Param			If-Match	header		string						false	"Version permission, contoh W/\"3\""
+// @Param			permission	body		requests.PermissionRequest	true	"Permission Data"
+// @Success		200			{object}	helpers.ResponseParams[models.Permission]{item=models.Permission}
+// @Router			/permissions/{id} [put]
+func (c *PermissionController) Replace(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+	var request requests.PermissionRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	request.ID = uint(id)
+	c.save(ctx, request, 200)
+}
+
+// @Summary		Patch Permission
+// @Description	API untuk mengubah sebagian data Permission dengan JSON Merge Patch (RFC 7396)
+// @Tags			Permission
+// @Accept			application/merge-patch+json
+// @Produce		json
+// @Param			id			path		int							true	"Permission ID"
+// @Param			If-Match	header		string						false	"Version permission, contoh W/\"3\""
+// @Param			permission	body		requests.PermissionRequest	true	"Field Permission yang diubah"
+// @Success		200			{object}	helpers.ResponseParams[models.Permission]{item=models.Permission}
+// @Router			/permissions/{id} [patch]
+func (c *PermissionController) Patch(ctx *gin.Context) {
+	permission, err := c.service.Find(ctx.Param("id"))
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Errors:    map[string]string{"error": err.Error()},
+			Message:   "Permission tidak ditemukan",
+			Reference: "ERROR-2",
+		}, 404)
+		return
+	}
+
+	request := requests.NewPermissionRequest(permission)
+	if err := helpers.BindMergePatch(ctx, &request); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	request.ID = permission.ID
+	c.save(ctx, request, 200)
+}
+
 // @Summary		Create/Update Permission
-// @Description	API untuk mengupdate atau membuat Permission
+// @Description	API untuk mengupdate atau membuat Permission berdasarkan id pada body, gunakan POST, PUT /permissions/{id} atau PATCH /permissions/{id}
 // @Tags			Permission
 // @Accept			json
 // @Produce		json
 // @Param			permission	body		requests.PermissionRequest	true	"Permission Data"
 // @Success		200			{object}	helpers.ResponseParams[models.Permission]{item=models.Permission}
+// @Deprecated
 // @Router			/permissions [put]
 func (c *PermissionController) Put(ctx *gin.Context) {
-	var permission models.Permission
-	if err := ctx.ShouldBindJSON(&permission); err != nil {
-		var verr validator.ValidationErrors
-		if errors.As(err, &verr) {
-			helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
-				Errors:    helpers.ValidationError(verr),
-				Message:   "Parameter tidak valid",
-				Reference: "ERROR-4",
-			}, 400)
-			return
-		}
-		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
-			Errors:    map[string]string{"error": err.Error()},
-			Message:   "Gagal membuat Permission",
-			Reference: "ERROR-3",
-		}, 400)
+	var request requests.PermissionRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		respondBindError(ctx, err)
 		return
 	}
 
-	updatedPermission, err := c.service.Put(permission)
+	c.save(ctx, request, 200)
+}
+
+// save menjalankan create atau replace Permission dan memetakan error service ke response.
+func (c *PermissionController) save(ctx *gin.Context, request requests.PermissionRequest, status int) {
+	if request.Version == 0 {
+		request.Version = helpers.IfMatchVersion(ctx)
+	}
+
+	permission, err := c.service.Put(request)
 	if err != nil {
+		if errors.Is(err, services.ErrVersionConflict) {
+			if current, findErr := c.service.Find(strconv.FormatUint(uint64(request.ID), 10)); findErr == nil {
+				respondVersionConflict(ctx, "Permission sudah diubah oleh user lain", err, current, current.Version)
+				return
+			}
+		}
+		code := 400
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			code = 404
+		} else if errors.Is(err, services.ErrVersionRequired) {
+			code = 428
+		}
 		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
 			Errors:    map[string]string{"error": err.Error()},
-			Message:   "Gagal membuat Permission",
+			Message:   "Gagal menyimpan Permission",
 			Reference: "ERROR-3",
-		}, 400)
+		}, code)
 		return
 	}
 
-	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.Permission]{Item: &updatedPermission}, 200)
+	helpers.SetETag(ctx, permission.Version)
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.Permission]{Item: &permission}, status)
 }
 
 // @Summary		Delete Permission
@@ -0,0 +1,185 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"golang_starter_kit_2025/app/helpers"
+	"golang_starter_kit_2025/app/models"
+	"golang_starter_kit_2025/app/requests"
+	"golang_starter_kit_2025/app/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+type ProductBarcodeController struct {
+	service *services.ProductBarcodeService
+}
+
+func NewProductBarcodeController() *ProductBarcodeController {
+	return &ProductBarcodeController{
+		service: services.NewProductBarcodeService(),
+	}
+}
+
+// productBarcodeErrorCode memetakan error dari ProductBarcodeService ke HTTP status code.
+func productBarcodeErrorCode(err error, fallback int) int {
+	switch {
+	case errors.Is(err, services.ErrStoreForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrInvalidBarcode):
+		return http.StatusUnprocessableEntity
+	}
+	return fallback
+}
+
+// @Summary		Get product by barcode
+// @Description	API untuk mencari produk dari hasil scan barcode, SKU atau reference
+// @Tags			Product
+// @Accept			json
+// @Produce		json
+// @Param			code	path		string	true	"Barcode, SKU atau reference"
+// @Success		200		{object}	helpers.ResponseParams[models.Product]{item=models.Product}
+// @Router			/products/by-barcode/{code} [get]
+func (c *ProductBarcodeController) ByBarcode(ctx *gin.Context) {
+	product, err := c.service.GetByBarcode(helpers.GetStoreContext(ctx), ctx.Param("code"))
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Produk dengan barcode tersebut tidak ditemukan",
+			Reference: "ERROR-2",
+			Errors:    map[string]string{"error": err.Error()},
+		}, productBarcodeErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.Product]{Item: &product}, http.StatusOK)
+}
+
+// @Summary		Render product barcode
+// @Description	API untuk merender barcode produk (EAN-13 atau Code128) sebagai PNG atau SVG
+// @Tags			Product
+// @Produce		image/png
+// @Produce		image/svg+xml
+// @Param			id		path	int							true	"Product ID"
+// @Param			request	query	requests.BarcodeImageRequest	false	"Barcode image request"
+// @Success		200		{file}	binary
+// @Router			/products/{id}/barcode [get]
+func (c *ProductBarcodeController) Barcode(ctx *gin.Context) {
+	var request requests.BarcodeImageRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		var verr validator.ValidationErrors
+		if errors.As(err, &verr) {
+			helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+				Message:   "Periksa kembali form anda",
+				Errors:    helpers.ValidationError(verr),
+				Reference: "ERROR-4",
+			}, http.StatusBadRequest)
+			return
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Errors:    map[string]string{"error": err.Error()},
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	image, contentType, err := c.service.Barcode(helpers.GetStoreContext(ctx), ctx.Param("id"), request)
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal membuat barcode produk",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, productBarcodeErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	ctx.Data(http.StatusOK, contentType, image)
+}
+
+// @Summary		Render product QR code
+// @Description	API untuk merender QR code berisi reference produk sebagai PNG atau SVG
+// @Tags			Product
+// @Produce		image/png
+// @Produce		image/svg+xml
+// @Param			id		path	int						true	"Product ID"
+// @Param			request	query	requests.QRCodeRequest	false	"QR code request"
+// @Success		200		{file}	binary
+// @Router			/products/{id}/qrcode [get]
+func (c *ProductBarcodeController) QRCode(ctx *gin.Context) {
+	var request requests.QRCodeRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		var verr validator.ValidationErrors
+		if errors.As(err, &verr) {
+			helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+				Message:   "Periksa kembali form anda",
+				Errors:    helpers.ValidationError(verr),
+				Reference: "ERROR-4",
+			}, http.StatusBadRequest)
+			return
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Errors:    map[string]string{"error": err.Error()},
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	image, contentType, err := c.service.QRCode(helpers.GetStoreContext(ctx), ctx.Param("id"), request)
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal membuat QR code produk",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, productBarcodeErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	ctx.Data(http.StatusOK, contentType, image)
+}
+
+// @Summary		Print product labels
+// @Description	API untuk membuat lembar label produk (nama, harga, barcode) dalam format PDF A4
+// @Tags			Product
+// @Produce		application/pdf
+// @Param			request	query	requests.ProductLabelRequest	true	"Product label request"
+// @Success		200		{file}	binary
+// @Router			/products/labels [get]
+func (c *ProductBarcodeController) Labels(ctx *gin.Context) {
+	var request requests.ProductLabelRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		var verr validator.ValidationErrors
+		if errors.As(err, &verr) {
+			helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+				Message:   "Periksa kembali form anda",
+				Errors:    helpers.ValidationError(verr),
+				Reference: "ERROR-4",
+			}, http.StatusBadRequest)
+			return
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Errors:    map[string]string{"error": err.Error()},
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	document, err := c.service.Labels(helpers.GetStoreContext(ctx), request)
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal membuat label produk",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, productBarcodeErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	ctx.Header("Content-Disposition", `inline; filename="product-labels.pdf"`)
+	ctx.Data(http.StatusOK, services.ContentTypePDF, document)
+}
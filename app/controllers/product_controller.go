@@ -3,6 +3,7 @@ package controllers
 import (
 	"errors"
 	"net/http"
+	"strconv"
 
 	"golang_starter_kit_2025/app/helpers"
 	"golang_starter_kit_2025/app/models"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
 )
 
 type ProductController struct {
@@ -28,11 +30,12 @@ func NewProductController() *ProductController {
 // @Tags			Product
 // @Accept			json
 // @Produce		json
-// @Param			request	query		requests.FilterRequest	false	"Filter request"
-// @Success		200		{object}	helpers.ResponseParams[models.Product]{data=[]models.Product}
+// @Param			request				query		requests.ProductFilterRequest	false	"Filter request"
+// @Param			attributes[code]	query		string							false	"Filter nilai atribut, contoh attributes[ukuran]=25kg"
+// @Success		200					{object}	helpers.ResponseParams[models.Product]{data=[]models.Product}
 // @Router			/products [get]
 func (c *ProductController) GetAll(ctx *gin.Context) {
-	var filters requests.FilterRequest
+	var filters requests.ProductFilterRequest
 	if err := ctx.ShouldBindQuery(&filters); err != nil {
 		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
 			Message:   "Parameter tidak valid",
@@ -40,8 +43,9 @@ func (c *ProductController) GetAll(ctx *gin.Context) {
 		}, http.StatusBadRequest)
 		return
 	}
+	filters.Attributes = ctx.QueryMap("attributes")
 
-	products, err := c.service.GetAll(filters)
+	products, err := c.service.GetAll(helpers.GetStoreContext(ctx), filters)
 	if err != nil {
 		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
 			Message:   "Gagal mendapatkan daftar produk",
@@ -64,7 +68,7 @@ func (c *ProductController) GetAll(ctx *gin.Context) {
 // @Router			/products/{id} [get]
 func (c *ProductController) GetByID(ctx *gin.Context) {
 	id := ctx.Param("id")
-	product, err := c.service.GetByID(id)
+	product, err := c.service.GetByID(helpers.GetStoreContext(ctx), id)
 	if err != nil {
 		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
 			Message:   "Gagal mendapatkan produk",
@@ -74,16 +78,94 @@ func (c *ProductController) GetByID(ctx *gin.Context) {
 		return
 	}
 
+	helpers.SetETag(ctx, product.Version)
 	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.Product]{Item: &product}, http.StatusOK)
 }
 
+// @Summary		Create product
+// @Description	API untuk membuat produk
+// @Tags			Product
+// @Accept			json
+// @Produce		json
+// @Param			product	body		requests.ProductRequest	true	"Product request body"
+// @Success		201		{object}	helpers.ResponseParams[models.Product]{item=models.Product}
+// @Router			/products [post]
+func (c *ProductController) Create(ctx *gin.Context) {
+	var request requests.ProductRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	request.ID = 0
+	c.save(ctx, request, http.StatusCreated)
+}
+
+// @Summary		Replace product
+// @Description	API untuk mengganti seluruh data produk, field yang tidak dikirim dikosongkan kecuali stok. Stok yang dikirim harus sama dengan stok saat ini, perubahan stok melalui /products/{id}/movements
+// @Tags			Product
+// @Accept			json
+// @Produce		json
+// @Param			id			path		int						true	"Product ID"
+// @Param			If-Match	header		string					false	"Version produk, contoh W/\"3\""
+// @Param			product		body		requests.ProductRequest	true	"Product request body"
+// @Success		200			{object}	helpers.ResponseParams[models.Product]{item=models.Product}
+// @Router			/products/{id} [put]
+func (c *ProductController) Replace(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+	var request requests.ProductRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	request.ID = uint(id)
+	c.save(ctx, request, http.StatusOK)
+}
+
+// @Summary		Patch product
+// @Description	API untuk mengubah sebagian data produk dengan JSON Merge Patch (RFC 7396), null mengosongkan field
+// @Tags			Product
+// @Accept			application/merge-patch+json
+// @Produce		json
+// @Param			id			path		int						true	"Product ID"
+// @Param			If-Match	header		string					false	"Version produk, contoh W/\"3\""
+// @Param			product		body		requests.ProductRequest	true	"Field produk yang diubah"
+// @Success		200			{object}	helpers.ResponseParams[models.Product]{item=models.Product}
+// @Router			/products/{id} [patch]
+func (c *ProductController) Patch(ctx *gin.Context) {
+	product, err := c.service.GetByID(helpers.GetStoreContext(ctx), ctx.Param("id"))
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mendapatkan produk",
+			Reference: "ERROR-2",
+			Errors:    map[string]string{"error": err.Error()},
+		}, http.StatusNotFound)
+		return
+	}
+
+	request := requests.NewProductRequest(product)
+	if err := helpers.BindMergePatch(ctx, &request); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	request.ID = product.ID
+	c.save(ctx, request, http.StatusOK)
+}
+
 // @Summary		Create/Update product
-// @Description	API untuk membuat atau mengupdate produk
+// @Description	API untuk membuat atau mengganti produk berdasarkan id pada body, gunakan POST, PUT /products/{id} atau PATCH /products/{id}
 // @Tags			Product
 // @Accept			json
 // @Produce		json
 // @Param			product	body		requests.ProductRequest	true	"Product request body"
 // @Success		200		{object}	helpers.ResponseParams[models.Product]{item=models.Product}
+// @Deprecated
 // @Router			/products [put]
 func (c *ProductController) Put(ctx *gin.Context) {
 	var request requests.ProductRequest
@@ -99,17 +181,46 @@ func (c *ProductController) Put(ctx *gin.Context) {
 		}
 	}
 
-	product, err := c.service.Put(ctx, request)
+	c.save(ctx, request, http.StatusOK)
+}
+
+// save menjalankan create atau replace produk dan memetakan error service ke response.
+func (c *ProductController) save(ctx *gin.Context, request requests.ProductRequest, status int) {
+	if request.Version == 0 {
+		request.Version = helpers.IfMatchVersion(ctx)
+	}
+
+	store := helpers.GetStoreContext(ctx)
+	product, err := c.service.Put(ctx, store, request)
 	if err != nil {
+		if errors.Is(err, services.ErrVersionConflict) {
+			if current, findErr := c.service.GetByID(store, strconv.FormatUint(uint64(request.ID), 10)); findErr == nil {
+				respondVersionConflict(ctx, "Produk sudah diubah oleh user lain", err, current, current.Version)
+				return
+			}
+		}
+		code := http.StatusInternalServerError
+		if errors.Is(err, services.ErrStoreForbidden) {
+			code = http.StatusForbidden
+		} else if errors.Is(err, gorm.ErrRecordNotFound) {
+			code = http.StatusNotFound
+		} else if errors.Is(err, services.ErrInvalidBarcode) || errors.Is(err, services.ErrStoreNotFound) {
+			code = http.StatusUnprocessableEntity
+		} else if errors.Is(err, services.ErrVersionRequired) {
+			code = http.StatusPreconditionRequired
+		} else if errors.Is(err, services.ErrStockChanged) {
+			code = http.StatusConflict
+		}
 		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
 			Message:   "Gagal membuat atau mengupdate produk",
 			Reference: "ERROR-3",
 			Errors:    map[string]string{"error": err.Error()},
-		}, http.StatusInternalServerError)
+		}, code)
 		return
 	}
 
-	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.Product]{Item: product}, http.StatusOK)
+	helpers.SetETag(ctx, product.Version)
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.Product]{Item: product}, status)
 }
 
 // @Summary		Delete product
@@ -122,7 +233,7 @@ func (c *ProductController) Put(ctx *gin.Context) {
 // @Router			/products/{id} [delete]
 func (c *ProductController) Delete(ctx *gin.Context) {
 	id := ctx.Param("id")
-	if err := c.service.Delete(id); err != nil {
+	if err := c.service.Delete(helpers.GetStoreContext(ctx), id); err != nil {
 		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
 			Message:   "Gagal menghapus produk",
 			Reference: "ERROR-3",
@@ -0,0 +1,265 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"golang_starter_kit_2025/app/helpers"
+	"golang_starter_kit_2025/app/models"
+	"golang_starter_kit_2025/app/requests"
+	"golang_starter_kit_2025/app/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+type ProductOfferingController struct {
+	service *services.ProductOfferingService
+}
+
+func NewProductOfferingController() *ProductOfferingController {
+	return &ProductOfferingController{
+		service: services.NewProductOfferingService(),
+	}
+}
+
+// productOfferingErrorCode memetakan error dari ProductOfferingService ke HTTP status code.
+func productOfferingErrorCode(err error, fallback int) int {
+	switch {
+	case errors.Is(err, services.ErrStoreForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrInvalidEffectiveDate):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, services.ErrVersionRequired):
+		return http.StatusPreconditionRequired
+	}
+	return fallback
+}
+
+// @Summary		Get all product offerings
+// @Description	API untuk mendapatkan katalog product offering, gunakan status=active untuk offering yang dapat dipakai di RAB
+// @Tags			ProductOffering
+// @Accept			json
+// @Produce		json
+// @Param			request	query		requests.ProductOfferingFilterRequest	false	"Filter request"
+// @Success		200		{object}	helpers.ResponseParams[models.ProductOffering]{data=[]models.ProductOffering}
+// @Router			/product-offerings [get]
+func (c *ProductOfferingController) List(ctx *gin.Context) {
+	var filters requests.ProductOfferingFilterRequest
+	if err := ctx.ShouldBindQuery(&filters); err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	offerings, total, err := c.service.GetAll(filters)
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mendapatkan daftar product offering",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.ProductOffering]{Data: &offerings, Total: &total}, http.StatusOK)
+}
+
+// @Summary		Get product offering by ID
+// @Description	API untuk mendapatkan product offering beserta produk penyusun dan riwayat harga
+// @Tags			ProductOffering
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int	true	"Product offering ID"
+// @Success		200	{object}	helpers.ResponseParams[models.ProductOffering]{item=models.ProductOffering}
+// @Router			/product-offerings/{id} [get]
+func (c *ProductOfferingController) Get(ctx *gin.Context) {
+	offering, err := c.service.GetByID(ctx.Param("id"))
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mendapatkan product offering",
+			Reference: "ERROR-2",
+			Errors:    map[string]string{"error": err.Error()},
+		}, productOfferingErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.SetETag(ctx, offering.Version)
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.ProductOffering]{Item: &offering}, http.StatusOK)
+}
+
+// @Summary		Create/Update product offering
+// @Description	API untuk membuat atau mengupdate product offering. Perubahan harga langsung berlaku dan tercatat di riwayat harga
+// @Tags			ProductOffering
+// @Accept			json
+// @Produce		json
+// @Param			If-Match	header		string								false	"Version product offering saat update, contoh W/\"3\""
+// @Param			offering	body		requests.ProductOfferingRequestPut	true	"Product offering request body"
+// @Success		200			{object}	helpers.ResponseParams[models.ProductOffering]{item=models.ProductOffering}
+// @Router			/product-offerings [put]
+func (c *ProductOfferingController) Put(ctx *gin.Context) {
+	var request requests.ProductOfferingRequestPut
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		var verr validator.ValidationErrors
+		if errors.As(err, &verr) {
+			helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+				Message:   "Periksa kembali form anda",
+				Errors:    helpers.ValidationError(verr),
+				Reference: "ERROR-4",
+			}, http.StatusBadRequest)
+			return
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Errors:    map[string]string{"error": err.Error()},
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	if request.Version == 0 {
+		request.Version = helpers.IfMatchVersion(ctx)
+	}
+
+	offering, err := c.service.Put(helpers.GetStoreContext(ctx), request)
+	if err != nil {
+		if errors.Is(err, services.ErrVersionConflict) {
+			if current, findErr := c.service.GetByID(request.ID); findErr == nil {
+				respondVersionConflict(ctx, "Product offering sudah diubah oleh user lain", err, current, current.Version)
+				return
+			}
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal membuat atau mengupdate product offering",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, productOfferingErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.SetETag(ctx, offering.Version)
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.ProductOffering]{Item: offering}, http.StatusOK)
+}
+
+// @Summary		Update product offering status
+// @Description	API untuk mengaktifkan atau menonaktifkan product offering. Offering nonaktif tidak dapat ditambahkan ke item RAB
+// @Tags			ProductOffering
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int										true	"Product offering ID"
+// @Param			status	body		requests.ProductOfferingRequestStatus	true	"Status request body"
+// @Success		200		{object}	helpers.ResponseParams[models.ProductOffering]{item=models.ProductOffering}
+// @Router			/product-offerings/{id}/status [put]
+func (c *ProductOfferingController) Status(ctx *gin.Context) {
+	var request requests.ProductOfferingRequestStatus
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Errors:    map[string]string{"error": err.Error()},
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	offering, err := c.service.SetStatus(helpers.GetStoreContext(ctx), ctx.Param("id"), request)
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mengubah status product offering",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, productOfferingErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.ProductOffering]{Item: offering}, http.StatusOK)
+}
+
+// @Summary		Delete product offering
+// @Description	API untuk menghapus product offering
+// @Tags			ProductOffering
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int	true	"Product offering ID"
+// @Success		200	{object}	helpers.ResponseParams[any]
+// @Router			/product-offerings/{id} [delete]
+func (c *ProductOfferingController) Delete(ctx *gin.Context) {
+	if err := c.service.Delete(helpers.GetStoreContext(ctx), ctx.Param("id")); err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal menghapus product offering",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, productOfferingErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[any]{}, http.StatusOK)
+}
+
+// @Summary		Get product offering prices
+// @Description	API untuk mendapatkan riwayat dan jadwal harga product offering
+// @Tags			ProductOffering
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int	true	"Product offering ID"
+// @Success		200	{object}	helpers.ResponseParams[models.ProductOfferingPrice]{data=[]models.ProductOfferingPrice}
+// @Router			/product-offerings/{id}/prices [get]
+func (c *ProductOfferingController) Prices(ctx *gin.Context) {
+	prices, err := c.service.Prices(ctx.Param("id"))
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mendapatkan harga product offering",
+			Reference: "ERROR-2",
+			Errors:    map[string]string{"error": err.Error()},
+		}, productOfferingErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.ProductOfferingPrice]{Data: &prices}, http.StatusOK)
+}
+
+// @Summary		Schedule product offering price
+// @Description	API untuk menjadwalkan harga product offering yang berlaku mulai effective_from
+// @Tags			ProductOffering
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int									true	"Product offering ID"
+// @Param			price	body		requests.ProductOfferingPriceRequest	true	"Price request body"
+// @Success		200		{object}	helpers.ResponseParams[models.ProductOfferingPrice]{data=[]models.ProductOfferingPrice}
+// @Router			/product-offerings/{id}/prices [post]
+func (c *ProductOfferingController) SchedulePrice(ctx *gin.Context) {
+	var request requests.ProductOfferingPriceRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		var verr validator.ValidationErrors
+		if errors.As(err, &verr) {
+			helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+				Message:   "Periksa kembali form anda",
+				Errors:    helpers.ValidationError(verr),
+				Reference: "ERROR-4",
+			}, http.StatusBadRequest)
+			return
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Errors:    map[string]string{"error": err.Error()},
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	prices, err := c.service.SchedulePrice(helpers.GetStoreContext(ctx), ctx.Param("id"), request)
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal menjadwalkan harga product offering",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, productOfferingErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.ProductOfferingPrice]{Data: &prices}, http.StatusOK)
+}
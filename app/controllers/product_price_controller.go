@@ -0,0 +1,122 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"golang_starter_kit_2025/app/helpers"
+	"golang_starter_kit_2025/app/models"
+	"golang_starter_kit_2025/app/requests"
+	"golang_starter_kit_2025/app/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+type ProductPriceController struct {
+	service *services.ProductPriceService
+}
+
+func NewProductPriceController() *ProductPriceController {
+	return &ProductPriceController{
+		service: services.NewProductPriceService(),
+	}
+}
+
+// productPriceErrorCode memetakan error dari ProductPriceService ke HTTP status code.
+func productPriceErrorCode(err error, fallback int) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrInvalidEffectiveDate):
+		return http.StatusUnprocessableEntity
+	}
+	return fallback
+}
+
+// @Summary		Get product price history
+// @Description	API untuk mendapatkan riwayat harga produk, termasuk perubahan harga yang dijadwalkan (applied_at kosong)
+// @Tags			Product
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int	true	"Product ID"
+// @Success		200	{object}	helpers.ResponseParams[models.ProductPrice]{data=[]models.ProductPrice}
+// @Router			/products/{id}/prices [get]
+func (c *ProductPriceController) History(ctx *gin.Context) {
+	prices, err := c.service.History(helpers.GetStoreContext(ctx), ctx.Param("id"))
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mendapatkan riwayat harga produk",
+			Reference: "ERROR-2",
+			Errors:    map[string]string{"error": err.Error()},
+		}, productPriceErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.ProductPrice]{Data: &prices}, http.StatusOK)
+}
+
+// @Summary		Schedule product price
+// @Description	API untuk menjadwalkan perubahan harga produk mulai effective_from, jadwal yang sudah jatuh tempo langsung diterapkan
+// @Tags			Product
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int							true	"Product ID"
+// @Param			price	body		requests.ProductPriceRequest	true	"Product price request body"
+// @Success		201		{object}	helpers.ResponseParams[models.ProductPrice]{data=[]models.ProductPrice}
+// @Router			/products/{id}/prices [post]
+func (c *ProductPriceController) Schedule(ctx *gin.Context) {
+	var request requests.ProductPriceRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		var verr validator.ValidationErrors
+		if errors.As(err, &verr) {
+			helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+				Message:   "Periksa kembali form anda",
+				Errors:    helpers.ValidationError(verr),
+				Reference: "ERROR-4",
+			}, http.StatusBadRequest)
+			return
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Errors:    map[string]string{"error": err.Error()},
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	prices, err := c.service.Schedule(helpers.GetStoreContext(ctx), ctx.Param("id"), request)
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal menjadwalkan harga produk",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, productPriceErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.ProductPrice]{Data: &prices}, http.StatusCreated)
+}
+
+// @Summary		Cancel scheduled product price
+// @Description	API untuk membatalkan perubahan harga produk yang belum diterapkan
+// @Tags			Product
+// @Accept			json
+// @Produce		json
+// @Param			id			path		int	true	"Product ID"
+// @Param			price_id	path		int	true	"Product price ID"
+// @Success		200			{object}	helpers.ResponseParams[any]
+// @Router			/products/{id}/prices/{price_id} [delete]
+func (c *ProductPriceController) Cancel(ctx *gin.Context) {
+	if err := c.service.Cancel(helpers.GetStoreContext(ctx), ctx.Param("id"), ctx.Param("price_id")); err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal membatalkan jadwal harga produk",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, productPriceErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[any]{Message: "Jadwal harga dibatalkan"}, http.StatusOK)
+}
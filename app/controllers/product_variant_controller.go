@@ -0,0 +1,159 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"golang_starter_kit_2025/app/helpers"
+	"golang_starter_kit_2025/app/models"
+	"golang_starter_kit_2025/app/requests"
+	"golang_starter_kit_2025/app/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+type ProductVariantController struct {
+	service *services.ProductVariantService
+}
+
+func NewProductVariantController() *ProductVariantController {
+	return &ProductVariantController{
+		service: services.NewProductVariantService(),
+	}
+}
+
+// productVariantErrorCode memetakan error dari ProductVariantService ke HTTP status code.
+func productVariantErrorCode(err error, fallback int) int {
+	switch {
+	case errors.Is(err, services.ErrStoreForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrVersionRequired):
+		return http.StatusPreconditionRequired
+	case errors.Is(err, services.ErrInvalidVariant), errors.Is(err, services.ErrStoreNotFound):
+		return http.StatusUnprocessableEntity
+	}
+	return fallback
+}
+
+// @Summary		Get product variants
+// @Description	API untuk mendapatkan daftar varian dari produk induk
+// @Tags			Product
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int	true	"Product ID"
+// @Success		200	{object}	helpers.ResponseParams[models.Product]{data=[]models.Product}
+// @Router			/products/{id}/variants [get]
+func (c *ProductVariantController) List(ctx *gin.Context) {
+	variants, err := c.service.GetAll(helpers.GetStoreContext(ctx), ctx.Param("id"))
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mendapatkan daftar varian produk",
+			Reference: "ERROR-2",
+			Errors:    map[string]string{"error": err.Error()},
+		}, productVariantErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.Product]{Data: &variants}, http.StatusOK)
+}
+
+// @Summary		Create/Update product variant
+// @Description	API untuk membuat atau mengupdate varian produk, setiap varian memiliki SKU, harga dan stok sendiri
+// @Tags			Product
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int								true	"Product ID"
+// @Param			variant	body		requests.ProductVariantRequest	true	"Product variant request body"
+// @Success		200		{object}	helpers.ResponseParams[models.Product]{item=models.Product}
+// @Router			/products/{id}/variants [put]
+func (c *ProductVariantController) Put(ctx *gin.Context) {
+	var request requests.ProductVariantRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		var verr validator.ValidationErrors
+		if errors.As(err, &verr) {
+			helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+				Message:   "Periksa kembali form anda",
+				Errors:    helpers.ValidationError(verr),
+				Reference: "ERROR-4",
+			}, http.StatusBadRequest)
+			return
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Errors:    map[string]string{"error": err.Error()},
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	if request.Version == 0 {
+		request.Version = helpers.IfMatchVersion(ctx)
+	}
+
+	store := helpers.GetStoreContext(ctx)
+	variant, err := c.service.Put(ctx, store, ctx.Param("id"), request)
+	if err != nil {
+		if errors.Is(err, services.ErrVersionConflict) {
+			if current, findErr := services.NewProductService().GetByID(store, strconv.FormatUint(uint64(request.ID), 10)); findErr == nil {
+				respondVersionConflict(ctx, "Varian produk sudah diubah oleh user lain", err, current, current.Version)
+				return
+			}
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal membuat atau mengupdate varian produk",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, productVariantErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.SetETag(ctx, variant.Version)
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.Product]{Item: variant}, http.StatusOK)
+}
+
+// @Summary		Set product attributes
+// @Description	API untuk mengganti seluruh nilai atribut kategori pada produk atau varian
+// @Tags			Product
+// @Accept			json
+// @Produce		json
+// @Param			id			path		int									true	"Product ID"
+// @Param			attributes	body		requests.ProductAttributesRequest	true	"Product attributes request body"
+// @Success		200			{object}	helpers.ResponseParams[models.Product]{item=models.Product}
+// @Router			/products/{id}/attributes [put]
+func (c *ProductVariantController) Attributes(ctx *gin.Context) {
+	var request requests.ProductAttributesRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		var verr validator.ValidationErrors
+		if errors.As(err, &verr) {
+			helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+				Message:   "Periksa kembali form anda",
+				Errors:    helpers.ValidationError(verr),
+				Reference: "ERROR-4",
+			}, http.StatusBadRequest)
+			return
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Errors:    map[string]string{"error": err.Error()},
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	product, err := c.service.SetAttributes(helpers.GetStoreContext(ctx), ctx.Param("id"), request)
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mengatur atribut produk",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, productVariantErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.Product]{Item: product}, http.StatusOK)
+}
@@ -0,0 +1,237 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"golang_starter_kit_2025/app/helpers"
+	"golang_starter_kit_2025/app/models"
+	"golang_starter_kit_2025/app/requests"
+	"golang_starter_kit_2025/app/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+type PurchaseOrderController struct {
+	service *services.PurchaseOrderService
+}
+
+func NewPurchaseOrderController() *PurchaseOrderController {
+	return &PurchaseOrderController{
+		service: services.NewPurchaseOrderService(),
+	}
+}
+
+// purchaseOrderErrorCode memetakan error dari PurchaseOrderService ke HTTP status code.
+func purchaseOrderErrorCode(err error, fallback int) int {
+	switch {
+	case errors.Is(err, services.ErrStoreForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrPurchaseOrderStatus):
+		return http.StatusConflict
+	case errors.Is(err, services.ErrInvalidPurchaseOrder), errors.Is(err, services.ErrCurrencyMismatch):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, services.ErrVersionRequired):
+		return http.StatusPreconditionRequired
+	}
+	return fallback
+}
+
+// @Summary		Get all purchase orders
+// @Description	API untuk mendapatkan daftar purchase order, dapat difilter per toko, supplier dan status
+// @Tags			PurchaseOrder
+// @Accept			json
+// @Produce		json
+// @Param			request	query		requests.PurchaseOrderFilterRequest	false	"Filter request"
+// @Success		200		{object}	helpers.ResponseParams[models.PurchaseOrder]{data=[]models.PurchaseOrder}
+// @Router			/purchase-orders [get]
+func (c *PurchaseOrderController) List(ctx *gin.Context) {
+	var filters requests.PurchaseOrderFilterRequest
+	if err := ctx.ShouldBindQuery(&filters); err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	orders, total, err := c.service.GetAll(helpers.GetStoreContext(ctx), filters)
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mendapatkan daftar purchase order",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.PurchaseOrder]{Data: &orders, Total: &total}, http.StatusOK)
+}
+
+// @Summary		Get purchase order by ID
+// @Description	API untuk mendapatkan purchase order beserta item dan riwayat penerimaan barang
+// @Tags			PurchaseOrder
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int	true	"Purchase order ID"
+// @Success		200	{object}	helpers.ResponseParams[models.PurchaseOrder]{item=models.PurchaseOrder}
+// @Router			/purchase-orders/{id} [get]
+func (c *PurchaseOrderController) Get(ctx *gin.Context) {
+	order, err := c.service.GetByID(helpers.GetStoreContext(ctx), ctx.Param("id"))
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mendapatkan purchase order",
+			Reference: "ERROR-2",
+			Errors:    map[string]string{"error": err.Error()},
+		}, purchaseOrderErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.SetETag(ctx, order.Version)
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.PurchaseOrder]{Item: &order}, http.StatusOK)
+}
+
+// @Summary		Create or update purchase order
+// @Description	API untuk membuat atau mengupdate purchase order. Purchase order hanya dapat diubah selama berstatus open
+// @Tags			PurchaseOrder
+// @Accept			json
+// @Produce		json
+// @Param			If-Match	header		string								false	"Version purchase order saat update, contoh W/\"3\""
+// @Param			order		body		requests.PurchaseOrderRequestPut	true	"Purchase order request body"
+// @Success		200			{object}	helpers.ResponseParams[models.PurchaseOrder]{item=models.PurchaseOrder}
+// @Router			/purchase-orders [put]
+func (c *PurchaseOrderController) Put(ctx *gin.Context) {
+	var request requests.PurchaseOrderRequestPut
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		var verr validator.ValidationErrors
+		if errors.As(err, &verr) {
+			helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+				Message:   "Periksa kembali form anda",
+				Errors:    helpers.ValidationError(verr),
+				Reference: "ERROR-4",
+			}, http.StatusBadRequest)
+			return
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Errors:    map[string]string{"error": err.Error()},
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	if request.Version == 0 {
+		request.Version = helpers.IfMatchVersion(ctx)
+	}
+
+	store := helpers.GetStoreContext(ctx)
+	order, err := c.service.Put(store, request)
+	if err != nil {
+		if errors.Is(err, services.ErrVersionConflict) {
+			if current, findErr := c.service.GetByID(store, strconv.FormatUint(uint64(request.ID), 10)); findErr == nil {
+				respondVersionConflict(ctx, "Purchase order sudah diubah oleh user lain", err, current, current.Version)
+				return
+			}
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal membuat atau mengupdate purchase order",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, purchaseOrderErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.SetETag(ctx, order.Version)
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.PurchaseOrder]{Item: order}, http.StatusOK)
+}
+
+// @Summary		Cancel purchase order
+// @Description	API untuk membatalkan purchase order yang belum menerima barang
+// @Tags			PurchaseOrder
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int	true	"Purchase order ID"
+// @Success		200	{object}	helpers.ResponseParams[models.PurchaseOrder]{item=models.PurchaseOrder}
+// @Router			/purchase-orders/{id}/cancel [post]
+func (c *PurchaseOrderController) Cancel(ctx *gin.Context) {
+	order, err := c.service.Cancel(helpers.GetStoreContext(ctx), ctx.Param("id"))
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal membatalkan purchase order",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, purchaseOrderErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.PurchaseOrder]{Item: order}, http.StatusOK)
+}
+
+// @Summary		Close purchase order
+// @Description	API untuk menutup purchase order yang sudah diterima sebagian, sisa pesanan tidak lagi ditunggu
+// @Tags			PurchaseOrder
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int	true	"Purchase order ID"
+// @Success		200	{object}	helpers.ResponseParams[models.PurchaseOrder]{item=models.PurchaseOrder}
+// @Router			/purchase-orders/{id}/close [post]
+func (c *PurchaseOrderController) Close(ctx *gin.Context) {
+	order, err := c.service.Close(helpers.GetStoreContext(ctx), ctx.Param("id"))
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal menutup purchase order",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, purchaseOrderErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.PurchaseOrder]{Item: order}, http.StatusOK)
+}
+
+// @Summary		Receive goods
+// @Description	API untuk mencatat penerimaan barang sebagian atau seluruhnya. Stok produk bertambah, tanggal terima dan harga pokok rata-rata produk diperbarui
+// @Tags			PurchaseOrder
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int							true	"Purchase order ID"
+// @Param			receipt	body		requests.GoodsReceiptRequest	true	"Goods receipt request body"
+// @Success		201		{object}	helpers.ResponseParams[models.PurchaseOrder]{item=models.PurchaseOrder}
+// @Router			/purchase-orders/{id}/receipts [post]
+func (c *PurchaseOrderController) Receive(ctx *gin.Context) {
+	var request requests.GoodsReceiptRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		var verr validator.ValidationErrors
+		if errors.As(err, &verr) {
+			helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+				Message:   "Periksa kembali form anda",
+				Errors:    helpers.ValidationError(verr),
+				Reference: "ERROR-4",
+			}, http.StatusBadRequest)
+			return
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Errors:    map[string]string{"error": err.Error()},
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	order, err := c.service.Receive(helpers.GetStoreContext(ctx), ctx.Param("id"), request)
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mencatat penerimaan barang",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, purchaseOrderErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.PurchaseOrder]{Item: order}, http.StatusCreated)
+}
@@ -3,13 +3,15 @@ package controllers
 import (
 	"errors"
 	"net/http"
+	"strconv"
 
 	"golang_starter_kit_2025/app/helpers"
 	"golang_starter_kit_2025/app/models"
+	"golang_starter_kit_2025/app/requests"
 	"golang_starter_kit_2025/app/services"
 
 	"github.com/gin-gonic/gin"
-	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
 )
 
 type RoleController struct {
@@ -41,44 +43,131 @@ func (c *RoleController) List(ctx *gin.Context) {
 	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.Role]{Data: &roles}, 200)
 }
 
+// @Summary		Create Role
+// @Description	API untuk membuat Role
+// @Tags			Role
+// @Accept			json
+// @Produce		json
+// @Param			role	body		requests.RoleRequestPut	true	"Role Data"
+// @Success		201		{object}	helpers.ResponseParams[models.Role]{item=models.Role}
+// @Router			/roles [post]
+func (c *RoleController) Create(ctx *gin.Context) {
+	var request requests.RoleRequestPut
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	request.ID = 0
+	c.save(ctx, request, 201)
+}
+
+// @Summary		Replace Role
+// @Description	API untuk mengganti seluruh data Role
+// @Tags			Role
+// @Accept			json
+// @Produce		json
+// @Param			id			path		int						true	"Role ID"
+// @Param			If-Match	header		string					false	"Version role, contoh W/\"3\""
+// @Param			role		body		requests.RoleRequestPut	true	"Role Data"
+// @Success		200			{object}	helpers.ResponseParams[models.Role]{item=models.Role}
+// @Router			/roles/{id} [put]
+func (c *RoleController) Replace(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+	var request requests.RoleRequestPut
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	request.ID = uint(id)
+	c.save(ctx, request, 200)
+}
+
+// @Summary		Patch Role
+// @Description	API untuk mengubah sebagian data Role dengan JSON Merge Patch (RFC 7396)
+// @Tags			Role
+// @Accept			application/merge-patch+json
+// @Produce		json
+// @Param			id			path		int						true	"Role ID"
+// @Param			If-Match	header		string					false	"Version role, contoh W/\"3\""
+// @Param			role		body		requests.RoleRequestPut	true	"Field Role yang diubah"
+// @Success		200			{object}	helpers.ResponseParams[models.Role]{item=models.Role}
+// @Router			/roles/{id} [patch]
+func (c *RoleController) Patch(ctx *gin.Context) {
+	role, err := c.service.Find(ctx.Param("id"))
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Errors:    map[string]string{"error": err.Error()},
+			Message:   "Role tidak ditemukan",
+			Reference: "ERROR-2",
+		}, 404)
+		return
+	}
+
+	request := requests.NewRoleRequest(role)
+	if err := helpers.BindMergePatch(ctx, &request); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	request.ID = role.ID
+	c.save(ctx, request, 200)
+}
+
 // @Summary		Create/Update Role
-// @Description	API untuk mengupdate atau membuat Role
+// @Description	API untuk mengupdate atau membuat Role berdasarkan id pada body, gunakan POST, PUT /roles/{id} atau PATCH /roles/{id}
 // @Tags			Role
 // @Accept			json
 // @Produce		json
 // @Param			role	body		requests.RoleRequestPut	true	"Role Data"
 // @Success		200		{object}	helpers.ResponseParams[models.Role]{item=models.Role}
+// @Deprecated
 // @Router			/roles [put]
 func (c *RoleController) Put(ctx *gin.Context) {
-	var role models.Role
-	if err := ctx.ShouldBindJSON(&role); err != nil {
-		var verr validator.ValidationErrors
-		if errors.As(err, &verr) {
-			helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
-				Errors:    helpers.ValidationError(verr),
-				Message:   "Parameter tidak valid",
-				Reference: "ERROR-4",
-			}, 400)
-		}
-
-		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
-			Errors:    map[string]string{"error": err.Error()},
-			Message:   "Gagal membuat Role",
-			Reference: "ERROR-3",
-		}, 400)
+	var request requests.RoleRequestPut
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		respondBindError(ctx, err)
 		return
 	}
-	updatedRole, err := c.service.Put(role)
+
+	c.save(ctx, request, 200)
+}
+
+// save menjalankan create atau replace Role dan memetakan error service ke response.
+func (c *RoleController) save(ctx *gin.Context, request requests.RoleRequestPut, status int) {
+	if request.Version == 0 {
+		request.Version = helpers.IfMatchVersion(ctx)
+	}
+
+	role, err := c.service.Put(request)
 	if err != nil {
+		if errors.Is(err, services.ErrVersionConflict) {
+			if current, findErr := c.service.Find(strconv.FormatUint(uint64(request.ID), 10)); findErr == nil {
+				respondVersionConflict(ctx, "Role sudah diubah oleh user lain", err, current, current.Version)
+				return
+			}
+		}
+		code := 400
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			code = 404
+		} else if errors.Is(err, services.ErrVersionRequired) {
+			code = 428
+		}
 		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
 			Errors:    map[string]string{"error": err.Error()},
-			Message:   "Gagal membuat Role",
+			Message:   "Gagal menyimpan Role",
 			Reference: "ERROR-3",
-		}, 400)
+		}, code)
 		return
 	}
 
-	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.Role]{Item: &updatedRole}, 200)
+	helpers.SetETag(ctx, role.Version)
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.Role]{Item: &role}, status)
 }
 
 // @Summary		Delete Role
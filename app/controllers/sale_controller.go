@@ -0,0 +1,218 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"golang_starter_kit_2025/app/helpers"
+	"golang_starter_kit_2025/app/models"
+	"golang_starter_kit_2025/app/requests"
+	"golang_starter_kit_2025/app/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+type SaleController struct {
+	service *services.SaleService
+}
+
+func NewSaleController() *SaleController {
+	return &SaleController{
+		service: services.NewSaleService(),
+	}
+}
+
+// saleErrorCode memetakan error dari SaleService ke HTTP status code.
+func saleErrorCode(err error, fallback int) int {
+	switch {
+	case errors.Is(err, services.ErrStoreForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrInsufficientStock), errors.Is(err, services.ErrSaleNotVoidable), errors.Is(err, services.ErrSaleNotReturnable):
+		return http.StatusConflict
+	case errors.Is(err, services.ErrInvalidSale), errors.Is(err, services.ErrInsufficientPayment), errors.Is(err, services.ErrCurrencyMismatch):
+		return http.StatusUnprocessableEntity
+	}
+	return fallback
+}
+
+// @Summary		Get all sales
+// @Description	API untuk mendapatkan daftar transaksi penjualan, dapat difilter per toko, member, status, metode pembayaran dan tanggal
+// @Tags			Sale
+// @Accept			json
+// @Produce		json
+// @Param			request	query		requests.SaleFilterRequest	false	"Filter request"
+// @Success		200		{object}	helpers.ResponseParams[models.Sale]{data=[]models.Sale}
+// @Router			/sales [get]
+func (c *SaleController) List(ctx *gin.Context) {
+	var filters requests.SaleFilterRequest
+	if err := ctx.ShouldBindQuery(&filters); err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	sales, total, err := c.service.GetAll(helpers.GetStoreContext(ctx), filters)
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mendapatkan daftar transaksi",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.Sale]{Data: &sales, Total: &total}, http.StatusOK)
+}
+
+// @Summary		Get sale by ID
+// @Description	API untuk mendapatkan transaksi penjualan beserta item dan retur
+// @Tags			Sale
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int	true	"Sale ID"
+// @Success		200	{object}	helpers.ResponseParams[models.Sale]{item=models.Sale}
+// @Router			/sales/{id} [get]
+func (c *SaleController) Get(ctx *gin.Context) {
+	sale, err := c.service.GetByID(helpers.GetStoreContext(ctx), ctx.Param("id"))
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mendapatkan transaksi",
+			Reference: "ERROR-2",
+			Errors:    map[string]string{"error": err.Error()},
+		}, saleErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.Sale]{Item: &sale}, http.StatusOK)
+}
+
+// @Summary		Checkout
+// @Description	API untuk mencatat transaksi penjualan, stok produk berkurang dan jumlah terjual bertambah. Harga diambil dari harga produk saat ini
+// @Tags			Sale
+// @Accept			json
+// @Produce		json
+// @Param			sale	body		requests.SaleRequest	true	"Sale request body"
+// @Success		201		{object}	helpers.ResponseParams[models.Sale]{item=models.Sale}
+// @Router			/sales [post]
+func (c *SaleController) Checkout(ctx *gin.Context) {
+	var request requests.SaleRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		var verr validator.ValidationErrors
+		if errors.As(err, &verr) {
+			helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+				Message:   "Periksa kembali form anda",
+				Errors:    helpers.ValidationError(verr),
+				Reference: "ERROR-4",
+			}, http.StatusBadRequest)
+			return
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Errors:    map[string]string{"error": err.Error()},
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	sale, err := c.service.Checkout(helpers.GetStoreContext(ctx), request)
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mencatat transaksi",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, saleErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.Sale]{Item: sale}, http.StatusCreated)
+}
+
+// @Summary		Void sale
+// @Description	API untuk membatalkan transaksi yang belum pernah diretur, stok seluruh item dikembalikan
+// @Tags			Sale
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int						true	"Sale ID"
+// @Param			void	body		requests.SaleVoidRequest	true	"Void request body"
+// @Success		200		{object}	helpers.ResponseParams[models.Sale]{item=models.Sale}
+// @Router			/sales/{id}/void [post]
+func (c *SaleController) Void(ctx *gin.Context) {
+	var request requests.SaleVoidRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		var verr validator.ValidationErrors
+		if errors.As(err, &verr) {
+			helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+				Message:   "Periksa kembali form anda",
+				Errors:    helpers.ValidationError(verr),
+				Reference: "ERROR-4",
+			}, http.StatusBadRequest)
+			return
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Errors:    map[string]string{"error": err.Error()},
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	sale, err := c.service.Void(helpers.GetStoreContext(ctx), ctx.Param("id"), request)
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal membatalkan transaksi",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, saleErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.Sale]{Item: sale}, http.StatusOK)
+}
+
+// @Summary		Return sale items
+// @Description	API untuk meretur sebagian atau seluruh item transaksi, stok item yang diretur dikembalikan
+// @Tags			Sale
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int							true	"Sale ID"
+// @Param			return	body		requests.SaleReturnRequest	true	"Return request body"
+// @Success		201		{object}	helpers.ResponseParams[models.Sale]{item=models.Sale}
+// @Router			/sales/{id}/returns [post]
+func (c *SaleController) Return(ctx *gin.Context) {
+	var request requests.SaleReturnRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		var verr validator.ValidationErrors
+		if errors.As(err, &verr) {
+			helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+				Message:   "Periksa kembali form anda",
+				Errors:    helpers.ValidationError(verr),
+				Reference: "ERROR-4",
+			}, http.StatusBadRequest)
+			return
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Errors:    map[string]string{"error": err.Error()},
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	sale, err := c.service.Return(helpers.GetStoreContext(ctx), ctx.Param("id"), request)
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal meretur transaksi",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, saleErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.Sale]{Item: sale}, http.StatusCreated)
+}
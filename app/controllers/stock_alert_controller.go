@@ -0,0 +1,153 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"golang_starter_kit_2025/app/helpers"
+	"golang_starter_kit_2025/app/models"
+	"golang_starter_kit_2025/app/requests"
+	"golang_starter_kit_2025/app/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+type StockAlertController struct {
+	service *services.StockAlertService
+}
+
+func NewStockAlertController() *StockAlertController {
+	return &StockAlertController{
+		service: services.NewStockAlertService(),
+	}
+}
+
+// stockAlertErrorCode memetakan error dari StockAlertService ke HTTP status code.
+func stockAlertErrorCode(err error, fallback int) int {
+	switch {
+	case errors.Is(err, services.ErrStoreForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrInvalidStockLevel):
+		return http.StatusUnprocessableEntity
+	}
+	return fallback
+}
+
+// @Summary		Get all stock alerts
+// @Description	API untuk mendapatkan daftar alert stok menipis beserta saran jumlah pemesanan ulang
+// @Tags			StockAlert
+// @Accept			json
+// @Produce		json
+// @Param			request	query		requests.StockAlertFilterRequest	false	"Filter request"
+// @Success		200		{object}	helpers.ResponseParams[models.StockAlert]{data=[]models.StockAlert}
+// @Router			/stock-alerts [get]
+func (c *StockAlertController) List(ctx *gin.Context) {
+	var filters requests.StockAlertFilterRequest
+	if err := ctx.ShouldBindQuery(&filters); err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	alerts, total, err := c.service.GetAll(helpers.GetStoreContext(ctx), filters)
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mendapatkan daftar alert stok",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.StockAlert]{Data: &alerts, Total: &total}, http.StatusOK)
+}
+
+// @Summary		Set product stock levels
+// @Description	API untuk mengatur batas stok minimum dan maksimum produk, nilai kosong mengikuti default toko
+// @Tags			Product
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int							true	"Product ID"
+// @Param			levels	body		requests.StockLevelRequest	true	"Stock level request body"
+// @Success		200		{object}	helpers.ResponseParams[models.Product]{item=models.Product}
+// @Router			/products/{id}/stock-levels [put]
+func (c *StockAlertController) ProductLevels(ctx *gin.Context) {
+	var request requests.StockLevelRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		var verr validator.ValidationErrors
+		if errors.As(err, &verr) {
+			helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+				Message:   "Periksa kembali form anda",
+				Errors:    helpers.ValidationError(verr),
+				Reference: "ERROR-4",
+			}, http.StatusBadRequest)
+			return
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Errors:    map[string]string{"error": err.Error()},
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	product, err := c.service.SetProductLevels(helpers.GetStoreContext(ctx), ctx.Param("id"), request)
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mengatur batas stok produk",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, stockAlertErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.Product]{Item: product}, http.StatusOK)
+}
+
+// @Summary		Set store default stock levels
+// @Description	API untuk mengatur batas stok minimum dan maksimum default seluruh produk toko
+// @Tags			Store
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int							true	"Store ID"
+// @Param			levels	body		requests.StockLevelRequest	true	"Stock level request body"
+// @Success		200		{object}	helpers.ResponseParams[models.Store]{item=models.Store}
+// @Router			/stores/{id}/stock-levels [put]
+func (c *StockAlertController) StoreLevels(ctx *gin.Context) {
+	var request requests.StockLevelRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		var verr validator.ValidationErrors
+		if errors.As(err, &verr) {
+			helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+				Message:   "Periksa kembali form anda",
+				Errors:    helpers.ValidationError(verr),
+				Reference: "ERROR-4",
+			}, http.StatusBadRequest)
+			return
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Errors:    map[string]string{"error": err.Error()},
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	store, err := c.service.SetStoreLevels(helpers.GetStoreContext(ctx), ctx.Param("id"), request)
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mengatur batas stok toko",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, stockAlertErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.Store]{Item: store}, http.StatusOK)
+}
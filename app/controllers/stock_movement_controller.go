@@ -0,0 +1,117 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"golang_starter_kit_2025/app/helpers"
+	"golang_starter_kit_2025/app/models"
+	"golang_starter_kit_2025/app/requests"
+	"golang_starter_kit_2025/app/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+type StockMovementController struct {
+	service *services.StockMovementService
+}
+
+func NewStockMovementController() *StockMovementController {
+	return &StockMovementController{
+		service: services.NewStockMovementService(),
+	}
+}
+
+// @Summary		Post stock movement
+// @Description	API untuk mencatat pergerakan stok (receipt, sale, return, adjustment, transfer) sebuah produk
+// @Tags			Product
+// @Accept			json
+// @Produce		json
+// @Param			id			path		int								true	"Product ID"
+// @Param			movement	body		requests.StockMovementRequest	true	"Stock movement request body"
+// @Success		201			{object}	helpers.ResponseParams[models.StockMovement]{item=models.StockMovement}
+// @Router			/products/{id}/movements [post]
+func (c *StockMovementController) Store(ctx *gin.Context) {
+	productID, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	var request requests.StockMovementRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		var verr validator.ValidationErrors
+		if errors.As(err, &verr) {
+			helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+				Message:   "Periksa kembali form anda",
+				Errors:    helpers.ValidationError(verr),
+				Reference: "ERROR-4",
+			}, http.StatusBadRequest)
+			return
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Errors:    map[string]string{"error": err.Error()},
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	movement, err := c.service.Post(helpers.GetStoreContext(ctx), uint(productID), ctx.GetUint("user_id"), request)
+	if err != nil {
+		code := http.StatusBadRequest
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			code = http.StatusNotFound
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mencatat pergerakan stok",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, code)
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.StockMovement]{Item: movement}, http.StatusCreated)
+}
+
+// @Summary		Get stock movement history
+// @Description	API untuk mendapatkan riwayat pergerakan stok sebuah produk
+// @Tags			Product
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int						true	"Product ID"
+// @Param			request	query		requests.FilterRequest	false	"Filter request"
+// @Success		200		{object}	helpers.ResponseParams[models.StockMovement]{data=[]models.StockMovement}
+// @Router			/products/{id}/movements [get]
+func (c *StockMovementController) History(ctx *gin.Context) {
+	var filters requests.FilterRequest
+	if err := ctx.ShouldBindQuery(&filters); err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	movements, total, err := c.service.History(helpers.GetStoreContext(ctx), ctx.Param("id"), filters)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			code = http.StatusNotFound
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mendapatkan riwayat stok",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, code)
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.StockMovement]{Data: &movements, Total: &total}, http.StatusOK)
+}
@@ -0,0 +1,360 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"golang_starter_kit_2025/app/helpers"
+	"golang_starter_kit_2025/app/models"
+	"golang_starter_kit_2025/app/requests"
+	"golang_starter_kit_2025/app/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+type StockTakeController struct {
+	service *services.StockTakeService
+}
+
+func NewStockTakeController() *StockTakeController {
+	return &StockTakeController{
+		service: services.NewStockTakeService(),
+	}
+}
+
+// stockTakeErrorCode memetakan error dari StockTakeService ke HTTP status code.
+func stockTakeErrorCode(err error, fallback int) int {
+	switch {
+	case errors.Is(err, services.ErrStoreForbidden), errors.Is(err, services.ErrPermissionDenied):
+		return http.StatusForbidden
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrStockTakeStatus), errors.Is(err, services.ErrInsufficientStock):
+		return http.StatusConflict
+	case errors.Is(err, services.ErrInvalidStockTake):
+		return http.StatusUnprocessableEntity
+	}
+	return fallback
+}
+
+// @Summary		Get all stock takes
+// @Description	API untuk mendapatkan daftar sesi stock opname, dapat difilter per toko dan status
+// @Tags			StockTake
+// @Accept			json
+// @Produce		json
+// @Param			request	query		requests.StockTakeFilterRequest	false	"Filter request"
+// @Success		200		{object}	helpers.ResponseParams[models.StockTake]{data=[]models.StockTake}
+// @Router			/stock-takes [get]
+func (c *StockTakeController) List(ctx *gin.Context) {
+	var filters requests.StockTakeFilterRequest
+	if err := ctx.ShouldBindQuery(&filters); err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	takes, total, err := c.service.GetAll(helpers.GetStoreContext(ctx), filters)
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mendapatkan daftar stock opname",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.StockTake]{Data: &takes, Total: &total}, http.StatusOK)
+}
+
+// @Summary		Get stock take by ID
+// @Description	API untuk mendapatkan sesi stock opname beserta snapshot, hasil hitung dan selisih setiap produk
+// @Tags			StockTake
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int	true	"Stock take ID"
+// @Success		200	{object}	helpers.ResponseParams[models.StockTake]{item=models.StockTake}
+// @Router			/stock-takes/{id} [get]
+func (c *StockTakeController) Get(ctx *gin.Context) {
+	take, err := c.service.GetByID(helpers.GetStoreContext(ctx), ctx.Param("id"))
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mendapatkan stock opname",
+			Reference: "ERROR-2",
+			Errors:    map[string]string{"error": err.Error()},
+		}, stockTakeErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.StockTake]{Item: &take}, http.StatusOK)
+}
+
+// @Summary		Create stock take
+// @Description	API untuk membuka sesi stock opname, stok sistem seluruh produk toko dibekukan sebagai snapshot
+// @Tags			StockTake
+// @Accept			json
+// @Produce		json
+// @Param			take	body		requests.StockTakeRequest	true	"Stock take request body"
+// @Success		201		{object}	helpers.ResponseParams[models.StockTake]{item=models.StockTake}
+// @Router			/stock-takes [post]
+func (c *StockTakeController) Create(ctx *gin.Context) {
+	var request requests.StockTakeRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		var verr validator.ValidationErrors
+		if errors.As(err, &verr) {
+			helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+				Message:   "Periksa kembali form anda",
+				Errors:    helpers.ValidationError(verr),
+				Reference: "ERROR-4",
+			}, http.StatusBadRequest)
+			return
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Errors:    map[string]string{"error": err.Error()},
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	take, err := c.service.Create(helpers.GetStoreContext(ctx), request)
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal membuat stock opname",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, stockTakeErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.StockTake]{Item: take}, http.StatusCreated)
+}
+
+// @Summary		Save stock take counts
+// @Description	API untuk menyimpan hasil hitung beberapa produk sekaligus, hasil hitung sebelumnya ditimpa
+// @Tags			StockTake
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int								true	"Stock take ID"
+// @Param			counts	body		requests.StockTakeCountRequest	true	"Count request body"
+// @Success		200		{object}	helpers.ResponseParams[models.StockTake]{item=models.StockTake}
+// @Router			/stock-takes/{id}/counts [put]
+func (c *StockTakeController) Count(ctx *gin.Context) {
+	var request requests.StockTakeCountRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		var verr validator.ValidationErrors
+		if errors.As(err, &verr) {
+			helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+				Message:   "Periksa kembali form anda",
+				Errors:    helpers.ValidationError(verr),
+				Reference: "ERROR-4",
+			}, http.StatusBadRequest)
+			return
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Errors:    map[string]string{"error": err.Error()},
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	take, err := c.service.Count(helpers.GetStoreContext(ctx), ctx.Param("id"), request)
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal menyimpan hasil hitung",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, stockTakeErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.StockTake]{Item: take}, http.StatusOK)
+}
+
+// @Summary		Upload stock take counts
+// @Description	API untuk mengunggah hasil hitung dalam file CSV berkolom produk (ID atau reference) dan quantity
+// @Tags			StockTake
+// @Accept			multipart/form-data
+// @Produce		json
+// @Param			id		path		int		true	"Stock take ID"
+// @Param			file	formData	file	true	"File CSV hasil hitung"
+// @Success		200		{object}	helpers.ResponseParams[models.StockTake]{item=models.StockTake}
+// @Router			/stock-takes/{id}/counts/upload [post]
+func (c *StockTakeController) Upload(ctx *gin.Context) {
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Errors:    map[string]string{"file": err.Error()},
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal membaca file",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, http.StatusInternalServerError)
+		return
+	}
+	defer file.Close()
+
+	take, err := c.service.Upload(helpers.GetStoreContext(ctx), ctx.Param("id"), file)
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal menyimpan hasil hitung",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, stockTakeErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.StockTake]{Item: take}, http.StatusOK)
+}
+
+// @Summary		Scan stock take item
+// @Description	API untuk menambah hasil hitung satu produk secara bertahap, misalnya dari pemindai
+// @Tags			StockTake
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int							true	"Stock take ID"
+// @Param			scan	body		requests.StockTakeScanRequest	true	"Scan request body"
+// @Success		200		{object}	helpers.ResponseParams[models.StockTakeItem]{item=models.StockTakeItem}
+// @Router			/stock-takes/{id}/scans [post]
+func (c *StockTakeController) Scan(ctx *gin.Context) {
+	var request requests.StockTakeScanRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		var verr validator.ValidationErrors
+		if errors.As(err, &verr) {
+			helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+				Message:   "Periksa kembali form anda",
+				Errors:    helpers.ValidationError(verr),
+				Reference: "ERROR-4",
+			}, http.StatusBadRequest)
+			return
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Errors:    map[string]string{"error": err.Error()},
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	item, err := c.service.Scan(helpers.GetStoreContext(ctx), ctx.Param("id"), request)
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal menyimpan hasil scan",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, stockTakeErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.StockTakeItem]{Item: item}, http.StatusOK)
+}
+
+// @Summary		Submit stock take
+// @Description	API untuk mengajukan hasil hitung stock opname untuk disetujui
+// @Tags			StockTake
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int	true	"Stock take ID"
+// @Success		200	{object}	helpers.ResponseParams[models.StockTake]{item=models.StockTake}
+// @Router			/stock-takes/{id}/submit [post]
+func (c *StockTakeController) Submit(ctx *gin.Context) {
+	take, err := c.service.Submit(helpers.GetStoreContext(ctx), ctx.Param("id"))
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mengajukan stock opname",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, stockTakeErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.StockTake]{Item: take}, http.StatusOK)
+}
+
+// @Summary		Approve stock take
+// @Description	API untuk menyetujui stock opname, selisih hasil hitung terhadap stok saat approval diposting sebagai movement adjustment. Membutuhkan permission stock_take.approve dan tidak dapat dilakukan oleh user yang mengajukan hasil hitung
+// @Tags			StockTake
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int	true	"Stock take ID"
+// @Success		200	{object}	helpers.ResponseParams[models.StockTake]{item=models.StockTake}
+// @Router			/stock-takes/{id}/approve [post]
+func (c *StockTakeController) Approve(ctx *gin.Context) {
+	take, err := c.service.Approve(helpers.GetStoreContext(ctx), ctx.Param("id"))
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal menyetujui stock opname",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, stockTakeErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.StockTake]{Item: take}, http.StatusOK)
+}
+
+// @Summary		Reject stock take
+// @Description	API untuk mengembalikan stock opname ke tahap penghitungan dengan komentar. Membutuhkan permission stock_take.approve
+// @Tags			StockTake
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int									true	"Stock take ID"
+// @Param			review	body		requests.StockTakeTransitionRequest	true	"Review request body"
+// @Success		200		{object}	helpers.ResponseParams[models.StockTake]{item=models.StockTake}
+// @Router			/stock-takes/{id}/reject [post]
+func (c *StockTakeController) Reject(ctx *gin.Context) {
+	var request requests.StockTakeTransitionRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Errors:    map[string]string{"error": err.Error()},
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	take, err := c.service.Reject(helpers.GetStoreContext(ctx), ctx.Param("id"), request)
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal menolak stock opname",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, stockTakeErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.StockTake]{Item: take}, http.StatusOK)
+}
+
+// @Summary		Cancel stock take
+// @Description	API untuk membatalkan stock opname yang belum disetujui tanpa mengubah stok
+// @Tags			StockTake
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int	true	"Stock take ID"
+// @Success		200	{object}	helpers.ResponseParams[models.StockTake]{item=models.StockTake}
+// @Router			/stock-takes/{id}/cancel [post]
+func (c *StockTakeController) Cancel(ctx *gin.Context) {
+	take, err := c.service.Cancel(helpers.GetStoreContext(ctx), ctx.Param("id"))
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal membatalkan stock opname",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, stockTakeErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.StockTake]{Item: take}, http.StatusOK)
+}
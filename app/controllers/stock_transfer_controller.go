@@ -0,0 +1,269 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"golang_starter_kit_2025/app/helpers"
+	"golang_starter_kit_2025/app/models"
+	"golang_starter_kit_2025/app/requests"
+	"golang_starter_kit_2025/app/responses"
+	"golang_starter_kit_2025/app/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+type StockTransferController struct {
+	service *services.StockTransferService
+}
+
+func NewStockTransferController() *StockTransferController {
+	return &StockTransferController{
+		service: services.NewStockTransferService(),
+	}
+}
+
+// stockTransferErrorCode memetakan error dari StockTransferService ke HTTP status code.
+func stockTransferErrorCode(err error, fallback int) int {
+	switch {
+	case errors.Is(err, services.ErrStoreForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrStockTransferStatus), errors.Is(err, services.ErrInsufficientStock):
+		return http.StatusConflict
+	case errors.Is(err, services.ErrInvalidStockTransfer), errors.Is(err, services.ErrCurrencyMismatch):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, services.ErrVersionRequired):
+		return http.StatusPreconditionRequired
+	}
+	return fallback
+}
+
+// @Summary		Get all stock transfers
+// @Description	API untuk mendapatkan daftar transfer stok dari atau ke toko yang dapat diakses user
+// @Tags			StockTransfer
+// @Accept			json
+// @Produce		json
+// @Param			request	query		requests.StockTransferFilterRequest	false	"Filter request"
+// @Success		200		{object}	helpers.ResponseParams[models.StockTransfer]{data=[]models.StockTransfer}
+// @Router			/stock-transfers [get]
+func (c *StockTransferController) List(ctx *gin.Context) {
+	var filters requests.StockTransferFilterRequest
+	if err := ctx.ShouldBindQuery(&filters); err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	transfers, total, err := c.service.GetAll(helpers.GetStoreContext(ctx), filters)
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mendapatkan daftar transfer stok",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.StockTransfer]{Data: &transfers, Total: &total}, http.StatusOK)
+}
+
+// @Summary		Get stock in transit
+// @Description	API untuk mendapatkan jumlah stok yang sedang dikirim per produk di toko tujuan
+// @Tags			StockTransfer
+// @Accept			json
+// @Produce		json
+// @Param			request	query		requests.StockInTransitFilterRequest	false	"Filter request"
+// @Success		200		{object}	helpers.ResponseParams[responses.StockInTransit]{data=[]responses.StockInTransit}
+// @Router			/stock-transfers/in-transit [get]
+func (c *StockTransferController) InTransit(ctx *gin.Context) {
+	var filters requests.StockInTransitFilterRequest
+	if err := ctx.ShouldBindQuery(&filters); err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	results, err := c.service.InTransit(helpers.GetStoreContext(ctx), filters)
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mendapatkan stok dalam perjalanan",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[responses.StockInTransit]{Data: &results}, http.StatusOK)
+}
+
+// @Summary		Get stock transfer by ID
+// @Description	API untuk mendapatkan transfer stok beserta item
+// @Tags			StockTransfer
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int	true	"Stock transfer ID"
+// @Success		200	{object}	helpers.ResponseParams[models.StockTransfer]{item=models.StockTransfer}
+// @Router			/stock-transfers/{id} [get]
+func (c *StockTransferController) Get(ctx *gin.Context) {
+	transfer, err := c.service.GetByID(helpers.GetStoreContext(ctx), ctx.Param("id"))
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mendapatkan transfer stok",
+			Reference: "ERROR-2",
+			Errors:    map[string]string{"error": err.Error()},
+		}, stockTransferErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.SetETag(ctx, transfer.Version)
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.StockTransfer]{Item: &transfer}, http.StatusOK)
+}
+
+// @Summary		Create or update stock transfer
+// @Description	API untuk membuat atau mengupdate draft transfer stok. User harus terdaftar di toko asal dan toko tujuan
+// @Tags			StockTransfer
+// @Accept			json
+// @Produce		json
+// @Param			If-Match	header		string								false	"Version transfer stok saat update, contoh W/\"3\""
+// @Param			transfer	body		requests.StockTransferRequestPut	true	"Stock transfer request body"
+// @Success		200			{object}	helpers.ResponseParams[models.StockTransfer]{item=models.StockTransfer}
+// @Router			/stock-transfers [put]
+func (c *StockTransferController) Put(ctx *gin.Context) {
+	var request requests.StockTransferRequestPut
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		var verr validator.ValidationErrors
+		if errors.As(err, &verr) {
+			helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+				Message:   "Periksa kembali form anda",
+				Errors:    helpers.ValidationError(verr),
+				Reference: "ERROR-4",
+			}, http.StatusBadRequest)
+			return
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Errors:    map[string]string{"error": err.Error()},
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	if request.Version == 0 {
+		request.Version = helpers.IfMatchVersion(ctx)
+	}
+
+	store := helpers.GetStoreContext(ctx)
+	transfer, err := c.service.Put(store, request)
+	if err != nil {
+		if errors.Is(err, services.ErrVersionConflict) {
+			if current, findErr := c.service.GetByID(store, strconv.FormatUint(uint64(request.ID), 10)); findErr == nil {
+				respondVersionConflict(ctx, "Transfer stok sudah diubah oleh user lain", err, current, current.Version)
+				return
+			}
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal membuat atau mengupdate transfer stok",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, stockTransferErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.SetETag(ctx, transfer.Version)
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.StockTransfer]{Item: transfer}, http.StatusOK)
+}
+
+// @Summary		Dispatch stock transfer
+// @Description	API untuk mengirim transfer stok, stok toko asal berkurang dan barang berstatus in transit
+// @Tags			StockTransfer
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int	true	"Stock transfer ID"
+// @Success		200	{object}	helpers.ResponseParams[models.StockTransfer]{item=models.StockTransfer}
+// @Router			/stock-transfers/{id}/dispatch [post]
+func (c *StockTransferController) Dispatch(ctx *gin.Context) {
+	transfer, err := c.service.Dispatch(helpers.GetStoreContext(ctx), ctx.Param("id"))
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mengirim transfer stok",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, stockTransferErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.StockTransfer]{Item: transfer}, http.StatusOK)
+}
+
+// @Summary		Receive stock transfer
+// @Description	API untuk menerima transfer stok di toko tujuan. Selisih quantity diterima wajib diberi catatan
+// @Tags			StockTransfer
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int									true	"Stock transfer ID"
+// @Param			receipt	body		requests.StockTransferReceiveRequest	true	"Receive request body"
+// @Success		200		{object}	helpers.ResponseParams[models.StockTransfer]{item=models.StockTransfer}
+// @Router			/stock-transfers/{id}/receive [post]
+func (c *StockTransferController) Receive(ctx *gin.Context) {
+	var request requests.StockTransferReceiveRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		var verr validator.ValidationErrors
+		if errors.As(err, &verr) {
+			helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+				Message:   "Periksa kembali form anda",
+				Errors:    helpers.ValidationError(verr),
+				Reference: "ERROR-4",
+			}, http.StatusBadRequest)
+			return
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Errors:    map[string]string{"error": err.Error()},
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	transfer, err := c.service.Receive(helpers.GetStoreContext(ctx), ctx.Param("id"), request)
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal menerima transfer stok",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, stockTransferErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.StockTransfer]{Item: transfer}, http.StatusOK)
+}
+
+// @Summary		Cancel stock transfer
+// @Description	API untuk membatalkan transfer stok yang belum dikirim
+// @Tags			StockTransfer
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int	true	"Stock transfer ID"
+// @Success		200	{object}	helpers.ResponseParams[models.StockTransfer]{item=models.StockTransfer}
+// @Router			/stock-transfers/{id}/cancel [post]
+func (c *StockTransferController) Cancel(ctx *gin.Context) {
+	transfer, err := c.service.Cancel(helpers.GetStoreContext(ctx), ctx.Param("id"))
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal membatalkan transfer stok",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, stockTransferErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.StockTransfer]{Item: transfer}, http.StatusOK)
+}
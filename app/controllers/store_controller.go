@@ -0,0 +1,341 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"golang_starter_kit_2025/app/helpers"
+	"golang_starter_kit_2025/app/models"
+	"golang_starter_kit_2025/app/requests"
+	"golang_starter_kit_2025/app/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type StoreController struct {
+	service *services.StoreService
+}
+
+func NewStoreController() *StoreController {
+	return &StoreController{
+		service: services.NewStoreService(),
+	}
+}
+
+// @Summary		Get all stores
+// @Description	API untuk mendapatkan semua toko
+// @Tags			Store
+// @Accept			json
+// @Produce		json
+// @Param			request	query		requests.FilterRequest	false	"Filter request"
+// @Success		200		{object}	helpers.ResponseParams[models.Store]{data=[]models.Store}
+// @Router			/stores [get]
+func (c *StoreController) List(ctx *gin.Context) {
+	var filters requests.FilterRequest
+	if err := ctx.ShouldBindQuery(&filters); err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	stores, total, err := c.service.GetAll(helpers.GetStoreContext(ctx), filters)
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mendapatkan daftar toko",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.Store]{Data: &stores, Total: &total}, http.StatusOK)
+}
+
+// @Summary		Get store by ID
+// @Description	API untuk mendapatkan toko berdasarkan ID
+// @Tags			Store
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int	true	"Store ID"
+// @Success		200	{object}	helpers.ResponseParams[models.Store]{item=models.Store}
+// @Router			/stores/{id} [get]
+func (c *StoreController) Get(ctx *gin.Context) {
+	store, err := c.service.GetByID(helpers.GetStoreContext(ctx), ctx.Param("id"))
+	if err != nil {
+		code := http.StatusNotFound
+		if errors.Is(err, services.ErrStoreForbidden) {
+			code = http.StatusForbidden
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mendapatkan toko",
+			Reference: "ERROR-2",
+			Errors:    map[string]string{"error": err.Error()},
+		}, code)
+		return
+	}
+
+	helpers.SetETag(ctx, store.Version)
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.Store]{Item: &store}, http.StatusOK)
+}
+
+// @Summary		Create store
+// @Description	API untuk membuat toko
+// @Tags			Store
+// @Accept			json
+// @Produce		json
+// @Param			store	body		requests.StoreRequestPut	true	"Store request body"
+// @Success		201		{object}	helpers.ResponseParams[models.Store]{item=models.Store}
+// @Router			/stores [post]
+func (c *StoreController) Create(ctx *gin.Context) {
+	var request requests.StoreRequestPut
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	request.ID = 0
+	c.save(ctx, request, http.StatusCreated)
+}
+
+// @Summary		Replace store
+// @Description	API untuk mengganti seluruh data toko, field yang tidak dikirim dikosongkan
+// @Tags			Store
+// @Accept			json
+// @Produce		json
+// @Param			id			path		int							true	"Store ID"
+// @Param			If-Match	header		string						false	"Version toko, contoh W/\"3\""
+// @Param			store		body		requests.StoreRequestPut	true	"Store request body"
+// @Success		200			{object}	helpers.ResponseParams[models.Store]{item=models.Store}
+// @Router			/stores/{id} [put]
+func (c *StoreController) Replace(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+	var request requests.StoreRequestPut
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	request.ID = uint(id)
+	c.save(ctx, request, http.StatusOK)
+}
+
+// @Summary		Patch store
+// @Description	API untuk mengubah sebagian data toko dengan JSON Merge Patch (RFC 7396), null mengosongkan field
+// @Tags			Store
+// @Accept			application/merge-patch+json
+// @Produce		json
+// @Param			id			path		int							true	"Store ID"
+// @Param			If-Match	header		string						false	"Version toko, contoh W/\"3\""
+// @Param			store		body		requests.StoreRequestPut	true	"Field toko yang diubah"
+// @Success		200			{object}	helpers.ResponseParams[models.Store]{item=models.Store}
+// @Router			/stores/{id} [patch]
+func (c *StoreController) Patch(ctx *gin.Context) {
+	current, err := c.service.GetByID(helpers.GetStoreContext(ctx), ctx.Param("id"))
+	if err != nil {
+		code := http.StatusNotFound
+		if errors.Is(err, services.ErrStoreForbidden) {
+			code = http.StatusForbidden
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mendapatkan toko",
+			Reference: "ERROR-2",
+			Errors:    map[string]string{"error": err.Error()},
+		}, code)
+		return
+	}
+
+	request := requests.NewStoreRequest(current)
+	if err := helpers.BindMergePatch(ctx, &request); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	request.ID = current.ID
+	c.save(ctx, request, http.StatusOK)
+}
+
+// @Summary		Create/Update store
+// @Description	API untuk membuat atau mengupdate toko berdasarkan id pada body, gunakan POST, PUT /stores/{id} atau PATCH /stores/{id}
+// @Tags			Store
+// @Accept			json
+// @Produce		json
+// @Param			store	body		requests.StoreRequestPut	true	"Store request body"
+// @Success		200		{object}	helpers.ResponseParams[models.Store]{item=models.Store}
+// @Deprecated
+// @Router			/stores [put]
+func (c *StoreController) Put(ctx *gin.Context) {
+	var request requests.StoreRequestPut
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		var verr validator.ValidationErrors
+		if errors.As(err, &verr) {
+			helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+				Message:   "Periksa kembali form anda",
+				Errors:    helpers.ValidationError(verr),
+				Reference: "ERROR-4",
+			}, http.StatusBadRequest)
+			return
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Errors:    map[string]string{"error": err.Error()},
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	c.save(ctx, request, http.StatusOK)
+}
+
+// save menjalankan create atau replace toko dan memetakan error service ke response.
+func (c *StoreController) save(ctx *gin.Context, request requests.StoreRequestPut, status int) {
+	if request.Version == 0 {
+		request.Version = helpers.IfMatchVersion(ctx)
+	}
+
+	storeContext := helpers.GetStoreContext(ctx)
+	store, err := c.service.Put(storeContext, request)
+	if err != nil {
+		if errors.Is(err, services.ErrVersionConflict) {
+			if current, findErr := c.service.GetByID(storeContext, strconv.FormatUint(uint64(request.ID), 10)); findErr == nil {
+				respondVersionConflict(ctx, "Toko sudah diubah oleh user lain", err, current, current.Version)
+				return
+			}
+		}
+		code := http.StatusInternalServerError
+		if errors.Is(err, services.ErrStoreForbidden) {
+			code = http.StatusForbidden
+		} else if errors.Is(err, services.ErrVersionRequired) {
+			code = http.StatusPreconditionRequired
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal membuat atau mengupdate toko",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, code)
+		return
+	}
+
+	helpers.SetETag(ctx, store.Version)
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.Store]{Item: store}, status)
+}
+
+// @Summary		Delete store
+// @Description	API untuk menghapus toko
+// @Tags			Store
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int	true	"Store ID"
+// @Success		200	{object}	helpers.ResponseParams[any]
+// @Router			/stores/{id} [delete]
+func (c *StoreController) Delete(ctx *gin.Context) {
+	if err := c.service.Delete(helpers.GetStoreContext(ctx), ctx.Param("id")); err != nil {
+		code := http.StatusNotFound
+		if errors.Is(err, services.ErrStoreForbidden) {
+			code = http.StatusForbidden
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal menghapus toko",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, code)
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[any]{}, http.StatusOK)
+}
+
+// @Summary		Get store users
+// @Description	API untuk mendapatkan user yang terdaftar di toko
+// @Tags			Store
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int	true	"Store ID"
+// @Success		200	{object}	helpers.ResponseParams[models.StoreUser]{data=[]models.StoreUser}
+// @Router			/stores/{id}/users [get]
+func (c *StoreController) Users(ctx *gin.Context) {
+	storeUsers, err := c.service.GetUsers(helpers.GetStoreContext(ctx), ctx.Param("id"))
+	if err != nil {
+		code := http.StatusNotFound
+		if errors.Is(err, services.ErrStoreForbidden) {
+			code = http.StatusForbidden
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mendapatkan user toko",
+			Reference: "ERROR-2",
+			Errors:    map[string]string{"error": err.Error()},
+		}, code)
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.StoreUser]{Data: &storeUsers}, http.StatusOK)
+}
+
+// @Summary		Attach users to store
+// @Description	API untuk menambahkan user ke toko, khusus admin
+// @Tags			Store
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int							true	"Store ID"
+// @Param			users	body		requests.StoreRequestUsers	true	"User IDs"
+// @Success		200		{object}	helpers.ResponseParams[any]
+// @Router			/stores/{id}/users [post]
+func (c *StoreController) AttachUsers(ctx *gin.Context) {
+	var request requests.StoreRequestUsers
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Errors:    map[string]string{"error": err.Error()},
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	if err := c.service.AttachUsers(helpers.GetStoreContext(ctx), ctx.Param("id"), request.UserIDs); err != nil {
+		code := http.StatusBadRequest
+		if errors.Is(err, services.ErrStoreForbidden) {
+			code = http.StatusForbidden
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal menambahkan user ke toko",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, code)
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[any]{Message: "User ditambahkan ke toko"}, http.StatusOK)
+}
+
+// @Summary		Detach user from store
+// @Description	API untuk mengeluarkan user dari toko, khusus admin
+// @Tags			Store
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int	true	"Store ID"
+// @Param			user_id	path		int	true	"User ID"
+// @Success		200		{object}	helpers.ResponseParams[any]
+// @Router			/stores/{id}/users/{user_id} [delete]
+func (c *StoreController) DetachUser(ctx *gin.Context) {
+	if err := c.service.DetachUser(helpers.GetStoreContext(ctx), ctx.Param("id"), ctx.Param("user_id")); err != nil {
+		code := http.StatusNotFound
+		if errors.Is(err, services.ErrStoreForbidden) {
+			code = http.StatusForbidden
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mengeluarkan user dari toko",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, code)
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[any]{Message: "User dikeluarkan dari toko"}, http.StatusOK)
+}
@@ -0,0 +1,249 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"golang_starter_kit_2025/app/helpers"
+	"golang_starter_kit_2025/app/models"
+	"golang_starter_kit_2025/app/requests"
+	"golang_starter_kit_2025/app/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+type SupplierController struct {
+	service *services.SupplierService
+}
+
+func NewSupplierController() *SupplierController {
+	return &SupplierController{
+		service: services.NewSupplierService(),
+	}
+}
+
+// supplierErrorCode memetakan error dari SupplierService ke HTTP status code.
+func supplierErrorCode(err error, fallback int) int {
+	switch {
+	case errors.Is(err, services.ErrStoreForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrVersionRequired):
+		return http.StatusPreconditionRequired
+	}
+	return fallback
+}
+
+// @Summary		Get all suppliers
+// @Description	API untuk mendapatkan daftar supplier, dapat difilter per toko
+// @Tags			Supplier
+// @Accept			json
+// @Produce		json
+// @Param			request	query		requests.SupplierFilterRequest	false	"Filter request"
+// @Success		200		{object}	helpers.ResponseParams[models.Supplier]{data=[]models.Supplier}
+// @Router			/suppliers [get]
+func (c *SupplierController) List(ctx *gin.Context) {
+	var filters requests.SupplierFilterRequest
+	if err := ctx.ShouldBindQuery(&filters); err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	suppliers, total, err := c.service.GetAll(helpers.GetStoreContext(ctx), filters)
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mendapatkan daftar supplier",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.Supplier]{Data: &suppliers, Total: &total}, http.StatusOK)
+}
+
+// @Summary		Get supplier by ID
+// @Description	API untuk mendapatkan detail supplier
+// @Tags			Supplier
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int	true	"Supplier ID"
+// @Success		200	{object}	helpers.ResponseParams[models.Supplier]{item=models.Supplier}
+// @Router			/suppliers/{id} [get]
+func (c *SupplierController) Get(ctx *gin.Context) {
+	supplier, err := c.service.GetByID(helpers.GetStoreContext(ctx), ctx.Param("id"))
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mendapatkan supplier",
+			Reference: "ERROR-2",
+			Errors:    map[string]string{"error": err.Error()},
+		}, supplierErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.SetETag(ctx, supplier.Version)
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.Supplier]{Item: &supplier}, http.StatusOK)
+}
+
+// @Summary		Create supplier
+// @Description	API untuk membuat supplier
+// @Tags			Supplier
+// @Accept			json
+// @Produce		json
+// @Param			supplier	body		requests.SupplierRequestPut	true	"Supplier request body"
+// @Success		201			{object}	helpers.ResponseParams[models.Supplier]{item=models.Supplier}
+// @Router			/suppliers [post]
+func (c *SupplierController) Create(ctx *gin.Context) {
+	var request requests.SupplierRequestPut
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	request.ID = 0
+	c.save(ctx, request, http.StatusCreated)
+}
+
+// @Summary		Replace supplier
+// @Description	API untuk mengganti seluruh data supplier, field yang tidak dikirim dikosongkan
+// @Tags			Supplier
+// @Accept			json
+// @Produce		json
+// @Param			id			path		int							true	"Supplier ID"
+// @Param			If-Match	header		string						false	"Version supplier, contoh W/\"3\""
+// @Param			supplier	body		requests.SupplierRequestPut	true	"Supplier request body"
+// @Success		200			{object}	helpers.ResponseParams[models.Supplier]{item=models.Supplier}
+// @Router			/suppliers/{id} [put]
+func (c *SupplierController) Replace(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+	var request requests.SupplierRequestPut
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	request.ID = uint(id)
+	c.save(ctx, request, http.StatusOK)
+}
+
+// @Summary		Patch supplier
+// @Description	API untuk mengubah sebagian data supplier dengan JSON Merge Patch (RFC 7396), null mengosongkan field
+// @Tags			Supplier
+// @Accept			application/merge-patch+json
+// @Produce		json
+// @Param			id			path		int							true	"Supplier ID"
+// @Param			If-Match	header		string						false	"Version supplier, contoh W/\"3\""
+// @Param			supplier	body		requests.SupplierRequestPut	true	"Field supplier yang diubah"
+// @Success		200			{object}	helpers.ResponseParams[models.Supplier]{item=models.Supplier}
+// @Router			/suppliers/{id} [patch]
+func (c *SupplierController) Patch(ctx *gin.Context) {
+	current, err := c.service.GetByID(helpers.GetStoreContext(ctx), ctx.Param("id"))
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mendapatkan supplier",
+			Reference: "ERROR-2",
+			Errors:    map[string]string{"error": err.Error()},
+		}, supplierErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	request := requests.NewSupplierRequest(current)
+	if err := helpers.BindMergePatch(ctx, &request); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	request.ID = current.ID
+	c.save(ctx, request, http.StatusOK)
+}
+
+// @Summary		Create or update supplier
+// @Description	API untuk membuat atau mengupdate supplier berdasarkan id pada body, gunakan POST, PUT /suppliers/{id} atau PATCH /suppliers/{id}
+// @Tags			Supplier
+// @Accept			json
+// @Produce		json
+// @Param			supplier	body		requests.SupplierRequestPut	true	"Supplier request body"
+// @Success		200			{object}	helpers.ResponseParams[models.Supplier]{item=models.Supplier}
+// @Deprecated
+// @Router			/suppliers [put]
+func (c *SupplierController) Put(ctx *gin.Context) {
+	var request requests.SupplierRequestPut
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		var verr validator.ValidationErrors
+		if errors.As(err, &verr) {
+			helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+				Message:   "Periksa kembali form anda",
+				Errors:    helpers.ValidationError(verr),
+				Reference: "ERROR-4",
+			}, http.StatusBadRequest)
+			return
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Errors:    map[string]string{"error": err.Error()},
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	c.save(ctx, request, http.StatusOK)
+}
+
+// save menjalankan create atau replace supplier dan memetakan error service ke response.
+func (c *SupplierController) save(ctx *gin.Context, request requests.SupplierRequestPut, status int) {
+	if request.Version == 0 {
+		request.Version = helpers.IfMatchVersion(ctx)
+	}
+
+	store := helpers.GetStoreContext(ctx)
+	supplier, err := c.service.Put(store, request)
+	if err != nil {
+		if errors.Is(err, services.ErrVersionConflict) {
+			if current, findErr := c.service.GetByID(store, strconv.FormatUint(uint64(request.ID), 10)); findErr == nil {
+				respondVersionConflict(ctx, "Supplier sudah diubah oleh user lain", err, current, current.Version)
+				return
+			}
+		}
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal membuat atau mengupdate supplier",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, supplierErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.SetETag(ctx, supplier.Version)
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[models.Supplier]{Item: supplier}, status)
+}
+
+// @Summary		Delete supplier
+// @Description	API untuk menghapus supplier
+// @Tags			Supplier
+// @Accept			json
+// @Produce		json
+// @Param			id	path		int	true	"Supplier ID"
+// @Success		200	{object}	helpers.ResponseParams[any]
+// @Router			/suppliers/{id} [delete]
+func (c *SupplierController) Delete(ctx *gin.Context) {
+	if err := c.service.Delete(helpers.GetStoreContext(ctx), ctx.Param("id")); err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal menghapus supplier",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, supplierErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[any]{}, http.StatusOK)
+}
@@ -0,0 +1,116 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"golang_starter_kit_2025/app/helpers"
+	"golang_starter_kit_2025/app/requests"
+	"golang_starter_kit_2025/app/services"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// TrashController melayani trash satu resource, contoh
+// NewTrashController(services.TrashProducts) untuk /products/trash.
+type TrashController struct {
+	resource string
+	service  *services.TrashService
+}
+
+func NewTrashController(resource string) *TrashController {
+	return &TrashController{
+		resource: resource,
+		service:  services.NewTrashService(),
+	}
+}
+
+// trashErrorCode memetakan error dari TrashService ke HTTP status code.
+func trashErrorCode(err error, fallback int) int {
+	switch {
+	case errors.Is(err, services.ErrStoreForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, gorm.ErrRecordNotFound), errors.Is(err, services.ErrTrashResource):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrTrashConflict):
+		return http.StatusConflict
+	}
+	return fallback
+}
+
+// @Summary		Get trashed records
+// @Description	API untuk mendapatkan data yang sudah dihapus (soft delete), resource global hanya untuk admin
+// @Tags			Trash
+// @Accept			json
+// @Produce		json
+// @Param			resource	path		string					true	"Resource"	Enums(products, categories, users, roles, permissions)
+// @Param			request		query		requests.FilterRequest	false	"Filter request"
+// @Success		200			{object}	helpers.ResponseParams[any]{data=[]any}
+// @Router			/{resource}/trash [get]
+func (c *TrashController) List(ctx *gin.Context) {
+	var filters requests.FilterRequest
+	if err := ctx.ShouldBindQuery(&filters); err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Parameter tidak valid",
+			Reference: "ERROR-4",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	items, total, err := c.service.GetAll(helpers.GetStoreContext(ctx), c.resource, filters)
+	if err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal mendapatkan data trash",
+			Reference: "ERROR-2",
+			Errors:    map[string]string{"error": err.Error()},
+		}, trashErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[any]{Data: &items, Total: &total}, http.StatusOK)
+}
+
+// @Summary		Restore trashed record
+// @Description	API untuk memulihkan data yang sudah dihapus
+// @Tags			Trash
+// @Accept			json
+// @Produce		json
+// @Param			resource	path		string	true	"Resource"	Enums(products, categories, users, roles, permissions)
+// @Param			id			path		int		true	"Record ID"
+// @Success		200			{object}	helpers.ResponseParams[any]
+// @Router			/{resource}/{id}/restore [post]
+func (c *TrashController) Restore(ctx *gin.Context) {
+	if err := c.service.Restore(helpers.GetStoreContext(ctx), c.resource, ctx.Param("id")); err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal memulihkan data",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, trashErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[any]{Message: "Data berhasil dipulihkan"}, http.StatusOK)
+}
+
+// @Summary		Permanently delete trashed record
+// @Description	API untuk menghapus permanen data yang sudah berada di trash, produk dengan riwayat stok atau harga tidak dapat dihapus permanen
+// @Tags			Trash
+// @Accept			json
+// @Produce		json
+// @Param			resource	path		string	true	"Resource"	Enums(products, categories, users, roles, permissions)
+// @Param			id			path		int		true	"Record ID"
+// @Success		200			{object}	helpers.ResponseParams[any]
+// @Router			/{resource}/{id}/force [delete]
+func (c *TrashController) ForceDelete(ctx *gin.Context) {
+	if err := c.service.ForceDelete(helpers.GetStoreContext(ctx), c.resource, ctx.Param("id")); err != nil {
+		helpers.ResponseError(ctx, &helpers.ResponseParams[any]{
+			Message:   "Gagal menghapus permanen data",
+			Reference: "ERROR-3",
+			Errors:    map[string]string{"error": err.Error()},
+		}, trashErrorCode(err, http.StatusInternalServerError))
+		return
+	}
+
+	helpers.ResponseSuccess(ctx, &helpers.ResponseParams[any]{Message: "Data berhasil dihapus permanen"}, http.StatusOK)
+}
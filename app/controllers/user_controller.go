@@ -1,12 +1,16 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
-	"golang_starter_kit_2025/app/models"
+	"golang_starter_kit_2025/app/helpers"
+	"golang_starter_kit_2025/app/requests"
 	"golang_starter_kit_2025/app/services"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 type UserController struct {
@@ -47,28 +51,123 @@ func (c *UserController) Get(ctx *gin.Context) {
 		ctx.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
 		return
 	}
+	helpers.SetETag(ctx, user.Version)
 	ctx.JSON(http.StatusOK, user)
 }
 
-// @Summary	Upsert a user
+// @Summary	Create a user
 // @Tags		users
 // @Accept		json
 // @Produce	json
-// @Success	200	{object}	models.User
-// @Router		/users [put]
-// @Param		JSON	body	models.User	true	"User object"
+// @Param		JSON	body		requests.UserRequest	true	"User object"
+// @Success	201		{object}	models.User
+// @Router		/users [post]
+func (c *UserController) Create(ctx *gin.Context) {
+	var request requests.UserRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	request.ID = 0
+	c.save(ctx, request, http.StatusCreated)
+}
+
+// @Summary	Replace a user
+// @Tags		users
+// @Accept		json
+// @Produce	json
+// @Param		id			path		int						true	"User ID"
+// @Param		If-Match	header		string					false	"Version user, contoh W/\"3\""
+// @Param		JSON		body		requests.UserRequest	true	"User object"
+// @Success	200			{object}	models.User
+// @Router		/users/{id} [put]
+func (c *UserController) Replace(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var request requests.UserRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	request.ID = uint(id)
+	c.save(ctx, request, http.StatusOK)
+}
+
+// @Summary		Patch a user
+// @Description	Mengubah sebagian data user dengan JSON Merge Patch (RFC 7396)
+// @Tags			users
+// @Accept			application/merge-patch+json
+// @Produce		json
+// @Param			id			path		int						true	"User ID"
+// @Param			If-Match	header		string					false	"Version user, contoh W/\"3\""
+// @Param			JSON		body		requests.UserRequest	true	"Field user yang diubah"
+// @Success		200			{object}	models.User
+// @Router			/users/{id} [patch]
+func (c *UserController) Patch(ctx *gin.Context) {
+	user, err := c.service.Find(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+	request := requests.NewUserRequest(user)
+	if err := helpers.BindMergePatch(ctx, &request); err != nil {
+		ctx.JSON(bindErrorCode(err), gin.H{"error": err.Error()})
+		return
+	}
+	request.ID = user.ID
+	c.save(ctx, request, http.StatusOK)
+}
+
+// @Summary		Upsert a user
+// @Description	Deprecated, gunakan POST /users, PUT /users/{id} atau PATCH /users/{id}
+// @Tags			users
+// @Accept			json
+// @Produce		json
+// @Success		201	{object}	models.User
+// @Deprecated
+// @Router			/users [put]
+// @Param			JSON	body	requests.UserRequest	true	"User object"
 func (c *UserController) Put(ctx *gin.Context) {
-	var user models.User
-	if err := ctx.ShouldBindJSON(&user); err != nil {
+	var request requests.UserRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	updatedUser, err := c.service.Put(user)
+	c.save(ctx, request, http.StatusCreated)
+}
+
+func (c *UserController) save(ctx *gin.Context, request requests.UserRequest, status int) {
+	if request.Version == 0 {
+		request.Version = helpers.IfMatchVersion(ctx)
+	}
+
+	user, err := c.service.Put(request)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		switch {
+		case errors.Is(err, services.ErrVersionConflict):
+			// user terbaru dikirim kembali agar client dapat menggabungkan perubahan
+			if current, findErr := c.service.Find(strconv.FormatUint(uint64(request.ID), 10)); findErr == nil {
+				helpers.SetETag(ctx, current.Version)
+				ctx.JSON(http.StatusConflict, gin.H{"error": err.Error(), "user": current})
+				return
+			}
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrVersionRequired):
+			ctx.JSON(http.StatusPreconditionRequired, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrPasswordRequired):
+			ctx.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
 		return
 	}
-	ctx.JSON(http.StatusCreated, updatedUser)
+	helpers.SetETag(ctx, user.Version)
+	ctx.JSON(status, user)
 }
 
 // @Summary	Delete a user
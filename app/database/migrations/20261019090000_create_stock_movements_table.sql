@@ -0,0 +1,27 @@
+-- +++ UP Migration
+CREATE TABLE stock_movements (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    reference VARCHAR(100) UNIQUE NOT NULL,
+    product_id BIGINT NOT NULL,
+    type ENUM('receipt', 'sale', 'return', 'adjustment', 'transfer') NOT NULL,
+    quantity INT NOT NULL,
+    stock_before INT NOT NULL DEFAULT 0,
+    stock_after INT NOT NULL DEFAULT 0,
+    reason VARCHAR(255),
+    actor_id BIGINT,
+    reference_document VARCHAR(255),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_stock_movements_product_created (product_id, created_at),
+    FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE,
+    FOREIGN KEY (actor_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+-- saldo awal: stok yang sudah ada sebelum ledger dicatat sebagai satu adjustment
+-- per produk sehingga SUM(quantity) sama dengan stok saat migrasi dijalankan
+INSERT INTO stock_movements (reference, product_id, type, quantity, stock_before, stock_after, reason)
+SELECT CONCAT('STM-OPENING-', id), id, 'adjustment', stock, 0, stock, 'Saldo awal sebelum ledger stok'
+FROM products
+WHERE stock IS NOT NULL AND stock <> 0;
+-- --- DOWN Migration
+DROP TABLE IF EXISTS stock_movements;
@@ -0,0 +1,15 @@
+-- +++ UP Migration
+ALTER TABLE stores
+MODIFY COLUMN id BIGINT NOT NULL AUTO_INCREMENT;
+
+UPDATE products SET store_id = NULL WHERE store_id IS NOT NULL AND store_id NOT IN (SELECT id FROM stores);
+
+ALTER TABLE products
+ADD CONSTRAINT fk_products_store_id FOREIGN KEY (store_id) REFERENCES stores(id) ON DELETE SET NULL;
+
+-- --- DOWN Migration
+ALTER TABLE products
+DROP FOREIGN KEY fk_products_store_id;
+
+ALTER TABLE stores
+MODIFY COLUMN id INT NOT NULL AUTO_INCREMENT;
@@ -0,0 +1,17 @@
+-- +++ UP Migration
+CREATE TABLE members (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    reference VARCHAR(100) UNIQUE NOT NULL,
+    nama VARCHAR(255) NOT NULL,
+    phone VARCHAR(50) NOT NULL,
+    alamat TEXT,
+    nik CHAR(16) NOT NULL,
+    jenis_kelamin VARCHAR(20) NOT NULL,
+    foto_ktp VARCHAR(255),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL DEFAULT NULL,
+    UNIQUE KEY uq_members_nik (nik)
+);
+-- --- DOWN Migration
+DROP TABLE IF EXISTS members;
@@ -0,0 +1,20 @@
+-- +++ UP Migration
+CREATE TABLE member_lands (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    reference VARCHAR(100) UNIQUE NOT NULL,
+    member_id BIGINT NOT NULL,
+    jenis_tanam JSON,
+    jumlah_panen INT NOT NULL DEFAULT 0,
+    luas_lahan DECIMAL(12, 4) NOT NULL DEFAULT 0,
+    alamat TEXT,
+    latitude DECIMAL(10, 8),
+    longitude DECIMAL(11, 8),
+    sertifikat VARCHAR(255),
+    description TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL DEFAULT NULL,
+    FOREIGN KEY (member_id) REFERENCES members(id) ON DELETE CASCADE
+);
+-- --- DOWN Migration
+DROP TABLE IF EXISTS member_lands;
@@ -0,0 +1,10 @@
+-- +++ UP Migration
+ALTER TABLE member_lands
+    ADD COLUMN boundary JSON NULL AFTER longitude,
+    ADD COLUMN luas_terukur DECIMAL(12, 4) NULL AFTER boundary,
+    ADD INDEX idx_member_lands_coordinate (latitude, longitude);
+-- --- DOWN Migration
+ALTER TABLE member_lands
+    DROP INDEX idx_member_lands_coordinate,
+    DROP COLUMN luas_terukur,
+    DROP COLUMN boundary;
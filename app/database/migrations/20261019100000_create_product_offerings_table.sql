@@ -0,0 +1,14 @@
+-- +++ UP Migration
+CREATE TABLE product_offerings (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    reference VARCHAR(100) UNIQUE NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    price DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    status ENUM('active', 'inactive') NOT NULL DEFAULT 'active',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL DEFAULT NULL
+);
+-- --- DOWN Migration
+DROP TABLE IF EXISTS product_offerings;
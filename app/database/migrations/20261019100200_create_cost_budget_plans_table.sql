@@ -0,0 +1,21 @@
+-- +++ UP Migration
+CREATE TABLE cost_budget_plans (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    reference VARCHAR(100) UNIQUE NOT NULL,
+    member_id BIGINT NOT NULL,
+    store_id BIGINT NOT NULL,
+    member_land_id BIGINT NOT NULL,
+    jenis_tanam VARCHAR(100) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'draft',
+    total DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    approved_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL DEFAULT NULL,
+    INDEX idx_cost_budget_plans_status (status),
+    FOREIGN KEY (member_id) REFERENCES members(id),
+    FOREIGN KEY (store_id) REFERENCES stores(id),
+    FOREIGN KEY (member_land_id) REFERENCES member_lands(id)
+);
+-- --- DOWN Migration
+DROP TABLE IF EXISTS cost_budget_plans;
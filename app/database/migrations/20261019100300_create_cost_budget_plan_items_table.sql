@@ -0,0 +1,18 @@
+-- +++ UP Migration
+CREATE TABLE cost_budget_plan_items (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    cost_budget_plan_id BIGINT NOT NULL,
+    product_offering_id BIGINT NOT NULL,
+    phase_id BIGINT NOT NULL,
+    quantity DECIMAL(12, 2) NOT NULL,
+    price DECIMAL(15, 2) NOT NULL,
+    subtotal DECIMAL(15, 2) NOT NULL,
+    description TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (cost_budget_plan_id) REFERENCES cost_budget_plans(id) ON DELETE CASCADE,
+    FOREIGN KEY (product_offering_id) REFERENCES product_offerings(id),
+    FOREIGN KEY (phase_id) REFERENCES phases(id)
+);
+-- --- DOWN Migration
+DROP TABLE IF EXISTS cost_budget_plan_items;
@@ -0,0 +1,16 @@
+-- +++ UP Migration
+ALTER TABLE cost_budget_plans
+    ADD COLUMN created_by BIGINT NULL AFTER jenis_tanam,
+    ADD COLUMN approval_level INT NOT NULL DEFAULT 0 AFTER status,
+    ADD COLUMN submitted_at TIMESTAMP NULL DEFAULT NULL AFTER total,
+    ADD COLUMN disbursed_at TIMESTAMP NULL DEFAULT NULL AFTER approved_at,
+    ADD COLUMN closed_at TIMESTAMP NULL DEFAULT NULL AFTER disbursed_at,
+    ADD CONSTRAINT fk_cost_budget_plans_created_by FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL;
+-- --- DOWN Migration
+ALTER TABLE cost_budget_plans
+    DROP FOREIGN KEY fk_cost_budget_plans_created_by,
+    DROP COLUMN closed_at,
+    DROP COLUMN disbursed_at,
+    DROP COLUMN submitted_at,
+    DROP COLUMN approval_level,
+    DROP COLUMN created_by;
@@ -0,0 +1,15 @@
+-- +++ UP Migration
+CREATE TABLE cost_budget_plan_approval_levels (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    store_id BIGINT NULL,
+    level INT NOT NULL,
+    min_amount DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    role_id BIGINT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_cost_budget_plan_approval_levels_store_level (store_id, level),
+    FOREIGN KEY (store_id) REFERENCES stores(id) ON DELETE CASCADE,
+    FOREIGN KEY (role_id) REFERENCES roles(id) ON DELETE CASCADE
+);
+-- --- DOWN Migration
+DROP TABLE IF EXISTS cost_budget_plan_approval_levels;
@@ -0,0 +1,17 @@
+-- +++ UP Migration
+CREATE TABLE cost_budget_plan_transitions (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    cost_budget_plan_id BIGINT NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    level INT NOT NULL DEFAULT 0,
+    actor_id BIGINT NULL,
+    comment TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_cost_budget_plan_transitions_plan (cost_budget_plan_id, created_at),
+    FOREIGN KEY (cost_budget_plan_id) REFERENCES cost_budget_plans(id) ON DELETE CASCADE,
+    FOREIGN KEY (actor_id) REFERENCES users(id) ON DELETE SET NULL
+);
+-- --- DOWN Migration
+DROP TABLE IF EXISTS cost_budget_plan_transitions;
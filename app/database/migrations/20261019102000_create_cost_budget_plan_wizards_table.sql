@@ -0,0 +1,17 @@
+-- +++ UP Migration
+CREATE TABLE cost_budget_plan_wizards (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    reference VARCHAR(100) UNIQUE NOT NULL,
+    user_id BIGINT NOT NULL,
+    store_id BIGINT NOT NULL,
+    step VARCHAR(20) NOT NULL,
+    data JSON,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_cost_budget_plan_wizards_user (user_id, expires_at),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (store_id) REFERENCES stores(id) ON DELETE CASCADE
+);
+-- --- DOWN Migration
+DROP TABLE IF EXISTS cost_budget_plan_wizards;
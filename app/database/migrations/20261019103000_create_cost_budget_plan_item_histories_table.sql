@@ -0,0 +1,20 @@
+-- +++ UP Migration
+CREATE TABLE cost_budget_plan_item_histories (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    cost_budget_plan_item_id BIGINT NOT NULL,
+    product_id BIGINT NOT NULL,
+    quantity DECIMAL(12, 2) NOT NULL,
+    price DECIMAL(15, 2) NOT NULL,
+    amount DECIMAL(15, 2) NOT NULL,
+    issued_at DATE NOT NULL,
+    description TEXT,
+    actor_id BIGINT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_cost_budget_plan_item_histories_item (cost_budget_plan_item_id, issued_at),
+    FOREIGN KEY (cost_budget_plan_item_id) REFERENCES cost_budget_plan_items(id) ON DELETE CASCADE,
+    FOREIGN KEY (product_id) REFERENCES products(id),
+    FOREIGN KEY (actor_id) REFERENCES users(id) ON DELETE SET NULL
+);
+-- --- DOWN Migration
+DROP TABLE IF EXISTS cost_budget_plan_item_histories;
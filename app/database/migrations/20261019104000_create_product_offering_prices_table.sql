@@ -0,0 +1,19 @@
+-- +++ UP Migration
+CREATE TABLE product_offering_prices (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    product_offering_id BIGINT NOT NULL,
+    price DECIMAL(15, 2) NOT NULL,
+    effective_from TIMESTAMP NOT NULL,
+    effective_to TIMESTAMP NULL DEFAULT NULL,
+    created_by BIGINT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_product_offering_prices_effective (product_offering_id, effective_from),
+    FOREIGN KEY (product_offering_id) REFERENCES product_offerings(id) ON DELETE CASCADE,
+    FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+INSERT INTO product_offering_prices (product_offering_id, price, effective_from)
+SELECT id, price, created_at FROM product_offerings;
+-- --- DOWN Migration
+DROP TABLE IF EXISTS product_offering_prices;
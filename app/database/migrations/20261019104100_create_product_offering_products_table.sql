@@ -0,0 +1,14 @@
+-- +++ UP Migration
+CREATE TABLE product_offering_products (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    product_offering_id BIGINT NOT NULL,
+    product_id BIGINT NOT NULL,
+    quantity DECIMAL(12, 2) NOT NULL DEFAULT 1,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_product_offering_products (product_offering_id, product_id),
+    FOREIGN KEY (product_offering_id) REFERENCES product_offerings(id) ON DELETE CASCADE,
+    FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE
+);
+-- --- DOWN Migration
+DROP TABLE IF EXISTS product_offering_products;
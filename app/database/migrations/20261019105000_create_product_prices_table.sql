@@ -0,0 +1,23 @@
+-- +++ UP Migration
+CREATE TABLE product_prices (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    product_id BIGINT NOT NULL,
+    price DECIMAL(10, 2) NOT NULL,
+    margin DECIMAL(10, 2) NOT NULL DEFAULT 0,
+    effective_from TIMESTAMP NOT NULL,
+    effective_to TIMESTAMP NULL DEFAULT NULL,
+    applied_at TIMESTAMP NULL DEFAULT NULL,
+    created_by BIGINT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_product_prices_product (product_id, effective_from),
+    INDEX idx_product_prices_pending (applied_at, effective_from),
+    FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE,
+    FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+INSERT INTO product_prices (product_id, price, margin, effective_from, applied_at)
+SELECT id, COALESCE(price, 0), COALESCE(margin, 0), COALESCE(created_at, CURRENT_TIMESTAMP), CURRENT_TIMESTAMP
+FROM products WHERE deleted_at IS NULL;
+-- --- DOWN Migration
+DROP TABLE IF EXISTS product_prices;
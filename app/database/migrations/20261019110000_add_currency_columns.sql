@@ -0,0 +1,8 @@
+-- +++ UP Migration
+ALTER TABLE products ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'IDR' AFTER margin;
+ALTER TABLE product_offerings ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'IDR' AFTER price;
+ALTER TABLE cost_budget_plans ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'IDR' AFTER total;
+-- --- DOWN Migration
+ALTER TABLE cost_budget_plans DROP COLUMN currency;
+ALTER TABLE product_offerings DROP COLUMN currency;
+ALTER TABLE products DROP COLUMN currency;
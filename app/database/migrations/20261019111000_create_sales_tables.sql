@@ -0,0 +1,75 @@
+-- +++ UP Migration
+CREATE TABLE sales (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    reference VARCHAR(255) NOT NULL UNIQUE,
+    store_id BIGINT NOT NULL,
+    member_id BIGINT NULL,
+    cashier_id BIGINT NULL,
+    status VARCHAR(32) NOT NULL DEFAULT 'completed',
+    payment_method VARCHAR(32) NOT NULL,
+    currency CHAR(3) NOT NULL DEFAULT 'IDR',
+    subtotal DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    discount DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    tax_percent DECIMAL(5, 2) NOT NULL DEFAULT 0,
+    tax DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    total DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    paid DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    change_amount DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    refunded DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    note TEXT NULL,
+    voided_at TIMESTAMP NULL DEFAULT NULL,
+    voided_by BIGINT NULL,
+    void_reason TEXT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_sales_store_created (store_id, created_at),
+    FOREIGN KEY (store_id) REFERENCES stores(id),
+    FOREIGN KEY (member_id) REFERENCES members(id) ON DELETE SET NULL,
+    FOREIGN KEY (cashier_id) REFERENCES users(id) ON DELETE SET NULL,
+    FOREIGN KEY (voided_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE TABLE sale_items (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    sale_id BIGINT NOT NULL,
+    product_id BIGINT NOT NULL,
+    quantity INT NOT NULL,
+    returned_quantity INT NOT NULL DEFAULT 0,
+    price DECIMAL(15, 2) NOT NULL,
+    discount DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    subtotal DECIMAL(15, 2) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (sale_id) REFERENCES sales(id) ON DELETE CASCADE,
+    FOREIGN KEY (product_id) REFERENCES products(id)
+);
+
+CREATE TABLE sale_returns (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    reference VARCHAR(255) NOT NULL UNIQUE,
+    sale_id BIGINT NOT NULL,
+    reason TEXT NOT NULL,
+    amount DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    actor_id BIGINT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (sale_id) REFERENCES sales(id) ON DELETE CASCADE,
+    FOREIGN KEY (actor_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE TABLE sale_return_items (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    sale_return_id BIGINT NOT NULL,
+    sale_item_id BIGINT NOT NULL,
+    quantity INT NOT NULL,
+    amount DECIMAL(15, 2) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (sale_return_id) REFERENCES sale_returns(id) ON DELETE CASCADE,
+    FOREIGN KEY (sale_item_id) REFERENCES sale_items(id)
+);
+-- --- DOWN Migration
+DROP TABLE IF EXISTS sale_return_items;
+DROP TABLE IF EXISTS sale_returns;
+DROP TABLE IF EXISTS sale_items;
+DROP TABLE IF EXISTS sales;
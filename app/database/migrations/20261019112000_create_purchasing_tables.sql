@@ -0,0 +1,86 @@
+-- +++ UP Migration
+CREATE TABLE suppliers (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    reference VARCHAR(255) NOT NULL UNIQUE,
+    store_id BIGINT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    contact_person VARCHAR(255) NULL,
+    phone VARCHAR(32) NULL,
+    email VARCHAR(255) NULL,
+    address TEXT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL DEFAULT NULL,
+    INDEX idx_suppliers_store (store_id),
+    FOREIGN KEY (store_id) REFERENCES stores(id)
+);
+
+CREATE TABLE purchase_orders (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    reference VARCHAR(255) NOT NULL UNIQUE,
+    store_id BIGINT NOT NULL,
+    supplier_id BIGINT NOT NULL,
+    status VARCHAR(32) NOT NULL DEFAULT 'open',
+    currency CHAR(3) NOT NULL DEFAULT 'IDR',
+    total DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    expected_at DATE NULL,
+    note TEXT NULL,
+    created_by BIGINT NULL,
+    closed_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_purchase_orders_store_status (store_id, status),
+    FOREIGN KEY (store_id) REFERENCES stores(id),
+    FOREIGN KEY (supplier_id) REFERENCES suppliers(id),
+    FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE TABLE purchase_order_items (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    purchase_order_id BIGINT NOT NULL,
+    product_id BIGINT NOT NULL,
+    quantity INT NOT NULL,
+    received_quantity INT NOT NULL DEFAULT 0,
+    cost_price DECIMAL(15, 2) NOT NULL,
+    subtotal DECIMAL(15, 2) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (purchase_order_id) REFERENCES purchase_orders(id) ON DELETE CASCADE,
+    FOREIGN KEY (product_id) REFERENCES products(id)
+);
+
+CREATE TABLE goods_receipts (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    reference VARCHAR(255) NOT NULL UNIQUE,
+    purchase_order_id BIGINT NOT NULL,
+    received_at TIMESTAMP NOT NULL,
+    note TEXT NULL,
+    actor_id BIGINT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (purchase_order_id) REFERENCES purchase_orders(id) ON DELETE CASCADE,
+    FOREIGN KEY (actor_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE TABLE goods_receipt_items (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    goods_receipt_id BIGINT NOT NULL,
+    purchase_order_item_id BIGINT NOT NULL,
+    product_id BIGINT NOT NULL,
+    quantity INT NOT NULL,
+    cost_price DECIMAL(15, 2) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (goods_receipt_id) REFERENCES goods_receipts(id) ON DELETE CASCADE,
+    FOREIGN KEY (purchase_order_item_id) REFERENCES purchase_order_items(id),
+    FOREIGN KEY (product_id) REFERENCES products(id)
+);
+
+ALTER TABLE products ADD COLUMN cost_price DECIMAL(15, 2) NOT NULL DEFAULT 0 AFTER margin;
+-- --- DOWN Migration
+ALTER TABLE products DROP COLUMN cost_price;
+DROP TABLE IF EXISTS goods_receipt_items;
+DROP TABLE IF EXISTS goods_receipts;
+DROP TABLE IF EXISTS purchase_order_items;
+DROP TABLE IF EXISTS purchase_orders;
+DROP TABLE IF EXISTS suppliers;
@@ -0,0 +1,43 @@
+-- +++ UP Migration
+CREATE TABLE stock_transfers (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    reference VARCHAR(255) NOT NULL UNIQUE,
+    from_store_id BIGINT NOT NULL,
+    to_store_id BIGINT NOT NULL,
+    status VARCHAR(32) NOT NULL DEFAULT 'draft',
+    note TEXT NULL,
+    has_discrepancy BOOLEAN NOT NULL DEFAULT FALSE,
+    created_by BIGINT NULL,
+    dispatched_by BIGINT NULL,
+    dispatched_at TIMESTAMP NULL DEFAULT NULL,
+    received_by BIGINT NULL,
+    received_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_stock_transfers_from_status (from_store_id, status),
+    INDEX idx_stock_transfers_to_status (to_store_id, status),
+    FOREIGN KEY (from_store_id) REFERENCES stores(id),
+    FOREIGN KEY (to_store_id) REFERENCES stores(id),
+    FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL,
+    FOREIGN KEY (dispatched_by) REFERENCES users(id) ON DELETE SET NULL,
+    FOREIGN KEY (received_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE TABLE stock_transfer_items (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    stock_transfer_id BIGINT NOT NULL,
+    product_id BIGINT NOT NULL,
+    to_product_id BIGINT NOT NULL,
+    quantity INT NOT NULL,
+    received_quantity INT NULL,
+    cost_price DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    discrepancy_note TEXT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (stock_transfer_id) REFERENCES stock_transfers(id) ON DELETE CASCADE,
+    FOREIGN KEY (product_id) REFERENCES products(id),
+    FOREIGN KEY (to_product_id) REFERENCES products(id)
+);
+-- --- DOWN Migration
+DROP TABLE IF EXISTS stock_transfer_items;
+DROP TABLE IF EXISTS stock_transfers;
@@ -0,0 +1,42 @@
+-- +++ UP Migration
+CREATE TABLE stock_takes (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    reference VARCHAR(255) NOT NULL UNIQUE,
+    store_id BIGINT NOT NULL,
+    status VARCHAR(32) NOT NULL DEFAULT 'counting',
+    note TEXT NULL,
+    created_by BIGINT NULL,
+    submitted_by BIGINT NULL,
+    submitted_at TIMESTAMP NULL DEFAULT NULL,
+    approved_by BIGINT NULL,
+    approved_at TIMESTAMP NULL DEFAULT NULL,
+    review_comment TEXT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_stock_takes_store_status (store_id, status),
+    FOREIGN KEY (store_id) REFERENCES stores(id),
+    FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL,
+    FOREIGN KEY (submitted_by) REFERENCES users(id) ON DELETE SET NULL,
+    FOREIGN KEY (approved_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE TABLE stock_take_items (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    stock_take_id BIGINT NOT NULL,
+    product_id BIGINT NOT NULL,
+    system_quantity INT NOT NULL,
+    counted_quantity INT NULL,
+    variance INT NOT NULL DEFAULT 0,
+    cost_price DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    counted_by BIGINT NULL,
+    counted_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_stock_take_items_product (stock_take_id, product_id),
+    FOREIGN KEY (stock_take_id) REFERENCES stock_takes(id) ON DELETE CASCADE,
+    FOREIGN KEY (product_id) REFERENCES products(id),
+    FOREIGN KEY (counted_by) REFERENCES users(id) ON DELETE SET NULL
+);
+-- --- DOWN Migration
+DROP TABLE IF EXISTS stock_take_items;
+DROP TABLE IF EXISTS stock_takes;
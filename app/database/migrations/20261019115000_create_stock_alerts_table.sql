@@ -0,0 +1,26 @@
+-- +++ UP Migration
+ALTER TABLE stores ADD COLUMN default_min_stock INT NULL AFTER zip, ADD COLUMN default_max_stock INT NULL AFTER default_min_stock;
+ALTER TABLE products ADD COLUMN min_stock INT NULL AFTER sold, ADD COLUMN max_stock INT NULL AFTER min_stock;
+
+CREATE TABLE stock_alerts (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    store_id BIGINT NOT NULL,
+    product_id BIGINT NOT NULL,
+    status VARCHAR(32) NOT NULL DEFAULT 'open',
+    stock INT NOT NULL,
+    min_stock INT NOT NULL,
+    max_stock INT NULL,
+    daily_sales DECIMAL(15, 4) NOT NULL DEFAULT 0,
+    suggested_quantity INT NOT NULL DEFAULT 0,
+    resolved_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_stock_alerts_store_status (store_id, status),
+    INDEX idx_stock_alerts_product_status (product_id, status),
+    FOREIGN KEY (store_id) REFERENCES stores(id),
+    FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE
+);
+-- --- DOWN Migration
+DROP TABLE IF EXISTS stock_alerts;
+ALTER TABLE products DROP COLUMN max_stock, DROP COLUMN min_stock;
+ALTER TABLE stores DROP COLUMN default_max_stock, DROP COLUMN default_min_stock;
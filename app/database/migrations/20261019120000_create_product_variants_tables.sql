@@ -0,0 +1,39 @@
+-- +++ UP Migration
+CREATE TABLE category_attributes (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    category_id BIGINT NOT NULL,
+    code VARCHAR(64) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    type VARCHAR(16) NOT NULL DEFAULT 'text',
+    options JSON NULL,
+    unit VARCHAR(32) NULL,
+    required BOOLEAN NOT NULL DEFAULT FALSE,
+    sort_order INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_category_attributes_code (category_id, code),
+    FOREIGN KEY (category_id) REFERENCES categories(id) ON DELETE CASCADE
+);
+
+ALTER TABLE products
+    ADD COLUMN parent_id BIGINT NULL AFTER category_id,
+    ADD COLUMN sku VARCHAR(64) NULL AFTER reference,
+    ADD UNIQUE KEY uq_products_store_sku (store_id, sku),
+    ADD CONSTRAINT fk_products_parent FOREIGN KEY (parent_id) REFERENCES products(id);
+
+CREATE TABLE product_attribute_values (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    product_id BIGINT NOT NULL,
+    category_attribute_id BIGINT NOT NULL,
+    value VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_product_attribute_values (product_id, category_attribute_id),
+    INDEX idx_product_attribute_values_value (category_attribute_id, value),
+    FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE,
+    FOREIGN KEY (category_attribute_id) REFERENCES category_attributes(id) ON DELETE CASCADE
+);
+-- --- DOWN Migration
+DROP TABLE IF EXISTS product_attribute_values;
+ALTER TABLE products DROP FOREIGN KEY fk_products_parent, DROP INDEX uq_products_store_sku, DROP COLUMN sku, DROP COLUMN parent_id;
+DROP TABLE IF EXISTS category_attributes;
@@ -0,0 +1,6 @@
+-- +++ UP Migration
+ALTER TABLE products
+    ADD COLUMN barcode VARCHAR(32) NULL AFTER sku,
+    ADD UNIQUE KEY uq_products_barcode (barcode);
+-- --- DOWN Migration
+ALTER TABLE products DROP INDEX uq_products_barcode, DROP COLUMN barcode;
@@ -0,0 +1,29 @@
+-- +++ UP Migration
+ALTER TABLE categories
+    ADD COLUMN parent_id BIGINT NULL AFTER id,
+    ADD COLUMN slug VARCHAR(255) NULL AFTER category,
+    ADD COLUMN path VARCHAR(1024) NOT NULL DEFAULT '' AFTER slug,
+    ADD COLUMN depth INT NOT NULL DEFAULT 0 AFTER path,
+    ADD COLUMN sort_order INT NOT NULL DEFAULT 0 AFTER depth,
+    ADD CONSTRAINT fk_categories_parent FOREIGN KEY (parent_id) REFERENCES categories(id);
+
+-- kategori lama menjadi root, slug diberi akhiran ID agar tetap unik
+UPDATE categories
+SET slug = CONCAT(LOWER(REPLACE(TRIM(category), ' ', '-')), '-', id),
+    path = CONCAT('/', id, '/'),
+    sort_order = id;
+
+ALTER TABLE categories
+    MODIFY slug VARCHAR(255) NOT NULL,
+    ADD UNIQUE KEY uq_categories_slug (slug),
+    ADD INDEX idx_categories_path (path(255));
+-- --- DOWN Migration
+ALTER TABLE categories
+    DROP FOREIGN KEY fk_categories_parent,
+    DROP INDEX uq_categories_slug,
+    DROP INDEX idx_categories_path,
+    DROP COLUMN sort_order,
+    DROP COLUMN depth,
+    DROP COLUMN path,
+    DROP COLUMN slug,
+    DROP COLUMN parent_id;
@@ -0,0 +1,12 @@
+-- +++ UP Migration
+ALTER TABLE products ADD COLUMN version INT UNSIGNED NOT NULL DEFAULT 1 AFTER deleted_at;
+ALTER TABLE categories ADD COLUMN version INT UNSIGNED NOT NULL DEFAULT 1 AFTER deleted_at;
+ALTER TABLE stores ADD COLUMN version INT UNSIGNED NOT NULL DEFAULT 1 AFTER deleted_at;
+ALTER TABLE suppliers ADD COLUMN version INT UNSIGNED NOT NULL DEFAULT 1 AFTER deleted_at;
+ALTER TABLE members ADD COLUMN version INT UNSIGNED NOT NULL DEFAULT 1 AFTER deleted_at;
+-- --- DOWN Migration
+ALTER TABLE members DROP COLUMN version;
+ALTER TABLE suppliers DROP COLUMN version;
+ALTER TABLE stores DROP COLUMN version;
+ALTER TABLE categories DROP COLUMN version;
+ALTER TABLE products DROP COLUMN version;
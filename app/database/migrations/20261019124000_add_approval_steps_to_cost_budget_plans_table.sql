@@ -0,0 +1,6 @@
+-- +++ UP Migration
+ALTER TABLE cost_budget_plans
+    ADD COLUMN approval_steps JSON NULL AFTER approval_level;
+-- --- DOWN Migration
+ALTER TABLE cost_budget_plans
+    DROP COLUMN approval_steps;
@@ -0,0 +1,18 @@
+-- +++ UP Migration
+ALTER TABLE users ADD COLUMN version INT UNSIGNED NOT NULL DEFAULT 1 AFTER pin;
+ALTER TABLE roles ADD COLUMN version INT UNSIGNED NOT NULL DEFAULT 1;
+ALTER TABLE permissions ADD COLUMN version INT UNSIGNED NOT NULL DEFAULT 1;
+ALTER TABLE product_offerings ADD COLUMN version INT UNSIGNED NOT NULL DEFAULT 1 AFTER deleted_at;
+ALTER TABLE cost_budget_plans ADD COLUMN version INT UNSIGNED NOT NULL DEFAULT 1 AFTER deleted_at;
+ALTER TABLE purchase_orders ADD COLUMN version INT UNSIGNED NOT NULL DEFAULT 1 AFTER updated_at;
+ALTER TABLE stock_transfers ADD COLUMN version INT UNSIGNED NOT NULL DEFAULT 1 AFTER updated_at;
+ALTER TABLE member_lands ADD COLUMN version INT UNSIGNED NOT NULL DEFAULT 1 AFTER deleted_at;
+-- --- DOWN Migration
+ALTER TABLE member_lands DROP COLUMN version;
+ALTER TABLE stock_transfers DROP COLUMN version;
+ALTER TABLE purchase_orders DROP COLUMN version;
+ALTER TABLE cost_budget_plans DROP COLUMN version;
+ALTER TABLE product_offerings DROP COLUMN version;
+ALTER TABLE permissions DROP COLUMN version;
+ALTER TABLE roles DROP COLUMN version;
+ALTER TABLE users DROP COLUMN version;
@@ -0,0 +1,128 @@
+package plugins
+
+import (
+	"errors"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/callbacks"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// ErrVersionConflict dikembalikan saat data sudah diubah oleh request lain
+// sejak versi yang dikirim client dibaca.
+var ErrVersionConflict = errors.New("data sudah diubah oleh pengguna lain, muat ulang data terlebih dahulu")
+
+const (
+	versionColumn  = "version"
+	versionSet     = "optimistic_lock:set"
+	versionChecked = "optimistic_lock:checked"
+)
+
+// OptimisticLock menambahkan optimistic locking pada model yang memiliki
+// kolom version. Setiap Update/Updates menaikkan version, dan jika data yang
+// diupdate membawa version bukan nol, update hanya berlaku untuk baris dengan
+// version tersebut. UpdateColumn/UpdateColumns (SkipHooks) tidak terpengaruh
+// sehingga perubahan stok dari ledger tidak membuat version berubah.
+type OptimisticLock struct{}
+
+func (OptimisticLock) Name() string {
+	return "optimistic_lock"
+}
+
+func (plugin OptimisticLock) Initialize(db *gorm.DB) error {
+	if err := db.Callback().Update().Before("gorm:update").Register("optimistic_lock:before_update", plugin.beforeUpdate); err != nil {
+		return err
+	}
+	return db.Callback().Update().After("gorm:update").Register("optimistic_lock:after_update", plugin.afterUpdate)
+}
+
+func (OptimisticLock) beforeUpdate(db *gorm.DB) {
+	stmt := db.Statement
+	if db.Error != nil || stmt.Schema == nil || stmt.SkipHooks {
+		return
+	}
+	field := stmt.Schema.LookUpField(versionColumn)
+	if field == nil {
+		return
+	}
+	if _, ok := stmt.Clauses["SET"]; ok {
+		return
+	}
+
+	expected := expectedVersion(stmt, field)
+	set := callbacks.ConvertToAssignments(stmt)
+	if len(set) == 0 {
+		return
+	}
+
+	assignments := make(clause.Set, 0, len(set)+1)
+	for _, assignment := range set {
+		if assignment.Column.Name != field.DBName {
+			assignments = append(assignments, assignment)
+		}
+	}
+	assignments = append(assignments, clause.Assignment{
+		Column: clause.Column{Name: field.DBName},
+		Value:  gorm.Expr(stmt.Quote(field.DBName) + " + 1"),
+	})
+	stmt.AddClause(assignments)
+	stmt.Settings.Store(versionSet, true)
+
+	if expected != 0 {
+		stmt.AddClause(clause.Where{Exprs: []clause.Expression{
+			clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: field.DBName}, Value: expected},
+		}})
+		stmt.Settings.Store(versionChecked, true)
+	}
+}
+
+func (OptimisticLock) afterUpdate(db *gorm.DB) {
+	stmt := db.Statement
+	if _, ok := stmt.Settings.LoadAndDelete(versionSet); ok {
+		// SET dibuat oleh plugin, dihapus seperti yang dilakukan callback gorm:update
+		delete(stmt.Clauses, "SET")
+	}
+	if _, ok := stmt.Settings.LoadAndDelete(versionChecked); !ok {
+		return
+	}
+	if db.Error == nil && !db.DryRun && db.RowsAffected == 0 {
+		db.AddError(ErrVersionConflict)
+	}
+}
+
+// expectedVersion membaca version dari data update, baik struct model maupun map.
+func expectedVersion(stmt *gorm.Statement, field *schema.Field) uint64 {
+	var value interface{}
+	switch dest := stmt.Dest.(type) {
+	case map[string]interface{}:
+		if v, ok := dest[field.DBName]; ok {
+			value = v
+		} else if v, ok := dest[field.Name]; ok {
+			value = v
+		}
+	default:
+		destValue := reflect.Indirect(reflect.ValueOf(stmt.Dest))
+		if destValue.Kind() != reflect.Struct || destValue.Type() != stmt.Schema.ModelType {
+			return 0
+		}
+		value, _ = field.ValueOf(stmt.Context, destValue)
+	}
+
+	switch v := value.(type) {
+	case uint:
+		return uint64(v)
+	case uint64:
+		return v
+	case int:
+		if v > 0 {
+			return uint64(v)
+		}
+	case int64:
+		if v > 0 {
+			return uint64(v)
+		}
+	}
+	return 0
+}
@@ -0,0 +1,80 @@
+package plugins_test
+
+import (
+	"golang_starter_kit_2025/app/database/plugins"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type versionedItem struct {
+	ID      uint
+	Name    string
+	Version uint
+}
+
+type plainItem struct {
+	ID   uint
+	Name string
+}
+
+var _ = Describe("OptimisticLock", func() {
+	var (
+		row *fakeRow
+		db  *gorm.DB
+	)
+
+	BeforeEach(func() {
+		row = &fakeRow{Name: "Pupuk", Version: 3}
+		var err error
+		db, err = gorm.Open(mysql.New(mysql.Config{Conn: openFakeDB(row), SkipInitializeWithVersion: true}), &gorm.Config{
+			SkipDefaultTransaction: true,
+			Logger:                 logger.Discard,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(db.Use(plugins.OptimisticLock{})).To(Succeed())
+	})
+
+	It("should update and increment version when version matches", func() {
+		err := db.Model(&versionedItem{ID: 1}).Updates(&versionedItem{Name: "Benih", Version: 3}).Error
+		Expect(err).NotTo(HaveOccurred())
+		Expect(row.Queries).To(ConsistOf("UPDATE `versioned_items` SET `name`=?,`version`=`version` + 1 WHERE `id` = ? AND `versioned_items`.`version` = ?"))
+		Expect(row.Name).To(Equal("Benih"))
+		Expect(row.Version).To(Equal(int64(4)))
+	})
+
+	It("should return ErrVersionConflict and leave row untouched when version is stale", func() {
+		err := db.Model(&versionedItem{ID: 1}).Updates(map[string]interface{}{"name": "Benih", "version": 2}).Error
+		Expect(err).To(MatchError(plugins.ErrVersionConflict))
+		Expect(row.Queries).To(HaveLen(1))
+		Expect(row.Name).To(Equal("Pupuk"))
+		Expect(row.Version).To(Equal(int64(3)))
+	})
+
+	It("should not check version when version is zero", func() {
+		err := db.Model(&versionedItem{ID: 1}).Updates(&versionedItem{Name: "Benih"}).Error
+		Expect(err).NotTo(HaveOccurred())
+		Expect(row.Queries).To(ConsistOf("UPDATE `versioned_items` SET `name`=?,`version`=`version` + 1 WHERE `id` = ?"))
+		Expect(row.Version).To(Equal(int64(4)))
+	})
+
+	It("should leave statement unchanged for models without version", func() {
+		err := db.Model(&plainItem{ID: 1}).Updates(&plainItem{Name: "Benih"}).Error
+		Expect(err).NotTo(HaveOccurred())
+		Expect(row.Queries).To(ConsistOf("UPDATE `plain_items` SET `name`=? WHERE `id` = ?"))
+	})
+
+	It("should not bump version on UpdateColumn and UpdateColumns", func() {
+		Expect(db.Model(&versionedItem{ID: 1, Version: 3}).UpdateColumn("name", "Benih").Error).To(Succeed())
+		Expect(db.Model(&versionedItem{ID: 1, Version: 3}).UpdateColumns(map[string]interface{}{"name": "Bibit"}).Error).To(Succeed())
+		Expect(row.Queries).To(ConsistOf(
+			"UPDATE `versioned_items` SET `name`=? WHERE `id` = ?",
+			"UPDATE `versioned_items` SET `name`=? WHERE `id` = ?",
+		))
+		Expect(row.Name).To(Equal("Bibit"))
+		Expect(row.Version).To(Equal(int64(3)))
+	})
+})
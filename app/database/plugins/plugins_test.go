@@ -0,0 +1,85 @@
+package plugins_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestPluginsSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Plugins Test Suite")
+}
+
+// fakeRow adalah satu baris tabel yang diemulasikan oleh fakeConnector. UPDATE
+// hanya berlaku jika kondisi `version` = ? cocok dengan version baris.
+type fakeRow struct {
+	Name    string
+	Version int64
+	Queries []string
+	Args    [][]driver.Value
+}
+
+// fakeConnector adalah driver database/sql minimal untuk menguji SQL UPDATE
+// yang dihasilkan gorm tanpa server MySQL.
+type fakeConnector struct {
+	row *fakeRow
+}
+
+func (c fakeConnector) Connect(context.Context) (driver.Conn, error) {
+	return fakeConn(c), nil
+}
+
+func (c fakeConnector) Driver() driver.Driver {
+	return nil
+}
+
+type fakeConn struct {
+	row *fakeRow
+}
+
+func (fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepare tidak didukung")
+}
+
+func (fakeConn) Close() error {
+	return nil
+}
+
+func (fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transaksi tidak didukung")
+}
+
+func (c fakeConn) ExecContext(_ context.Context, query string, named []driver.NamedValue) (driver.Result, error) {
+	args := make([]driver.Value, len(named))
+	for i, value := range named {
+		args[i] = value.Value
+	}
+	c.row.Queries = append(c.row.Queries, query)
+	c.row.Args = append(c.row.Args, args)
+
+	set, where, _ := strings.Cut(query, " WHERE ")
+	if index := strings.Index(where, "`version` = ?"); index >= 0 {
+		position := strings.Count(set, "?") + strings.Count(where[:index], "?")
+		if args[position] != c.row.Version {
+			return driver.RowsAffected(0), nil
+		}
+	}
+	if index := strings.Index(set, "`name`=?"); index >= 0 {
+		c.row.Name = args[strings.Count(set[:index], "?")].(string)
+	}
+	if strings.Contains(set, "`version`=`version` + 1") {
+		c.row.Version++
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func openFakeDB(row *fakeRow) *sql.DB {
+	return sql.OpenDB(fakeConnector{row: row})
+}
@@ -24,6 +24,18 @@ var SeederList = []Seeder{
 		Run:      seeds.SeedUserSeeder,
 		Rollback: seeds.RollbackUserSeeder,
 	},
+	{Name: "PhaseSeeder",
+		Run:      seeds.SeedPhaseSeeder,
+		Rollback: seeds.RollbackPhaseSeeder,
+	},
+	{Name: "CostBudgetPlanPermissionSeeder",
+		Run:      seeds.SeedCostBudgetPlanPermissionSeeder,
+		Rollback: seeds.RollbackCostBudgetPlanPermissionSeeder,
+	},
+	{Name: "StockTakePermissionSeeder",
+		Run:      seeds.SeedStockTakePermissionSeeder,
+		Rollback: seeds.RollbackStockTakePermissionSeeder,
+	},
 }
 
 func ensureSeedsTable() error {
@@ -0,0 +1,33 @@
+package seeds
+
+import (
+	"golang_starter_kit_2025/app/models"
+	"log"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var phaseNames = []string{
+	"Persiapan Lahan",
+	"Pembibitan",
+	"Penanaman",
+	"Pemupukan",
+	"Pengendalian Hama dan Penyakit",
+	"Panen dan Pasca Panen",
+}
+
+func SeedPhaseSeeder(db *gorm.DB) error {
+	log.Println("🌱 Seeding PhaseSeeder...")
+
+	phases := make([]models.Phase, 0, len(phaseNames))
+	for i, name := range phaseNames {
+		phases = append(phases, models.Phase{Name: name, SortOrder: i + 1})
+	}
+	return db.Clauses(clause.OnConflict{DoNothing: true}).Create(&phases).Error
+}
+
+func RollbackPhaseSeeder(db *gorm.DB) error {
+	log.Println("🗑️ Rolling back PhaseSeeder…")
+	return db.Where("name IN ?", phaseNames).Delete(&models.Phase{}).Error
+}
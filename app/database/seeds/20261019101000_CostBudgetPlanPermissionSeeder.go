@@ -0,0 +1,32 @@
+package seeds
+
+import (
+	"golang_starter_kit_2025/app/models"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+var costBudgetPlanPermissions = []string{
+	models.PermissionCostBudgetPlanSubmit,
+	models.PermissionCostBudgetPlanApprove,
+	models.PermissionCostBudgetPlanDisburse,
+	models.PermissionCostBudgetPlanClose,
+}
+
+func SeedCostBudgetPlanPermissionSeeder(db *gorm.DB) error {
+	log.Println("🌱 Seeding CostBudgetPlanPermissionSeeder...")
+
+	for _, name := range costBudgetPlanPermissions {
+		permission := models.Permission{Name: name, Group: "cost_budget_plan"}
+		if err := db.Where(models.Permission{Name: name}).FirstOrCreate(&permission).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func RollbackCostBudgetPlanPermissionSeeder(db *gorm.DB) error {
+	log.Println("🗑️ Rolling back CostBudgetPlanPermissionSeeder…")
+	return db.Where("name IN ?", costBudgetPlanPermissions).Delete(&models.Permission{}).Error
+}
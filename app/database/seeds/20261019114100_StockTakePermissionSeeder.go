@@ -0,0 +1,29 @@
+package seeds
+
+import (
+	"golang_starter_kit_2025/app/models"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+var stockTakePermissions = []string{
+	models.PermissionStockTakeApprove,
+}
+
+func SeedStockTakePermissionSeeder(db *gorm.DB) error {
+	log.Println("🌱 Seeding StockTakePermissionSeeder...")
+
+	for _, name := range stockTakePermissions {
+		permission := models.Permission{Name: name, Group: "stock_take"}
+		if err := db.Where(models.Permission{Name: name}).FirstOrCreate(&permission).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func RollbackStockTakePermissionSeeder(db *gorm.DB) error {
+	log.Println("🗑️ Rolling back StockTakePermissionSeeder…")
+	return db.Where("name IN ?", stockTakePermissions).Delete(&models.Permission{}).Error
+}
@@ -0,0 +1,78 @@
+package helpers
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	BarcodeEAN13   = "ean13"
+	BarcodeCode128 = "code128"
+)
+
+// EAN13CheckDigit menghitung check digit EAN-13 dari 12 digit pertama.
+func EAN13CheckDigit(digits string) (int, error) {
+	if len(digits) != 12 {
+		return 0, errors.New("EAN-13 membutuhkan 12 digit sebelum check digit")
+	}
+	sum := 0
+	for i, r := range digits {
+		if r < '0' || r > '9' {
+			return 0, errors.New("EAN-13 hanya boleh berisi angka")
+		}
+		digit := int(r - '0')
+		// bobot 1 untuk posisi ganjil dan 3 untuk posisi genap dari kiri
+		if i%2 == 1 {
+			digit *= 3
+		}
+		sum += digit
+	}
+	return (10 - sum%10) % 10, nil
+}
+
+// IsValidEAN13 memeriksa panjang dan check digit kode EAN-13.
+func IsValidEAN13(code string) bool {
+	if len(code) != 13 {
+		return false
+	}
+	check, err := EAN13CheckDigit(code[:12])
+	if err != nil {
+		return false
+	}
+	return strconv.Itoa(check) == code[12:]
+}
+
+// GenerateBarcode membuat kode barcode pendek dari ID produk. EAN-13 disusun
+// dari prefix, ID dengan nol di depan dan check digit, Code128 dari prefix dan ID.
+func GenerateBarcode(format string, prefix string, id uint) (string, error) {
+	switch format {
+	case BarcodeEAN13:
+		width := 12 - len(prefix)
+		number := strconv.FormatUint(uint64(id), 10)
+		if width < 1 || len(number) > width {
+			return "", fmt.Errorf("ID %d tidak muat pada EAN-13 dengan prefix %s", id, prefix)
+		}
+		digits := prefix + strings.Repeat("0", width-len(number)) + number
+		check, err := EAN13CheckDigit(digits)
+		if err != nil {
+			return "", err
+		}
+		return digits + strconv.Itoa(check), nil
+	case BarcodeCode128:
+		return fmt.Sprintf("%s%06d", prefix, id), nil
+	}
+	return "", fmt.Errorf("format barcode %s tidak didukung", format)
+}
+
+// GenerateProductBarcode membuat barcode produk sesuai BARCODE_FORMAT dan BARCODE_PREFIX.
+func GenerateProductBarcode(id uint) (string, error) {
+	format := GetEnv("BARCODE_FORMAT", BarcodeEAN13)
+	prefix := "PRD"
+	if format == BarcodeEAN13 {
+		// 20-29 adalah rentang GS1 untuk penggunaan internal toko
+		prefix = "200"
+	}
+	return GenerateBarcode(format, GetEnv("BARCODE_PREFIX", prefix), id)
+}
@@ -0,0 +1,54 @@
+package helpers_test
+
+import (
+	"golang_starter_kit_2025/app/helpers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Barcode", func() {
+	Context("when EAN-13 check digit is calculated", func() {
+		It("should return the GS1 check digit", func() {
+			check, err := helpers.EAN13CheckDigit("400638133393")
+			Expect(err).To(BeNil())
+			Expect(check).To(Equal(1))
+		})
+
+		It("should reject non numeric digits", func() {
+			_, err := helpers.EAN13CheckDigit("40063813339A")
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	Context("when EAN-13 is validated", func() {
+		It("should accept a valid code", func() {
+			Expect(helpers.IsValidEAN13("4006381333931")).To(BeTrue())
+		})
+
+		It("should reject a wrong check digit", func() {
+			Expect(helpers.IsValidEAN13("4006381333932")).To(BeFalse())
+		})
+	})
+
+	Context("when barcode is generated", func() {
+		It("should pad the ID for EAN-13", func() {
+			code, err := helpers.GenerateBarcode(helpers.BarcodeEAN13, "200", 42)
+			Expect(err).To(BeNil())
+			Expect(code).To(HaveLen(13))
+			Expect(code).To(HavePrefix("200000000042"))
+			Expect(helpers.IsValidEAN13(code)).To(BeTrue())
+		})
+
+		It("should fail when the ID does not fit", func() {
+			_, err := helpers.GenerateBarcode(helpers.BarcodeEAN13, "2000000000", 1234)
+			Expect(err).NotTo(BeNil())
+		})
+
+		It("should prefix the ID for Code128", func() {
+			code, err := helpers.GenerateBarcode(helpers.BarcodeCode128, "PRD", 42)
+			Expect(err).To(BeNil())
+			Expect(code).To(Equal("PRD000042"))
+		})
+	})
+})
@@ -0,0 +1,27 @@
+package helpers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IfMatchVersion membaca version dari header If-Match, contoh `"3"` atau
+// `W/"3"`. Mengembalikan 0 jika header kosong atau tidak valid.
+func IfMatchVersion(ctx *gin.Context) uint {
+	value := strings.TrimSpace(ctx.GetHeader("If-Match"))
+	value = strings.TrimPrefix(value, "W/")
+	value = strings.Trim(value, `"`)
+	version, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return uint(version)
+}
+
+// SetETag menulis version data sebagai header ETag untuk dipakai kembali pada If-Match.
+func SetETag(ctx *gin.Context, version uint) {
+	ctx.Header("ETag", fmt.Sprintf(`W/"%d"`, version))
+}
@@ -0,0 +1,42 @@
+package helpers_test
+
+import (
+	"net/http/httptest"
+
+	"golang_starter_kit_2025/app/helpers"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ETag", func() {
+	ifMatch := func(header string) uint {
+		ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+		ctx.Request = httptest.NewRequest("PUT", "/", nil)
+		ctx.Request.Header.Set("If-Match", header)
+		return helpers.IfMatchVersion(ctx)
+	}
+
+	Context("when If-Match header is set", func() {
+		It("should parse strong and weak ETag", func() {
+			Expect(ifMatch(`"3"`)).To(Equal(uint(3)))
+			Expect(ifMatch(`W/"7"`)).To(Equal(uint(7)))
+		})
+
+		It("should return zero for invalid header", func() {
+			Expect(ifMatch("*")).To(Equal(uint(0)))
+			Expect(ifMatch("")).To(Equal(uint(0)))
+		})
+	})
+
+	Context("when ETag is written", func() {
+		It("should round trip with If-Match", func() {
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+			helpers.SetETag(ctx, 5)
+			Expect(recorder.Header().Get("ETag")).To(Equal(`W/"5"`))
+			Expect(ifMatch(recorder.Header().Get("ETag"))).To(Equal(uint(5)))
+		})
+	})
+})
@@ -2,6 +2,8 @@ package helpers
 
 import (
 	"fmt"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -21,3 +23,17 @@ func GetFileURL(key string, path string) string {
 	mainUrl := GetEnv("APP_URL", "http://localhost:8080")
 	return fmt.Sprintf("%s/file/%s/%s?signature=%s", mainUrl, path, key, token)
 }
+
+// FileKeyFromURL mengembalikan key file dari URL yang dibuat GetFileURL,
+// dipakai untuk mengenali file lama yang dikirim kembali pada PUT atau PATCH.
+func FileKeyFromURL(value string, path string) (string, bool) {
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return "", false
+	}
+	key, found := strings.CutPrefix(parsed.Path, "/file/"+path+"/")
+	if !found || key == "" || strings.Contains(key, "/") {
+		return "", false
+	}
+	return key, true
+}
@@ -0,0 +1,27 @@
+package helpers_test
+
+import (
+	"golang_starter_kit_2025/app/helpers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FileKeyFromURL", func() {
+	Context("when URL is created by GetFileURL", func() {
+		It("should return the file key", func() {
+			key, ok := helpers.FileKeyFromURL(helpers.GetFileURL("images-abc.png", "products"), "products")
+			Expect(ok).To(BeTrue())
+			Expect(key).To(Equal("images-abc.png"))
+		})
+	})
+
+	Context("when value is not a file URL of the path", func() {
+		It("should return false", func() {
+			_, ok := helpers.FileKeyFromURL(helpers.GetFileURL("foto.png", "members"), "products")
+			Expect(ok).To(BeFalse())
+			_, ok = helpers.FileKeyFromURL("iVBORw0KGgoAAAANSUhEUgAAAAE=", "products")
+			Expect(ok).To(BeFalse())
+		})
+	})
+})
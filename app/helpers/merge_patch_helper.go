@@ -0,0 +1,110 @@
+package helpers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+const ContentTypeMergePatch = "application/merge-patch+json"
+
+var (
+	ErrMergePatchContentType = errors.New("content type harus application/merge-patch+json atau application/json")
+	ErrInvalidMergePatch     = errors.New("body bukan JSON merge patch yang valid")
+)
+
+// MergePatch menerapkan JSON Merge Patch (RFC 7396) pada document. Member
+// bernilai null dihapus, object digabung secara rekursif dan nilai lain
+// termasuk array menggantikan nilai lama.
+func MergePatch(document []byte, patch []byte) ([]byte, error) {
+	var target interface{}
+	if len(document) > 0 {
+		if err := decodeJSONNumber(document, &target); err != nil {
+			return nil, err
+		}
+	}
+	var changes interface{}
+	if err := decodeJSONNumber(patch, &changes); err != nil {
+		return nil, err
+	}
+	return json.Marshal(mergePatch(target, changes))
+}
+
+// decodeJSONNumber seperti json.Unmarshal namun angka dibaca sebagai
+// json.Number agar ID dan nominal besar tidak kehilangan presisi float64.
+func decodeJSONNumber(data []byte, value interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(value); err != nil {
+		return err
+	}
+	// data setelah nilai JSON pertama ditolak seperti json.Unmarshal
+	if _, err := decoder.Token(); err != io.EOF {
+		return ErrInvalidMergePatch
+	}
+	return nil
+}
+
+func mergePatch(target interface{}, patch interface{}) interface{} {
+	changes, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	result, ok := target.(map[string]interface{})
+	if !ok {
+		result = map[string]interface{}{}
+	}
+	for key, value := range changes {
+		if value == nil {
+			delete(result, key)
+			continue
+		}
+		result[key] = mergePatch(result[key], value)
+	}
+	return result
+}
+
+// BindMergePatch menerapkan body request sebagai merge patch pada current,
+// lalu memvalidasi hasilnya dengan tag binding seperti ShouldBindJSON.
+// Member yang dihapus dengan null menjadi zero value atau nil pada current,
+// karena itu field untuk kolom nullable memakai pointer agar null tersimpan
+// sebagai NULL dan dapat dibedakan dari nilai kosong.
+func BindMergePatch(ctx *gin.Context, current interface{}) error {
+	if contentType := ctx.GetHeader("Content-Type"); contentType != "" {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil || (mediaType != ContentTypeMergePatch && mediaType != binding.MIMEJSON) {
+			return ErrMergePatchContentType
+		}
+	}
+
+	patch, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		return err
+	}
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(patch, &object); err != nil {
+		return ErrInvalidMergePatch
+	}
+
+	document, err := json.Marshal(current)
+	if err != nil {
+		return err
+	}
+	merged, err := MergePatch(document, patch)
+	if err != nil {
+		return err
+	}
+
+	value := reflect.ValueOf(current).Elem()
+	value.Set(reflect.Zero(value.Type()))
+	if err := json.Unmarshal(merged, current); err != nil {
+		return err
+	}
+	return binding.Validator.ValidateStruct(current)
+}
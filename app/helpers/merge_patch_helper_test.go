@@ -0,0 +1,83 @@
+package helpers_test
+
+import (
+	"net/http/httptest"
+	"strings"
+
+	"golang_starter_kit_2025/app/helpers"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MergePatch", func() {
+	merge := func(document string, patch string) string {
+		result, err := helpers.MergePatch([]byte(document), []byte(patch))
+		Expect(err).NotTo(HaveOccurred())
+		return string(result)
+	}
+
+	Context("when patch follows RFC 7396 examples", func() {
+		It("should replace, add and remove members", func() {
+			Expect(merge(`{"a":"b"}`, `{"a":"c"}`)).To(MatchJSON(`{"a":"c"}`))
+			Expect(merge(`{"a":"b"}`, `{"b":"c"}`)).To(MatchJSON(`{"a":"b","b":"c"}`))
+			Expect(merge(`{"a":"b","b":"c"}`, `{"a":null}`)).To(MatchJSON(`{"b":"c"}`))
+		})
+
+		It("should replace arrays and merge nested objects", func() {
+			Expect(merge(`{"a":["b"]}`, `{"a":"c"}`)).To(MatchJSON(`{"a":"c"}`))
+			Expect(merge(`{"a":[{"b":"c"}]}`, `{"a":[1]}`)).To(MatchJSON(`{"a":[1]}`))
+			Expect(merge(`{"e":null}`, `{"a":1}`)).To(MatchJSON(`{"e":null,"a":1}`))
+			Expect(merge(`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`)).To(MatchJSON(`{"a":{"b":"d"}}`))
+			Expect(merge(`{}`, `{"a":{"bb":{"ccc":null}}}`)).To(MatchJSON(`{"a":{"bb":{}}}`))
+		})
+
+		It("should keep large numbers exact", func() {
+			Expect(merge(`{"id":9007199254740993,"price":0.1}`, `{"stock":9007199254740995}`)).
+				To(Equal(`{"id":9007199254740993,"price":0.1,"stock":9007199254740995}`))
+		})
+
+		It("should reject trailing data", func() {
+			_, err := helpers.MergePatch([]byte(`{}`), []byte(`{"a":1}}`))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("when patch is bound to a request struct", func() {
+		type request struct {
+			Name        string `json:"name" binding:"required"`
+			Description string `json:"description"`
+			Stock       *int   `json:"stock"`
+			ParentID    *uint  `json:"parent_id"`
+		}
+
+		bind := func(contentType string, body string, current *request) error {
+			ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+			ctx.Request = httptest.NewRequest("PATCH", "/", strings.NewReader(body))
+			ctx.Request.Header.Set("Content-Type", contentType)
+			return helpers.BindMergePatch(ctx, current)
+		}
+
+		It("should keep absent members and clear null members", func() {
+			parentID, stock := uint(3), 12
+			current := request{Name: "Pupuk", Description: "NPK", Stock: &stock, ParentID: &parentID}
+			Expect(bind(helpers.ContentTypeMergePatch, `{"description":"","stock":0,"parent_id":null}`, &current)).To(Succeed())
+			Expect(current.Name).To(Equal("Pupuk"))
+			Expect(current.Description).To(Equal(""))
+			Expect(*current.Stock).To(Equal(0))
+			Expect(current.ParentID).To(BeNil())
+		})
+
+		It("should validate the merged request", func() {
+			current := request{Name: "Pupuk"}
+			Expect(bind("application/json", `{"name":null}`, &current)).To(HaveOccurred())
+		})
+
+		It("should reject unsupported content type and non object patch", func() {
+			current := request{Name: "Pupuk"}
+			Expect(bind("text/plain", `{"name":"Benih"}`, &current)).To(MatchError(helpers.ErrMergePatchContentType))
+			Expect(bind(helpers.ContentTypeMergePatch, `[1]`, &current)).To(MatchError(helpers.ErrInvalidMergePatch))
+		})
+	})
+})
@@ -0,0 +1,142 @@
+package helpers
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang_starter_kit_2025/app/casts"
+)
+
+// regions.csv berisi kode wilayah Kemendagri: seluruh provinsi (2 digit) dan
+// kabupaten/kota (4 digit), serta kecamatan (6 digit) yang divalidasi hanya
+// untuk kabupaten/kota yang data kecamatannya tersedia.
+//
+//go:embed data/regions.csv
+var regionsCSV []byte
+
+var (
+	regions       map[string]string
+	regionParents map[string]bool // kode wilayah yang memiliki data wilayah turunan
+	regionsOnce   sync.Once
+)
+
+func loadRegions() map[string]string {
+	regionsOnce.Do(func() {
+		regions = make(map[string]string)
+		regionParents = make(map[string]bool)
+		records, err := csv.NewReader(bytes.NewReader(regionsCSV)).ReadAll()
+		if err != nil {
+			panic(fmt.Sprintf("invalid embedded regions dataset: %v", err))
+		}
+		for _, record := range records[1:] {
+			regions[record[0]] = record[1]
+			if len(record[0]) > 2 {
+				regionParents[record[0][:len(record[0])-2]] = true
+			}
+		}
+	})
+	return regions
+}
+
+// RegionName mengembalikan nama wilayah berdasarkan kode Kemendagri.
+func RegionName(code string) (string, bool) {
+	name, ok := loadRegions()[code]
+	return name, ok
+}
+
+// hasSubregions mengecek apakah dataset memiliki wilayah turunan dari code.
+func hasSubregions(code string) bool {
+	loadRegions()
+	return regionParents[code]
+}
+
+// NormalizeGender menyeragamkan jenis kelamin menjadi casts.GenderMale atau
+// casts.GenderFemale, string kosong jika tidak dikenali.
+func NormalizeGender(gender string) string {
+	switch strings.ToLower(strings.TrimSpace(gender)) {
+	case "pria", "laki-laki", "laki laki", "l", "male":
+		return casts.GenderMale
+	case "wanita", "perempuan", "p", "female":
+		return casts.GenderFemale
+	}
+	return ""
+}
+
+// DecodeNIK memvalidasi struktur NIK 16 digit (PPKKCC DDMMYY SSSS) dan
+// mengurai kode wilayah, tanggal lahir dan jenis kelamin. Tanggal lahir
+// perempuan ditambah 40.
+func DecodeNIK(nik string) (*casts.NIKData, error) {
+	if len(nik) != 16 {
+		return nil, errors.New("NIK harus 16 digit")
+	}
+	for _, r := range nik {
+		if r < '0' || r > '9' {
+			return nil, errors.New("NIK hanya boleh berisi angka")
+		}
+	}
+
+	data := casts.NIKData{
+		ProvinceCode: nik[0:2],
+		RegencyCode:  nik[0:4],
+		DistrictCode: nik[0:6],
+		Serial:       nik[12:16],
+		Gender:       casts.GenderMale,
+	}
+
+	province, ok := RegionName(data.ProvinceCode)
+	if !ok {
+		return nil, fmt.Errorf("kode provinsi %s tidak dikenal", data.ProvinceCode)
+	}
+	data.Province = province
+
+	if nik[2:4] == "00" {
+		return nil, errors.New("kode kabupaten/kota tidak valid")
+	}
+	regency, ok := RegionName(data.RegencyCode)
+	if !ok {
+		return nil, fmt.Errorf("kode kabupaten/kota %s tidak dikenal", data.RegencyCode)
+	}
+	data.Regency = regency
+
+	if nik[4:6] == "00" {
+		return nil, errors.New("kode kecamatan tidak valid")
+	}
+	if district, ok := RegionName(data.DistrictCode); ok {
+		data.District = district
+	} else if hasSubregions(data.RegencyCode) {
+		return nil, fmt.Errorf("kode kecamatan %s tidak dikenal", data.DistrictCode)
+	}
+
+	day, _ := strconv.Atoi(nik[6:8])
+	month, _ := strconv.Atoi(nik[8:10])
+	year, _ := strconv.Atoi(nik[10:12])
+	if day > 40 {
+		day -= 40
+		data.Gender = casts.GenderFemale
+	}
+
+	now := time.Now()
+	year += 2000
+	if year > now.Year() {
+		year -= 100
+	}
+
+	birthDate := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.Local)
+	if day < 1 || month < 1 || month > 12 || birthDate.Day() != day || birthDate.After(now) {
+		return nil, errors.New("tanggal lahir pada NIK tidak valid")
+	}
+	data.BirthDate = birthDate.Format("2006-01-02")
+
+	if data.Serial == "0000" {
+		return nil, errors.New("nomor urut NIK tidak valid")
+	}
+
+	return &data, nil
+}
@@ -0,0 +1,91 @@
+package helpers_test
+
+import (
+	"golang_starter_kit_2025/app/casts"
+	"golang_starter_kit_2025/app/helpers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DecodeNIK", func() {
+	Context("when NIK is valid", func() {
+		It("should decode region, birth date and gender of a male", func() {
+			data, err := helpers.DecodeNIK("7371011201900001")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(data.ProvinceCode).To(Equal("73"))
+			Expect(data.Province).To(Equal("Sulawesi Selatan"))
+			Expect(data.RegencyCode).To(Equal("7371"))
+			Expect(data.Regency).To(Equal("Kota Makassar"))
+			Expect(data.DistrictCode).To(Equal("737101"))
+			Expect(data.BirthDate).To(Equal("1990-01-12"))
+			Expect(data.Gender).To(Equal(casts.GenderMale))
+			Expect(data.Serial).To(Equal("0001"))
+		})
+
+		It("should decode regions outside Java, Bali and Sulawesi Selatan", func() {
+			data, err := helpers.DecodeNIK("1271010101800003")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(data.Province).To(Equal("Sumatera Utara"))
+			Expect(data.Regency).To(Equal("Kota Medan"))
+
+			data, err = helpers.DecodeNIK("9501011201900001")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(data.Province).To(Equal("Papua Pegunungan"))
+			Expect(data.Regency).To(Equal("Kab. Jayawijaya"))
+		})
+
+		It("should decode the district when it is in the dataset", func() {
+			data, err := helpers.DecodeNIK("1271010101800003")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(data.DistrictCode).To(Equal("127101"))
+			Expect(data.District).To(Equal("Medan Kota"))
+
+			data, err = helpers.DecodeNIK("3471145208850002")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(data.District).To(Equal("Tegalrejo"))
+		})
+
+		It("should subtract 40 from the birth day of a female", func() {
+			data, err := helpers.DecodeNIK("3273015208850002")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(data.BirthDate).To(Equal("1985-08-12"))
+			Expect(data.Gender).To(Equal(casts.GenderFemale))
+		})
+	})
+
+	Context("when NIK is invalid", func() {
+		DescribeTable("should return error",
+			func(nik string) {
+				_, err := helpers.DecodeNIK(nik)
+				Expect(err).To(HaveOccurred())
+			},
+			Entry("too short", "737101120190"),
+			Entry("contains letters", "73710112019A0001"),
+			Entry("unknown province", "9971011201900001"),
+			Entry("unknown regency", "7399011201900001"),
+			Entry("empty district", "7371001201900001"),
+			Entry("invalid month", "7371011213900001"),
+			Entry("invalid day", "7371013102900001"),
+			Entry("empty serial", "7371011201900000"),
+		)
+
+		It("should reject unknown districts of a regency with district data", func() {
+			_, err := helpers.DecodeNIK("1271220101800003")
+			Expect(err).To(MatchError(ContainSubstring("kode kecamatan 127122")))
+
+			_, err = helpers.DecodeNIK("3471155208850002")
+			Expect(err).To(MatchError(ContainSubstring("kode kecamatan 347115")))
+		})
+	})
+})
+
+var _ = Describe("NormalizeGender", func() {
+	It("should normalize common spellings", func() {
+		Expect(helpers.NormalizeGender("Laki-laki")).To(Equal(casts.GenderMale))
+		Expect(helpers.NormalizeGender("pria")).To(Equal(casts.GenderMale))
+		Expect(helpers.NormalizeGender("Perempuan")).To(Equal(casts.GenderFemale))
+		Expect(helpers.NormalizeGender("wanita")).To(Equal(casts.GenderFemale))
+		Expect(helpers.NormalizeGender("unknown")).To(BeEmpty())
+	})
+})
@@ -0,0 +1,22 @@
+package helpers
+
+import "strings"
+
+// Slugify mengubah teks menjadi slug huruf kecil dengan pemisah "-", contoh
+// "Pupuk & Nutrisi" menjadi "pupuk-nutrisi".
+func Slugify(text string) string {
+	var builder strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(text) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			builder.WriteRune(r)
+			dash = false
+			continue
+		}
+		if !dash && builder.Len() > 0 {
+			builder.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(builder.String(), "-")
+}
@@ -0,0 +1,22 @@
+package helpers_test
+
+import (
+	"golang_starter_kit_2025/app/helpers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Slugify", func() {
+	Context("when text contains spaces and symbols", func() {
+		It("should return lowercase slug separated by dash", func() {
+			Expect(helpers.Slugify("  Pupuk & Nutrisi Tanaman ")).To(Equal("pupuk-nutrisi-tanaman"))
+		})
+	})
+
+	Context("when text has no letters or digits", func() {
+		It("should return empty slug", func() {
+			Expect(helpers.Slugify("#&!")).To(Equal(""))
+		})
+	})
+})
@@ -0,0 +1,17 @@
+package helpers
+
+import (
+	"golang_starter_kit_2025/app/casts"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetStoreContext mengambil casts.StoreContext yang di-set oleh StoreMiddleware.
+func GetStoreContext(ctx *gin.Context) casts.StoreContext {
+	if value, ok := ctx.Get("store_context"); ok {
+		if store, ok := value.(casts.StoreContext); ok {
+			return store
+		}
+	}
+	return casts.StoreContext{UserID: ctx.GetUint("user_id")}
+}
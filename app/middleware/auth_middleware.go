@@ -53,6 +53,7 @@ func AuthMiddleware() gin.HandlerFunc {
 		// set token and user id to context
 		c.Set("token", tokenString)
 		c.Set("user_id", claims.UserID)
+		c.Set("store_id", claims.StoreID)
 		// c.JSON(http.StatusOK, gin.H{"user_id": c.GetString("user_id")})
 		// c.Request.WithContext(context.WithValue(c.Request.Context(), "user_id", claims.UserID))
 		// var user models.User
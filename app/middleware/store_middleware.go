@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+
+	"golang_starter_kit_2025/app/helpers"
+	"golang_starter_kit_2025/app/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StoreMiddleware menentukan toko yang dapat diakses user. Toko aktif diambil
+// dari header X-Store-ID, atau claim store_id pada token jika header kosong.
+// Harus dipasang setelah AuthMiddleware.
+func StoreMiddleware() gin.HandlerFunc {
+	storeService := services.NewStoreService()
+
+	return func(c *gin.Context) {
+		storeID := c.GetUint("store_id")
+		if header := c.GetHeader("X-Store-ID"); header != "" {
+			id, err := strconv.ParseUint(header, 10, 64)
+			if err != nil {
+				helpers.ResponseError(c, &helpers.ResponseParams[any]{
+					Reference: "ERROR-4",
+					Message:   "Header X-Store-ID tidak valid",
+				}, http.StatusBadRequest)
+				c.Abort()
+				return
+			}
+			storeID = uint(id)
+		}
+
+		store, err := storeService.ResolveContext(c.GetUint("user_id"), storeID)
+		if err != nil {
+			helpers.ResponseError(c, &helpers.ResponseParams[any]{
+				Reference: "ERROR-3",
+				Message:   "Gagal memuat akses toko",
+				Errors:    map[string]string{"error": err.Error()},
+			}, http.StatusInternalServerError)
+			c.Abort()
+			return
+		}
+
+		if storeID != 0 && !store.CanAccess(storeID) {
+			helpers.ResponseError(c, &helpers.ResponseParams[any]{
+				Reference: "ERROR-5",
+				Message:   services.ErrStoreForbidden.Error(),
+			}, http.StatusForbidden)
+			c.Abort()
+			return
+		}
+
+		c.Set("store_context", store)
+		c.Next()
+	}
+}
@@ -7,11 +7,23 @@ import (
 )
 
 type Category struct {
-	ID       uint   `gorm:"primaryKey" json:"id"`
-	Category string `json:"category"`
+	ID        uint   `gorm:"primaryKey" json:"id"`
+	ParentID  *uint  `json:"parent_id"`
+	Category  string `json:"category"`
+	Slug      string `gorm:"unique" json:"slug"`
+	Path      string `json:"path"`  // materialised path ID leluhur, contoh "/1/5/"
+	Depth     int    `json:"depth"` // 0 untuk kategori root
+	SortOrder int    `json:"sort_order"`
 
-	Products *[]Product `gorm:"foreignKey:CategoryID" json:"products"`
+	// jumlah produk pada kategori ini dan seluruh turunannya
+	ProductCount int64 `gorm:"-" json:"product_count"`
 
+	Parent     *Category           `gorm:"foreignKey:ParentID" json:"parent,omitempty"`
+	Children   []Category          `gorm:"foreignKey:ParentID" json:"children,omitempty"`
+	Products   *[]Product          `gorm:"foreignKey:CategoryID" json:"products"`
+	Attributes []CategoryAttribute `json:"attributes,omitempty"`
+
+	Version   uint           `gorm:"default:1" json:"version"` // optimistic locking, naik setiap update
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at" swaggerignore:"true"`
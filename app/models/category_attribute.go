@@ -0,0 +1,59 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+)
+
+const (
+	CategoryAttributeText   = "text"
+	CategoryAttributeNumber = "number"
+	CategoryAttributeOption = "option"
+)
+
+// CategoryAttribute adalah definisi atribut varian produk untuk satu
+// kategori, misalnya ukuran kemasan pupuk.
+type CategoryAttribute struct {
+	ID         uint     `gorm:"primaryKey" json:"id"`
+	CategoryID uint     `json:"category_id"`
+	Code       string   `json:"code"` // kunci atribut pada filter produk, unik per kategori
+	Name       string   `json:"name"`
+	Type       string   `json:"type" enums:"text,number,option"`
+	Options    []string `gorm:"serializer:json" json:"options"` // pilihan nilai untuk tipe option
+	Unit       string   `json:"unit"`
+	Required   bool     `json:"required"` // wajib diisi pada setiap varian
+	SortOrder  int      `json:"sort_order"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Validate memeriksa nilai atribut sesuai tipenya.
+func (a *CategoryAttribute) Validate(value string) error {
+	switch a.Type {
+	case CategoryAttributeNumber:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("nilai atribut %s harus berupa angka", a.Name)
+		}
+	case CategoryAttributeOption:
+		if !slices.Contains(a.Options, value) {
+			return fmt.Errorf("nilai atribut %s harus salah satu dari %v", a.Name, a.Options)
+		}
+	}
+	return nil
+}
+
+// ProductAttributeValue adalah nilai atribut kategori pada satu produk atau varian.
+type ProductAttributeValue struct {
+	ID                  uint   `gorm:"primaryKey" json:"id"`
+	ProductID           uint   `json:"product_id"`
+	CategoryAttributeID uint   `json:"category_attribute_id"`
+	Value               string `json:"value"`
+
+	Attribute *CategoryAttribute `gorm:"foreignKey:CategoryAttributeID" json:"attribute,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,118 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"golang_starter_kit_2025/app/casts"
+	"golang_starter_kit_2025/app/helpers"
+
+	"gorm.io/gorm"
+)
+
+const (
+	CostBudgetPlanDraft     = "draft"
+	CostBudgetPlanSubmitted = "submitted"
+	CostBudgetPlanApproved  = "approved"
+	CostBudgetPlanRejected  = "rejected"
+	CostBudgetPlanDisbursed = "disbursed"
+	CostBudgetPlanClosed    = "closed"
+)
+
+const (
+	CostBudgetPlanActionSubmit   = "submit"
+	CostBudgetPlanActionApprove  = "approve"
+	CostBudgetPlanActionReject   = "reject"
+	CostBudgetPlanActionRevise   = "revise"
+	CostBudgetPlanActionDisburse = "disburse"
+	CostBudgetPlanActionClose    = "close"
+)
+
+const (
+	PermissionCostBudgetPlanSubmit   = "cost_budget_plan.submit"
+	PermissionCostBudgetPlanApprove  = "cost_budget_plan.approve"
+	PermissionCostBudgetPlanDisburse = "cost_budget_plan.disburse"
+	PermissionCostBudgetPlanClose    = "cost_budget_plan.close"
+)
+
+var ErrInvalidTransition = errors.New("perubahan status RAB tidak diizinkan")
+
+// CostBudgetPlanTransitionRule mendefinisikan status asal yang diizinkan,
+// status tujuan dan permission yang dibutuhkan untuk sebuah aksi.
+type CostBudgetPlanTransitionRule struct {
+	From       []string
+	To         string
+	Permission string
+}
+
+// CostBudgetPlanTransitionRules adalah state machine RAB:
+// draft → submitted → approved/rejected → disbursed → closed, dan rejected
+// dapat dikembalikan ke draft untuk direvisi. Aksi approve tetap di status
+// submitted sampai seluruh level approval terpenuhi.
+var CostBudgetPlanTransitionRules = map[string]CostBudgetPlanTransitionRule{
+	CostBudgetPlanActionSubmit:   {From: []string{CostBudgetPlanDraft}, To: CostBudgetPlanSubmitted, Permission: PermissionCostBudgetPlanSubmit},
+	CostBudgetPlanActionApprove:  {From: []string{CostBudgetPlanSubmitted}, To: CostBudgetPlanApproved, Permission: PermissionCostBudgetPlanApprove},
+	CostBudgetPlanActionReject:   {From: []string{CostBudgetPlanSubmitted}, To: CostBudgetPlanRejected, Permission: PermissionCostBudgetPlanApprove},
+	CostBudgetPlanActionRevise:   {From: []string{CostBudgetPlanRejected}, To: CostBudgetPlanDraft, Permission: PermissionCostBudgetPlanSubmit},
+	CostBudgetPlanActionDisburse: {From: []string{CostBudgetPlanApproved}, To: CostBudgetPlanDisbursed, Permission: PermissionCostBudgetPlanDisburse},
+	CostBudgetPlanActionClose:    {From: []string{CostBudgetPlanDisbursed}, To: CostBudgetPlanClosed, Permission: PermissionCostBudgetPlanClose},
+}
+
+// CostBudgetPlan adalah Rencana Anggaran Biaya (RAB) budidaya satu jenis
+// tanaman di lahan member.
+type CostBudgetPlan struct {
+	ID            uint                        `gorm:"primaryKey" json:"id"`
+	Reference     string                      `gorm:"unique" json:"reference"`
+	MemberID      uint                        `json:"member_id"`
+	StoreID       uint                        `json:"store_id"`
+	MemberLandID  uint                        `json:"member_land_id"`
+	JenisTanam    string                      `json:"jenis_tanam"`
+	CreatedBy     *uint                       `json:"created_by"`
+	Status        string                      `json:"status"`
+	ApprovalLevel int                         `json:"approval_level"` // jumlah level approval yang sudah disetujui
+	ApprovalSteps CostBudgetPlanApprovalSteps `json:"approval_steps"` // level approval yang berlaku sejak RAB diajukan
+	Total         casts.Money                 `json:"total"`
+	Currency      string                      `json:"currency"`
+	SubmittedAt   *time.Time                  `json:"submitted_at"`
+	ApprovedAt    *time.Time                  `json:"approved_at"`
+	DisbursedAt   *time.Time                  `json:"disbursed_at"`
+	ClosedAt      *time.Time                  `json:"closed_at"`
+
+	Member      *Member                    `json:"member,omitempty"`
+	Store       *Store                     `json:"store,omitempty"`
+	MemberLand  *MemberLand                `json:"member_land,omitempty"`
+	Items       []CostBudgetPlanItem       `json:"items,omitempty"`
+	Transitions []CostBudgetPlanTransition `json:"transitions,omitempty"`
+
+	Version   uint           `gorm:"default:1" json:"version"` // optimistic locking, naik setiap update
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at" swaggerignore:"true"`
+}
+
+// BeforeCreate hook
+func (p *CostBudgetPlan) BeforeCreate(tx *gorm.DB) (err error) {
+	p.Reference = helpers.GenerateReference("RAB")
+	p.Currency = casts.NormalizeCurrency(p.Currency)
+	return
+}
+
+// Editable menandakan item RAB masih boleh diubah.
+func (p *CostBudgetPlan) Editable() bool {
+	return p.Status == CostBudgetPlanDraft
+}
+
+// Rule mengembalikan aturan transisi untuk action jika dapat dijalankan dari
+// status RAB saat ini.
+func (p *CostBudgetPlan) Rule(action string) (CostBudgetPlanTransitionRule, error) {
+	rule, ok := CostBudgetPlanTransitionRules[action]
+	if !ok {
+		return rule, fmt.Errorf("%w: aksi %q tidak dikenal", ErrInvalidTransition, action)
+	}
+	if !slices.Contains(rule.From, p.Status) {
+		return rule, fmt.Errorf("%w: %s tidak dapat dilakukan dari status %s", ErrInvalidTransition, action, p.Status)
+	}
+	return rule, nil
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"golang_starter_kit_2025/app/casts"
+)
+
+// CostBudgetPlanApprovalLevel adalah satu level approval RAB. Level berlaku
+// untuk RAB dengan total >= MinAmount dan disetujui oleh user dengan RoleID.
+// StoreID kosong berarti level default untuk toko yang tidak memiliki
+// konfigurasi sendiri.
+type CostBudgetPlanApprovalLevel struct {
+	ID        uint        `gorm:"primaryKey" json:"id"`
+	StoreID   *uint       `json:"store_id"`
+	Level     int         `json:"level"`
+	MinAmount casts.Money `json:"min_amount"`
+	RoleID    uint        `json:"role_id"`
+
+	Role *Role `json:"role,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CostBudgetPlanApprovalStep adalah salinan level approval yang disimpan pada
+// RAB saat diajukan. RoleID 0 hanya dipakai level bawaan saat toko tidak
+// memiliki konfigurasi, level tersebut dapat disetujui user dengan permission
+// approve.
+type CostBudgetPlanApprovalStep struct {
+	Level     int         `json:"level"`
+	MinAmount casts.Money `json:"min_amount"`
+	RoleID    uint        `json:"role_id"`
+}
+
+// CostBudgetPlanApprovalSteps disimpan sebagai JSON sehingga perubahan
+// konfigurasi level tidak memengaruhi RAB yang sedang diproses.
+type CostBudgetPlanApprovalSteps []CostBudgetPlanApprovalStep
+
+// Scan implements sql.Scanner
+func (s *CostBudgetPlanApprovalSteps) Scan(value any) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*s = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("tidak dapat membaca %T sebagai CostBudgetPlanApprovalSteps", value)
+	}
+	return json.Unmarshal(data, s)
+}
+
+// Value implements driver.Valuer
+func (s CostBudgetPlanApprovalSteps) Value() (driver.Value, error) {
+	if len(s) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"golang_starter_kit_2025/app/casts"
+)
+
+type CostBudgetPlanItem struct {
+	ID                uint        `gorm:"primaryKey" json:"id"`
+	CostBudgetPlanID  uint        `json:"cost_budget_plan_id"`
+	ProductOfferingID uint        `json:"product_offering_id"`
+	PhaseID           uint        `json:"phase_id"`
+	Quantity          float64     `json:"quantity"`
+	Price             casts.Money `json:"price"` // harga offering saat item disimpan
+	Subtotal          casts.Money `json:"subtotal"`
+	Description       string      `json:"description"`
+
+	ProductOffering *ProductOffering `json:"product_offering,omitempty"`
+	Phase           *Phase           `json:"phase,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"golang_starter_kit_2025/app/casts"
+)
+
+// CostBudgetPlanItemHistory adalah realisasi item RAB: produk yang benar-benar
+// diberikan ke member beserta jumlah dan biayanya.
+type CostBudgetPlanItemHistory struct {
+	ID                   uint        `gorm:"primaryKey" json:"id"`
+	CostBudgetPlanItemID uint        `json:"cost_budget_plan_item_id"`
+	ProductID            uint        `json:"product_id"`
+	Quantity             float64     `json:"quantity"`
+	Price                casts.Money `json:"price"` // harga produk saat realisasi dicatat
+	Amount               casts.Money `json:"amount"`
+	IssuedAt             time.Time   `gorm:"type:date" json:"issued_at"`
+	Description          string      `json:"description"`
+	ActorID              *uint       `json:"actor_id"`
+
+	Product *Product `json:"product,omitempty"`
+	Actor   *User    `gorm:"foreignKey:ActorID" json:"actor,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var ErrTransitionImmutable = errors.New("riwayat status RAB tidak dapat diubah")
+
+// CostBudgetPlanTransition adalah riwayat perubahan status RAB. Baris yang
+// sudah tercatat tidak dapat diubah maupun dihapus.
+type CostBudgetPlanTransition struct {
+	ID               uint      `gorm:"primaryKey" json:"id"`
+	CostBudgetPlanID uint      `json:"cost_budget_plan_id"`
+	Action           string    `json:"action"`
+	FromStatus       string    `json:"from_status"`
+	ToStatus         string    `json:"to_status"`
+	Level            int       `json:"level"`
+	ActorID          *uint     `json:"actor_id"`
+	Comment          string    `json:"comment"`
+	CreatedAt        time.Time `json:"created_at"`
+
+	Actor *User `gorm:"foreignKey:ActorID" json:"actor,omitempty"`
+}
+
+// BeforeUpdate hook
+func (t *CostBudgetPlanTransition) BeforeUpdate(tx *gorm.DB) (err error) {
+	return ErrTransitionImmutable
+}
+
+// BeforeDelete hook
+func (t *CostBudgetPlanTransition) BeforeDelete(tx *gorm.DB) (err error) {
+	return ErrTransitionImmutable
+}
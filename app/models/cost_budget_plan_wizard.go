@@ -0,0 +1,71 @@
+package models
+
+import (
+	"slices"
+	"time"
+
+	"golang_starter_kit_2025/app/helpers"
+
+	"gorm.io/gorm"
+)
+
+const (
+	CostBudgetPlanStepMember = "member"
+	CostBudgetPlanStepLand   = "land"
+	CostBudgetPlanStepCrop   = "crop"
+	CostBudgetPlanStepItems  = "items"
+	CostBudgetPlanStepReview = "review"
+)
+
+// CostBudgetPlanSteps adalah urutan langkah wizard RAB.
+var CostBudgetPlanSteps = []string{
+	CostBudgetPlanStepMember,
+	CostBudgetPlanStepLand,
+	CostBudgetPlanStepCrop,
+	CostBudgetPlanStepItems,
+	CostBudgetPlanStepReview,
+}
+
+// CostBudgetPlanWizard menyimpan isian wizard RAB per langkah sehingga dapat
+// dilanjutkan dari perangkat lain sampai difinalisasi menjadi CostBudgetPlan.
+type CostBudgetPlanWizard struct {
+	ID        uint                     `gorm:"primaryKey" json:"id"`
+	Reference string                   `gorm:"unique" json:"reference"`
+	UserID    uint                     `json:"user_id"`
+	StoreID   uint                     `json:"store_id"`
+	Step      string                   `json:"step"` // langkah terakhir yang sudah disimpan
+	Data      CostBudgetPlanWizardData `gorm:"serializer:json" json:"data"`
+	ExpiresAt time.Time                `json:"expires_at"`
+	CreatedAt time.Time                `json:"created_at"`
+	UpdatedAt time.Time                `json:"updated_at"`
+}
+
+type CostBudgetPlanWizardData struct {
+	MemberID     uint                       `json:"member_id,omitempty"`
+	MemberLandID uint                       `json:"member_land_id,omitempty"`
+	JenisTanam   string                     `json:"jenis_tanam,omitempty"`
+	Items        []CostBudgetPlanWizardItem `json:"items,omitempty"`
+}
+
+type CostBudgetPlanWizardItem struct {
+	ProductOfferingID uint    `json:"product_offering_id"`
+	PhaseID           uint    `json:"phase_id"`
+	Quantity          float32 `json:"quantity"`
+	Description       string  `json:"description"`
+}
+
+// BeforeCreate hook
+func (w *CostBudgetPlanWizard) BeforeCreate(tx *gorm.DB) (err error) {
+	w.Reference = helpers.GenerateReference("DRF")
+	return
+}
+
+// StepIndex mengembalikan posisi step pada CostBudgetPlanSteps, -1 jika tidak dikenal.
+func StepIndex(step string) int {
+	return slices.Index(CostBudgetPlanSteps, step)
+}
+
+// Expired menandakan wizard sudah melewati masa berlaku.
+func (w *CostBudgetPlanWizard) Expired() bool {
+	return time.Now().After(w.ExpiresAt)
+}
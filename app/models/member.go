@@ -0,0 +1,57 @@
+package models
+
+import (
+	"time"
+
+	"golang_starter_kit_2025/app/casts"
+	"golang_starter_kit_2025/app/helpers"
+
+	"gorm.io/gorm"
+)
+
+type Member struct {
+	ID           uint   `gorm:"primaryKey" json:"id"`
+	Reference    string `gorm:"unique" json:"reference"`
+	Nama         string `json:"nama"`
+	Phone        string `json:"phone"`
+	Alamat       string `json:"alamat"`
+	NIK          string `gorm:"column:nik;unique" json:"nik"`
+	JenisKelamin string `json:"jenis_kelamin"`
+	FotoKTP      string `gorm:"column:foto_ktp" json:"foto_ktp"`
+
+	NIKData *casts.NIKData `gorm:"-" json:"nik_data,omitempty"`
+
+	Stores []Store      `gorm:"many2many:store_members;" json:"stores,omitempty"`
+	Lands  []MemberLand `gorm:"foreignKey:MemberID" json:"lands,omitempty"`
+
+	Version   uint           `gorm:"default:1" json:"version"` // optimistic locking, naik setiap update
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at" swaggerignore:"true"`
+}
+
+// BeforeCreate hook
+func (m *Member) BeforeCreate(tx *gorm.DB) (err error) {
+	m.Reference = helpers.GenerateReference("MBR")
+	return
+}
+
+// AfterFind hook
+func (m *Member) AfterFind(tx *gorm.DB) (err error) {
+	m.NIKData, _ = helpers.DecodeNIK(m.NIK)
+	if m.FotoKTP != "" {
+		m.FotoKTP = helpers.GetFileURL(m.FotoKTP, "members")
+	}
+
+	return
+}
+
+// AfterCreate hook
+func (m *Member) AfterCreate(tx *gorm.DB) (err error) {
+	m.NIKData, _ = helpers.DecodeNIK(m.NIK)
+	if m.FotoKTP != "" {
+		m.FotoKTP = helpers.GetFileURL(m.FotoKTP, "members")
+	}
+
+	return
+}
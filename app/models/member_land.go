@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"golang_starter_kit_2025/app/casts"
+	"golang_starter_kit_2025/app/helpers"
+
+	"gorm.io/gorm"
+)
+
+type MemberLand struct {
+	ID          uint              `gorm:"primaryKey" json:"id"`
+	Reference   string            `gorm:"unique" json:"reference"`
+	MemberID    uint              `json:"member_id"`
+	JenisTanam  []string          `gorm:"serializer:json" json:"jenis_tanam"`
+	JumlahPanen int               `json:"jumlah_panen"`
+	LuasLahan   float64           `json:"luas_lahan"`
+	Alamat      string            `json:"alamat"`
+	Latitude    float64           `json:"latitude"`
+	Longitude   float64           `json:"longitude"`
+	Boundary    *casts.GeoPolygon `gorm:"type:json" json:"boundary"`
+	LuasTerukur *float64          `json:"luas_terukur"` // luas hasil perhitungan boundary, hektar
+	Sertifikat  string            `json:"sertifikat"`
+	Description string            `json:"description"`
+
+	Member *Member `json:"member,omitempty"`
+
+	Version   uint           `gorm:"default:1" json:"version"` // optimistic locking, naik setiap update
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at" swaggerignore:"true"`
+}
+
+// BeforeCreate hook
+func (m *MemberLand) BeforeCreate(tx *gorm.DB) (err error) {
+	m.Reference = helpers.GenerateReference("LND")
+	return
+}
+
+// AfterFind hook
+func (m *MemberLand) AfterFind(tx *gorm.DB) (err error) {
+	if m.Sertifikat != "" {
+		m.Sertifikat = helpers.GetFileURL(m.Sertifikat, "member_lands")
+	}
+
+	return
+}
@@ -2,12 +2,17 @@ package models
 
 import (
 	"time"
+
+	"gorm.io/gorm"
 )
 
 type Permission struct {
 	ID    uint   `gorm:"primaryKey" json:"id"`
 	Name  string `json:"name"`
 	Group string `json:"group"`
+
+	Version   uint           `gorm:"default:1" json:"version"` // optimistic locking, naik setiap update
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at" swaggerignore:"true"`
 }
 
 type UserHasPermissions struct {
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// Phase adalah tahapan budidaya yang mengelompokkan item RAB.
+type Phase struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Name      string    `gorm:"unique" json:"name"`
+	SortOrder int       `json:"sort_order"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
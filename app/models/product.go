@@ -3,28 +3,40 @@ package models
 import (
 	"time"
 
+	"golang_starter_kit_2025/app/casts"
 	"golang_starter_kit_2025/app/helpers"
 
 	"gorm.io/gorm"
 )
 
 type Product struct {
-	ID          uint      `gorm:"primaryKey" json:"id"`
-	Reference   string    `gorm:"unique" json:"reference"`
-	StoreID     uint      `json:"store_id"`
-	CategoryID  uint      `json:"category_id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Price       float64   `json:"price"`
-	Margin      float64   `json:"margin"`
-	Stock       int       `json:"stock"`
-	Sold        int       `json:"sold"`
-	Images      []string  `json:"images" gorm:"serializer:json"`
-	ReceivedAt  time.Time `json:"received_at"`
+	ID          uint        `gorm:"primaryKey" json:"id"`
+	Reference   string      `gorm:"unique" json:"reference"`
+	SKU         *string     `gorm:"column:sku" json:"sku"` // kode varian, unik per toko
+	Barcode     *string     `gorm:"unique" json:"barcode"` // EAN-13 atau Code128, dibuat otomatis jika kosong
+	StoreID     uint        `json:"store_id"`
+	CategoryID  uint        `json:"category_id"`
+	ParentID    *uint       `json:"parent_id"` // produk induk jika produk ini adalah varian
+	Name        string      `json:"name"`
+	Description *string     `json:"description"`
+	Price       casts.Money `json:"price"`
+	Margin      casts.Money `json:"margin"`
+	CostPrice   casts.Money `json:"cost_price"` // harga pokok rata-rata tertimbang dari penerimaan barang
+	Currency    string      `json:"currency"`
+	Stock       int         `json:"stock"`
+	Sold        int         `json:"sold"`
+	MinStock    *int        `json:"min_stock"` // kosong berarti mengikuti default toko
+	MaxStock    *int        `json:"max_stock"` // kosong berarti mengikuti default toko
+	Images      []string    `json:"images" gorm:"serializer:json"`
+	ReceivedAt  *time.Time  `gorm:"default:CURRENT_TIMESTAMP" json:"received_at"`
 
-	// Store    *Store    `json:"store"`
-	Category *Category `json:"category"`
+	Store      *Store                  `json:"store"`
+	Category   *Category               `json:"category"`
+	Parent     *Product                `gorm:"foreignKey:ParentID" json:"parent,omitempty"`
+	Variants   []Product               `gorm:"foreignKey:ParentID" json:"variants,omitempty"`
+	Attributes []ProductAttributeValue `json:"attributes,omitempty"`
 
+	Version   uint           `gorm:"default:1" json:"version"` // optimistic locking, naik setiap update
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at" swaggerignore:"true"`
@@ -33,6 +45,7 @@ type Product struct {
 // BeforeCreate hook
 func (p *Product) BeforeCreate(tx *gorm.DB) (err error) {
 	p.Reference = helpers.GenerateReference("PRD")
+	p.Currency = casts.NormalizeCurrency(p.Currency)
 	return
 }
 
@@ -40,7 +53,7 @@ func (p *Product) BeforeCreate(tx *gorm.DB) (err error) {
 func (m *Product) AfterFind(tx *gorm.DB) (err error) {
 	if m.Images != nil && len(m.Images) > 0 {
 		for i, Image := range m.Images {
-			m.Images[i] = helpers.GetFileURL(Image, "member_lands")
+			m.Images[i] = helpers.GetFileURL(Image, "products")
 		}
 	}
 
@@ -51,10 +64,20 @@ func (m *Product) AfterFind(tx *gorm.DB) (err error) {
 func (m *Product) AfterCreate(tx *gorm.DB) (err error) {
 	if m.Images != nil && len(m.Images) > 0 {
 		for i, image := range m.Images {
-			m.Images[i] = helpers.GetFileURL(image, "member_lands")
+			m.Images[i] = helpers.GetFileURL(image, "products")
 		}
 	}
 
+	// barcode dibuat dari ID produk sehingga tetap pendek untuk label
+	if m.Barcode == nil {
+		code, err := helpers.GenerateProductBarcode(m.ID)
+		if err != nil {
+			return err
+		}
+		m.Barcode = &code
+		return tx.Model(m).UpdateColumn("barcode", code).Error
+	}
+
 	return
 }
 
@@ -62,7 +85,7 @@ func (m *Product) AfterCreate(tx *gorm.DB) (err error) {
 func (m *Product) AfterUpdate(tx *gorm.DB) (err error) {
 	if m.Images != nil && len(m.Images) > 0 {
 		for i, image := range m.Images {
-			m.Images[i] = helpers.GetFileURL(image, "member_lands")
+			m.Images[i] = helpers.GetFileURL(image, "products")
 		}
 	}
 
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"golang_starter_kit_2025/app/casts"
+	"golang_starter_kit_2025/app/helpers"
+
+	"gorm.io/gorm"
+)
+
+const (
+	ProductOfferingActive   = "active"
+	ProductOfferingInactive = "inactive"
+)
+
+// ProductOffering adalah paket/produk yang ditawarkan ke member dalam RAB,
+// terpisah dari stok produk toko.
+type ProductOffering struct {
+	ID           uint         `gorm:"primaryKey" json:"id"`
+	Reference    string       `gorm:"unique" json:"reference"`
+	Name         string       `json:"name"`
+	Description  string       `json:"description"`
+	Price        casts.Money  `json:"price"` // harga dasar jika belum ada harga berlaku
+	Currency     string       `json:"currency"`
+	Status       string       `json:"status"`
+	CurrentPrice *casts.Money `gorm:"->;-:migration" json:"current_price,omitempty"` // harga berlaku saat ini, lihat ProductOfferingService
+
+	Products []ProductOfferingProduct `json:"products,omitempty"`
+	Prices   []ProductOfferingPrice   `json:"prices,omitempty"`
+
+	Version   uint           `gorm:"default:1" json:"version"` // optimistic locking, naik setiap update
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at" swaggerignore:"true"`
+}
+
+// BeforeCreate hook
+func (p *ProductOffering) BeforeCreate(tx *gorm.DB) (err error) {
+	p.Reference = helpers.GenerateReference("OFR")
+	p.Currency = casts.NormalizeCurrency(p.Currency)
+	return
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"golang_starter_kit_2025/app/casts"
+)
+
+// ProductOfferingPrice adalah harga product offering yang berlaku mulai
+// EffectiveFrom sampai sebelum EffectiveTo. EffectiveTo kosong berarti harga
+// berlaku sampai ada harga baru.
+type ProductOfferingPrice struct {
+	ID                uint        `gorm:"primaryKey" json:"id"`
+	ProductOfferingID uint        `json:"product_offering_id"`
+	Price             casts.Money `json:"price"`
+	EffectiveFrom     time.Time   `json:"effective_from"`
+	EffectiveTo       *time.Time  `json:"effective_to"`
+	CreatedBy         *uint       `json:"created_by"`
+	CreatedAt         time.Time   `json:"created_at"`
+	UpdatedAt         time.Time   `json:"updated_at"`
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// ProductOfferingProduct menghubungkan product offering dengan produk stok
+// penyusunnya. Quantity adalah jumlah produk untuk satu unit offering.
+type ProductOfferingProduct struct {
+	ID                uint    `gorm:"primaryKey" json:"id"`
+	ProductOfferingID uint    `json:"product_offering_id"`
+	ProductID         uint    `json:"product_id"`
+	Quantity          float64 `json:"quantity"`
+
+	Product *Product `json:"product,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
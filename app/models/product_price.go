@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"golang_starter_kit_2025/app/casts"
+)
+
+// ProductPrice adalah riwayat harga produk. Harga dengan AppliedAt kosong
+// adalah perubahan terjadwal yang belum diterapkan ke products.price.
+type ProductPrice struct {
+	ID            uint        `gorm:"primaryKey" json:"id"`
+	ProductID     uint        `json:"product_id"`
+	Price         casts.Money `json:"price"`
+	Margin        casts.Money `json:"margin"`
+	EffectiveFrom time.Time   `json:"effective_from"`
+	EffectiveTo   *time.Time  `json:"effective_to"`
+	AppliedAt     *time.Time  `json:"applied_at"`
+	CreatedBy     *uint       `json:"created_by"`
+
+	Creator *User `gorm:"foreignKey:CreatedBy" json:"creator,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Scheduled menandakan harga belum diterapkan ke produk.
+func (p *ProductPrice) Scheduled() bool {
+	return p.AppliedAt == nil
+}
@@ -0,0 +1,111 @@
+package models
+
+import (
+	"time"
+
+	"golang_starter_kit_2025/app/casts"
+	"golang_starter_kit_2025/app/helpers"
+
+	"gorm.io/gorm"
+)
+
+const (
+	PurchaseOrderOpen              = "open"
+	PurchaseOrderPartiallyReceived = "partially_received"
+	PurchaseOrderClosed            = "closed"
+	PurchaseOrderCancelled         = "cancelled"
+)
+
+// PurchaseOrder adalah pesanan pembelian ke supplier. Stok bertambah saat
+// barang diterima melalui GoodsReceipt, bukan saat PO dibuat.
+type PurchaseOrder struct {
+	ID         uint        `gorm:"primaryKey" json:"id"`
+	Reference  string      `gorm:"unique" json:"reference"`
+	StoreID    uint        `json:"store_id"`
+	SupplierID uint        `json:"supplier_id"`
+	Status     string      `json:"status" enums:"open,partially_received,closed,cancelled"`
+	Currency   string      `json:"currency"`
+	Total      casts.Money `json:"total"`
+	ExpectedAt *time.Time  `gorm:"type:date" json:"expected_at"`
+	Note       string      `json:"note"`
+	CreatedBy  *uint       `json:"created_by"`
+	ClosedAt   *time.Time  `json:"closed_at"`
+
+	Store    *Store              `json:"store,omitempty"`
+	Supplier *Supplier           `json:"supplier,omitempty"`
+	Items    []PurchaseOrderItem `json:"items,omitempty"`
+	Receipts []GoodsReceipt      `json:"receipts,omitempty"`
+
+	Version   uint      `gorm:"default:1" json:"version"` // optimistic locking, naik setiap update
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// BeforeCreate hook
+func (p *PurchaseOrder) BeforeCreate(tx *gorm.DB) (err error) {
+	p.Reference = helpers.GenerateReference("PO")
+	p.Currency = casts.NormalizeCurrency(p.Currency)
+	return
+}
+
+// Editable menandakan item PO masih boleh diubah, yaitu belum ada barang diterima.
+func (p *PurchaseOrder) Editable() bool {
+	return p.Status == PurchaseOrderOpen
+}
+
+// Receivable menandakan PO masih dapat menerima barang.
+func (p *PurchaseOrder) Receivable() bool {
+	return p.Status == PurchaseOrderOpen || p.Status == PurchaseOrderPartiallyReceived
+}
+
+type PurchaseOrderItem struct {
+	ID               uint        `gorm:"primaryKey" json:"id"`
+	PurchaseOrderID  uint        `json:"purchase_order_id"`
+	ProductID        uint        `json:"product_id"`
+	Quantity         int         `json:"quantity"`
+	ReceivedQuantity int         `json:"received_quantity"`
+	CostPrice        casts.Money `json:"cost_price"` // harga beli per unit yang dipesan
+	Subtotal         casts.Money `json:"subtotal"`
+
+	Product *Product `json:"product,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Outstanding mengembalikan quantity yang belum diterima.
+func (i *PurchaseOrderItem) Outstanding() int {
+	return i.Quantity - i.ReceivedQuantity
+}
+
+// GoodsReceipt adalah penerimaan barang (sebagian atau seluruhnya) dari PO.
+type GoodsReceipt struct {
+	ID              uint               `gorm:"primaryKey" json:"id"`
+	Reference       string             `gorm:"unique" json:"reference"`
+	PurchaseOrderID uint               `json:"purchase_order_id"`
+	ReceivedAt      time.Time          `json:"received_at"`
+	Note            string             `json:"note"`
+	ActorID         *uint              `json:"actor_id"`
+	Items           []GoodsReceiptItem `json:"items,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// BeforeCreate hook
+func (r *GoodsReceipt) BeforeCreate(tx *gorm.DB) (err error) {
+	r.Reference = helpers.GenerateReference("GRN")
+	return
+}
+
+type GoodsReceiptItem struct {
+	ID                  uint        `gorm:"primaryKey" json:"id"`
+	GoodsReceiptID      uint        `json:"goods_receipt_id"`
+	PurchaseOrderItemID uint        `json:"purchase_order_item_id"`
+	ProductID           uint        `json:"product_id"`
+	Quantity            int         `json:"quantity"`
+	CostPrice           casts.Money `json:"cost_price"` // harga beli aktual per unit
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -1,9 +1,14 @@
 package models
 
+import "gorm.io/gorm"
+
 type Role struct {
 	ID    uint   `gorm:"primaryKey" json:"id"`
 	Name  string `json:"name"`
 	Group string `json:"group"`
 
+	Version   uint           `gorm:"default:1" json:"version"` // optimistic locking, naik setiap update
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at" swaggerignore:"true"`
+
 	Users []User `gorm:"many2many:user_has_roles;" json:"users"`
 }
@@ -0,0 +1,129 @@
+package models
+
+import (
+	"time"
+
+	"golang_starter_kit_2025/app/casts"
+	"golang_starter_kit_2025/app/helpers"
+
+	"gorm.io/gorm"
+)
+
+const (
+	SaleCompleted         = "completed"
+	SalePartiallyReturned = "partially_returned"
+	SaleReturned          = "returned"
+	SaleVoided            = "voided"
+)
+
+const (
+	PaymentCash     = "cash"
+	PaymentTransfer = "transfer"
+	PaymentQRIS     = "qris"
+	PaymentDebit    = "debit"
+	PaymentCredit   = "credit"
+)
+
+// Sale adalah transaksi penjualan di toko. Reference dipakai sebagai nomor
+// struk. Stok dan Sold produk berubah bersamaan dengan transaksi dibuat,
+// di-void atau diretur.
+type Sale struct {
+	ID            uint        `gorm:"primaryKey" json:"id"`
+	Reference     string      `gorm:"unique" json:"reference"`
+	StoreID       uint        `json:"store_id"`
+	MemberID      *uint       `json:"member_id"`
+	CashierID     *uint       `json:"cashier_id"`
+	Status        string      `json:"status" enums:"completed,partially_returned,returned,voided"`
+	PaymentMethod string      `json:"payment_method" enums:"cash,transfer,qris,debit,credit"`
+	Currency      string      `json:"currency"`
+	Subtotal      casts.Money `json:"subtotal"` // jumlah subtotal item setelah diskon item
+	Discount      casts.Money `json:"discount"` // diskon transaksi
+	TaxPercent    float64     `json:"tax_percent"`
+	Tax           casts.Money `json:"tax"`
+	Total         casts.Money `json:"total"`
+	Paid          casts.Money `json:"paid"`
+	ChangeAmount  casts.Money `json:"change_amount"`
+	Refunded      casts.Money `json:"refunded"` // total pengembalian dana dari retur
+	Note          string      `json:"note"`
+	VoidedAt      *time.Time  `json:"voided_at"`
+	VoidedBy      *uint       `json:"voided_by"`
+	VoidReason    string      `json:"void_reason"`
+
+	Store   *Store       `json:"store,omitempty"`
+	Member  *Member      `json:"member,omitempty"`
+	Cashier *User        `gorm:"foreignKey:CashierID" json:"cashier,omitempty"`
+	Items   []SaleItem   `json:"items,omitempty"`
+	Returns []SaleReturn `json:"returns,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// BeforeCreate hook
+func (s *Sale) BeforeCreate(tx *gorm.DB) (err error) {
+	s.Reference = helpers.GenerateReference("RCP")
+	s.Currency = casts.NormalizeCurrency(s.Currency)
+	return
+}
+
+// Voidable menandakan transaksi masih dapat dibatalkan seluruhnya.
+func (s *Sale) Voidable() bool {
+	return s.Status == SaleCompleted
+}
+
+// Returnable menandakan masih ada item yang dapat diretur.
+func (s *Sale) Returnable() bool {
+	return s.Status == SaleCompleted || s.Status == SalePartiallyReturned
+}
+
+type SaleItem struct {
+	ID               uint        `gorm:"primaryKey" json:"id"`
+	SaleID           uint        `json:"sale_id"`
+	ProductID        uint        `json:"product_id"`
+	Quantity         int         `json:"quantity"`
+	ReturnedQuantity int         `json:"returned_quantity"`
+	Price            casts.Money `json:"price"` // harga produk saat transaksi
+	Discount         casts.Money `json:"discount"`
+	Subtotal         casts.Money `json:"subtotal"` // price * quantity - discount
+
+	Product *Product `json:"product,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Returnable mengembalikan quantity item yang belum diretur.
+func (i *SaleItem) Returnable() int {
+	return i.Quantity - i.ReturnedQuantity
+}
+
+// SaleReturn adalah retur sebagian atau seluruh item transaksi.
+type SaleReturn struct {
+	ID        uint             `gorm:"primaryKey" json:"id"`
+	Reference string           `gorm:"unique" json:"reference"`
+	SaleID    uint             `json:"sale_id"`
+	Reason    string           `json:"reason"`
+	Amount    casts.Money      `json:"amount"` // dana yang dikembalikan ke pembeli
+	ActorID   *uint            `json:"actor_id"`
+	Items     []SaleReturnItem `json:"items,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// BeforeCreate hook
+func (r *SaleReturn) BeforeCreate(tx *gorm.DB) (err error) {
+	r.Reference = helpers.GenerateReference("RTN")
+	return
+}
+
+type SaleReturnItem struct {
+	ID           uint        `gorm:"primaryKey" json:"id"`
+	SaleReturnID uint        `json:"sale_return_id"`
+	SaleItemID   uint        `json:"sale_item_id"`
+	Quantity     int         `json:"quantity"`
+	Amount       casts.Money `json:"amount"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
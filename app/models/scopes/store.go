@@ -0,0 +1,49 @@
+package scopes
+
+import (
+	"golang_starter_kit_2025/app/casts"
+
+	"gorm.io/gorm"
+)
+
+// StoreScope membatasi query ke toko yang dapat diakses user. Jika toko aktif
+// dipilih hanya data toko tersebut yang dikembalikan, admin tanpa toko aktif
+// melihat seluruh toko. column adalah kolom store_id pada tabel yang di-query.
+func StoreScope(store casts.StoreContext, column string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if store.StoreID != 0 {
+			return db.Where(column+" = ?", store.StoreID)
+		}
+		if store.IsAdmin {
+			return db
+		}
+		if len(store.StoreIDs) == 0 {
+			return db.Where("1 = 0")
+		}
+		return db.Where(column+" IN ?", store.StoreIDs)
+	}
+}
+
+// StoreScopeAny seperti StoreScope untuk data yang dimiliki lebih dari satu
+// toko, misalnya transfer stok. Data dikembalikan jika salah satu kolom
+// toko dapat diakses user.
+func StoreScopeAny(store casts.StoreContext, columns ...string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if store.StoreID == 0 && store.IsAdmin {
+			return db
+		}
+		if store.StoreID == 0 && len(store.StoreIDs) == 0 {
+			return db.Where("1 = 0")
+		}
+
+		condition := db.Session(&gorm.Session{NewDB: true})
+		for _, column := range columns {
+			if store.StoreID != 0 {
+				condition = condition.Or(column+" = ?", store.StoreID)
+			} else {
+				condition = condition.Or(column+" IN ?", store.StoreIDs)
+			}
+		}
+		return db.Where(condition)
+	}
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+const (
+	StockAlertOpen     = "open"
+	StockAlertResolved = "resolved"
+)
+
+// StockAlert dibuat saat stok produk turun di bawah batas minimum dan
+// diselesaikan otomatis saat stok kembali mencapai batas minimum. Setiap
+// produk hanya memiliki satu alert open.
+type StockAlert struct {
+	ID                uint       `gorm:"primaryKey" json:"id"`
+	StoreID           uint       `json:"store_id"`
+	ProductID         uint       `json:"product_id"`
+	Status            string     `json:"status" enums:"open,resolved"`
+	Stock             int        `json:"stock"` // stok saat alert dibuat
+	MinStock          int        `json:"min_stock"`
+	MaxStock          *int       `json:"max_stock"`
+	DailySales        float64    `json:"daily_sales"`        // rata-rata penjualan harian periode terakhir
+	SuggestedQuantity int        `json:"suggested_quantity"` // saran jumlah pemesanan ulang
+	ResolvedAt        *time.Time `json:"resolved_at"`
+
+	Store   *Store   `json:"store,omitempty"`
+	Product *Product `json:"product,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
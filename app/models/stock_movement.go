@@ -0,0 +1,72 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"golang_starter_kit_2025/app/helpers"
+
+	"gorm.io/gorm"
+)
+
+const (
+	StockMovementReceipt    = "receipt"
+	StockMovementSale       = "sale"
+	StockMovementReturn     = "return"
+	StockMovementAdjustment = "adjustment"
+	StockMovementTransfer   = "transfer"
+)
+
+// StockMovement adalah satu baris ledger stok. Quantity disimpan bertanda
+// (positif = stok masuk, negatif = stok keluar) sehingga stok produk selalu
+// sama dengan SUM(quantity) seluruh movement-nya.
+type StockMovement struct {
+	ID                uint   `gorm:"primaryKey" json:"id"`
+	Reference         string `gorm:"unique" json:"reference"`
+	ProductID         uint   `json:"product_id"`
+	Type              string `json:"type" enums:"receipt,sale,return,adjustment,transfer"`
+	Quantity          int    `json:"quantity"`
+	StockBefore       int    `json:"stock_before"`
+	StockAfter        int    `json:"stock_after"`
+	Reason            string `json:"reason"`
+	ActorID           *uint  `json:"actor_id"`
+	ReferenceDocument string `json:"reference_document"`
+
+	Product *Product `json:"product,omitempty"`
+	Actor   *User    `json:"actor,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// BeforeCreate hook
+func (m *StockMovement) BeforeCreate(tx *gorm.DB) (err error) {
+	m.Reference = helpers.GenerateReference("STM")
+	return
+}
+
+// SignedStockQuantity mengubah quantity dari request menjadi quantity bertanda
+// sesuai tipe movement. Receipt, sale dan return wajib bernilai positif,
+// sedangkan adjustment dan transfer boleh negatif untuk stok keluar.
+func SignedStockQuantity(movementType string, quantity int) (int, error) {
+	if quantity == 0 {
+		return 0, fmt.Errorf("quantity tidak boleh 0")
+	}
+
+	switch movementType {
+	case StockMovementReceipt, StockMovementReturn:
+		if quantity < 0 {
+			return 0, fmt.Errorf("quantity %s harus positif", movementType)
+		}
+		return quantity, nil
+	case StockMovementSale:
+		if quantity < 0 {
+			return 0, fmt.Errorf("quantity %s harus positif", movementType)
+		}
+		return -quantity, nil
+	case StockMovementAdjustment, StockMovementTransfer:
+		return quantity, nil
+	}
+
+	return 0, fmt.Errorf("tipe movement %s tidak dikenal", movementType)
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"time"
+
+	"golang_starter_kit_2025/app/casts"
+	"golang_starter_kit_2025/app/helpers"
+
+	"gorm.io/gorm"
+)
+
+const (
+	StockTakeCounting  = "counting"
+	StockTakeSubmitted = "submitted"
+	StockTakeApproved  = "approved"
+	StockTakeCancelled = "cancelled"
+
+	PermissionStockTakeApprove = "stock_take.approve"
+)
+
+// StockTake adalah sesi stock opname per toko. Stok sistem seluruh produk
+// dibekukan sebagai snapshot saat sesi dibuat, selisih hitung fisik terhadap
+// snapshot diposting sebagai movement adjustment saat sesi disetujui.
+type StockTake struct {
+	ID            uint       `gorm:"primaryKey" json:"id"`
+	Reference     string     `gorm:"unique" json:"reference"`
+	StoreID       uint       `json:"store_id"`
+	Status        string     `json:"status" enums:"counting,submitted,approved,cancelled"`
+	Note          string     `json:"note"`
+	CreatedBy     *uint      `json:"created_by"`
+	SubmittedBy   *uint      `json:"submitted_by"`
+	SubmittedAt   *time.Time `json:"submitted_at"`
+	ApprovedBy    *uint      `json:"approved_by"`
+	ApprovedAt    *time.Time `json:"approved_at"`
+	ReviewComment string     `json:"review_comment"` // komentar approver saat sesi dikembalikan untuk dihitung ulang
+
+	Store *Store          `json:"store,omitempty"`
+	Items []StockTakeItem `json:"items,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// BeforeCreate hook
+func (s *StockTake) BeforeCreate(tx *gorm.DB) (err error) {
+	s.Reference = helpers.GenerateReference("STK")
+	return
+}
+
+type StockTakeItem struct {
+	ID              uint        `gorm:"primaryKey" json:"id"`
+	StockTakeID     uint        `json:"stock_take_id"`
+	ProductID       uint        `json:"product_id"`
+	SystemQuantity  int         `json:"system_quantity"`  // stok sistem saat snapshot, diperbarui ke stok saat approval
+	CountedQuantity *int        `json:"counted_quantity"` // kosong jika belum dihitung
+	Variance        int         `json:"variance"`         // counted - system
+	CostPrice       casts.Money `json:"cost_price"`       // harga pokok saat snapshot
+	VarianceValue   casts.Money `gorm:"-" json:"variance_value"`
+	CountedBy       *uint       `json:"counted_by"`
+	CountedAt       *time.Time  `json:"counted_at"`
+
+	Product *Product `json:"product,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// AfterFind hook menghitung nilai selisih berdasarkan harga pokok snapshot.
+func (i *StockTakeItem) AfterFind(tx *gorm.DB) (err error) {
+	i.VarianceValue = i.CostPrice.Mul(float64(i.Variance))
+	return
+}
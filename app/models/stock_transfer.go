@@ -0,0 +1,75 @@
+package models
+
+import (
+	"time"
+
+	"golang_starter_kit_2025/app/casts"
+	"golang_starter_kit_2025/app/helpers"
+
+	"gorm.io/gorm"
+)
+
+const (
+	StockTransferDraft     = "draft"
+	StockTransferInTransit = "in_transit"
+	StockTransferReceived  = "received"
+	StockTransferCancelled = "cancelled"
+)
+
+// StockTransfer adalah dokumen perpindahan stok antar toko. Stok toko asal
+// berkurang saat dispatch dan stok toko tujuan bertambah saat receive, di
+// antaranya barang berstatus in transit.
+type StockTransfer struct {
+	ID             uint       `gorm:"primaryKey" json:"id"`
+	Reference      string     `gorm:"unique" json:"reference"`
+	FromStoreID    uint       `json:"from_store_id"`
+	ToStoreID      uint       `json:"to_store_id"`
+	Status         string     `json:"status" enums:"draft,in_transit,received,cancelled"`
+	Note           string     `json:"note"`
+	HasDiscrepancy bool       `json:"has_discrepancy"` // quantity diterima berbeda dari quantity dikirim
+	CreatedBy      *uint      `json:"created_by"`
+	DispatchedBy   *uint      `json:"dispatched_by"`
+	DispatchedAt   *time.Time `json:"dispatched_at"`
+	ReceivedBy     *uint      `json:"received_by"`
+	ReceivedAt     *time.Time `json:"received_at"`
+
+	FromStore *Store              `json:"from_store,omitempty"`
+	ToStore   *Store              `json:"to_store,omitempty"`
+	Items     []StockTransferItem `json:"items,omitempty"`
+
+	Version   uint      `gorm:"default:1" json:"version"` // optimistic locking, naik setiap update
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// BeforeCreate hook
+func (t *StockTransfer) BeforeCreate(tx *gorm.DB) (err error) {
+	t.Reference = helpers.GenerateReference("TRF")
+	return
+}
+
+type StockTransferItem struct {
+	ID               uint        `gorm:"primaryKey" json:"id"`
+	StockTransferID  uint        `json:"stock_transfer_id"`
+	ProductID        uint        `json:"product_id"`    // produk di toko asal
+	ToProductID      uint        `json:"to_product_id"` // produk di toko tujuan
+	Quantity         int         `json:"quantity"`
+	ReceivedQuantity *int        `json:"received_quantity"` // kosong sebelum transfer diterima
+	CostPrice        casts.Money `json:"cost_price"`        // harga pokok produk asal saat dispatch
+	DiscrepancyNote  string      `json:"discrepancy_note"`
+
+	Product   *Product `json:"product,omitempty"`
+	ToProduct *Product `json:"to_product,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Discrepancy mengembalikan selisih quantity dikirim dan diterima, positif
+// jika barang yang diterima kurang.
+func (i *StockTransferItem) Discrepancy() int {
+	if i.ReceivedQuantity == nil {
+		return 0
+	}
+	return i.Quantity - *i.ReceivedQuantity
+}
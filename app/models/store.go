@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Store struct {
+	ID      uint    `gorm:"primaryKey" json:"id"`
+	Name    string  `json:"name"`
+	Phone   *string `json:"phone"`
+	Address *string `json:"address"`
+	City    *string `json:"city"`
+	State   *string `json:"state"`
+	Country *string `json:"country"`
+	Zip     *string `json:"zip"`
+
+	DefaultMinStock *int `json:"default_min_stock"` // batas stok minimum produk yang tidak memiliki min_stock sendiri
+	DefaultMaxStock *int `json:"default_max_stock"` // batas stok maksimum produk yang tidak memiliki max_stock sendiri
+
+	Products *[]Product `gorm:"foreignKey:StoreID" json:"products,omitempty"`
+
+	Version   uint           `gorm:"default:1" json:"version"` // optimistic locking, naik setiap update
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at" swaggerignore:"true"`
+}
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+type StoreMember struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	StoreID   uint      `json:"store_id"`
+	MemberID  uint      `json:"member_id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+type StoreUser struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	StoreID   uint      `json:"store_id"`
+	UserID    uint      `json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	User *User `json:"user,omitempty"`
+}
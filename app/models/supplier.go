@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"golang_starter_kit_2025/app/helpers"
+
+	"gorm.io/gorm"
+)
+
+type Supplier struct {
+	ID            uint    `gorm:"primaryKey" json:"id"`
+	Reference     string  `gorm:"unique" json:"reference"`
+	StoreID       uint    `json:"store_id"`
+	Name          string  `json:"name"`
+	ContactPerson *string `json:"contact_person"`
+	Phone         *string `json:"phone"`
+	Email         *string `json:"email"`
+	Address       *string `json:"address"`
+
+	Store *Store `json:"store,omitempty"`
+
+	Version   uint           `gorm:"default:1" json:"version"` // optimistic locking, naik setiap update
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at" swaggerignore:"true"`
+}
+
+// BeforeCreate hook
+func (s *Supplier) BeforeCreate(tx *gorm.DB) (err error) {
+	s.Reference = helpers.GenerateReference("SUP")
+	return
+}
@@ -15,8 +15,9 @@ type User struct {
 	Email     string         `gorm:"type:varchar(100);uniqueIndex" json:"email"`
 	Password  string         `gorm:"type:varchar(255)" json:"password"`
 	JwtToken  string         `gorm:"type:varchar(255)" json:"jwt_token" swaggerignore:"true"`
-	FcmToken  string         `gorm:"type:varchar(255)" json:"fcm_token" swaggerignore:"true"`
+	FcmToken  *string        `gorm:"type:varchar(255)" json:"fcm_token" swaggerignore:"true"`
 	Pin       string         `gorm:"type:varchar(255)" json:"pin"`
+	Version   uint           `gorm:"default:1" json:"version"` // optimistic locking, naik setiap update
 	CreatedAt time.Time      `gorm:"autoCreateTime" json:"created_at" swaggerignore:"true"`
 	UpdatedAt time.Time      `gorm:"autoUpdateTime" json:"updated_at" swaggerignore:"true"`
 	DeletedAt gorm.DeletedAt `json:"deleted_at" swaggerignore:"true"`
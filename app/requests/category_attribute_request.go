@@ -0,0 +1,12 @@
+package requests
+
+type CategoryAttributeRequest struct {
+	ID        uint     `json:"id" form:"id"`
+	Code      string   `json:"code" form:"code" binding:"required,max=64" example:"ukuran"`
+	Name      string   `json:"name" form:"name" binding:"required" example:"Ukuran kemasan"`
+	Type      string   `json:"type" form:"type" binding:"required,oneof=text number option" example:"option" enums:"text,number,option"`
+	Options   []string `json:"options" form:"options" binding:"required_if=Type option,dive,required" example:"5kg,25kg"`
+	Unit      string   `json:"unit" form:"unit" example:"kg"`
+	Required  bool     `json:"required" form:"required" example:"true"`
+	SortOrder int      `json:"sort_order" form:"sort_order" example:"1"`
+}
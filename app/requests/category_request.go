@@ -1,6 +1,31 @@
 package requests
 
+import "golang_starter_kit_2025/app/models"
+
 type CategoryRequest struct {
 	ID       uint   `json:"id,omitempty" form:"id,omitempty"`
+	Version  uint   `json:"version" form:"version" example:"1"`     // wajib saat update, atau melalui header If-Match
+	ParentID *uint  `json:"parent_id" form:"parent_id" example:"1"` // kosong untuk kategori root
 	Category string `json:"category" form:"category" binding:"required" example:"Electronics" validate:"required"`
+	Slug     string `json:"slug" form:"slug" binding:"omitempty,max=255" example:"electronics"` // default dari nama kategori
+}
+
+// NewCategoryRequest membuat dokumen awal JSON merge patch dari kategori tersimpan.
+func NewCategoryRequest(category models.Category) CategoryRequest {
+	return CategoryRequest{
+		ID:       category.ID,
+		ParentID: category.ParentID,
+		Category: category.Category,
+		Slug:     category.Slug,
+	}
+}
+
+type CategoryMoveRequest struct {
+	ParentID *uint `json:"parent_id" form:"parent_id" example:"1"`                         // kosong untuk memindahkan ke root
+	Position *int  `json:"position" form:"position" binding:"omitempty,min=0" example:"0"` // default urutan terakhir
+}
+
+type CategoryReorderRequest struct {
+	ParentID *uint  `json:"parent_id" form:"parent_id" example:"1"` // kosong untuk kategori root
+	IDs      []uint `json:"ids" form:"ids" binding:"required,min=1"`
 }
@@ -1,29 +1,62 @@
 package requests
 
+import "golang_starter_kit_2025/app/casts"
+
 type CostBudgetPlanRequestPut struct {
 	ID           uint   `json:"id" form:"id"`
-	Status       string `json:"status" form:"status" binding:"required" validate:"required" example:"draft"`
+	Version      uint   `json:"version" form:"version" example:"1"` // wajib saat update, atau melalui header If-Match
 	MemberID     uint   `json:"member_id" form:"member_id" binding:"required" validate:"required" example:"1"`
 	StoreID      uint   `json:"store_id" form:"store_id" binding:"required" validate:"required" example:"1"`
-	ApprovedAt   string `json:"approved_at" example:"2021-01-01 00:00:00"`
 	MemberLandID uint   `json:"member_land_id" form:"member_land_id" binding:"required" validate:"required" example:"1"`
 	JenisTanam   string `json:"jenis_tanam" form:"jenis_tanam" binding:"required" validate:"required" example:"Jenis Tanam"`
+	Currency     string `json:"currency" form:"currency" binding:"omitempty,iso4217" example:"IDR"` // default IDR, harus sama dengan mata uang offering
 }
 
+// CostBudgetPlanWizardRequestPut menyimpan satu langkah wizard RAB. Hanya
+// field milik Step yang wajib diisi, langkah sebelumnya diambil dari draft.
 type CostBudgetPlanWizardRequestPut struct {
+	ID           uint                           `json:"id" form:"id" example:"0"` // ID draft, kosongkan untuk memulai wizard baru
+	Step         string                         `json:"step" form:"step" binding:"required,oneof=member land crop items review" example:"member" enums:"member,land,crop,items,review"`
+	MemberID     uint                           `json:"member_id" form:"member_id" binding:"required_if=Step member" example:"1"`
+	MemberLandID uint                           `json:"member_land_id" form:"member_land_id" binding:"required_if=Step land" example:"1"`
+	JenisTanam   string                         `json:"jenis_tanam" form:"jenis_tanam" binding:"required_if=Step crop" example:"Padi"`
+	Items        []CostBudgetPlanItemRequestPut `json:"items" form:"items" binding:"required_if=Step items,dive"`
 }
 
 type CostBudgetPlanItemRequestPut struct {
 	ID                uint    `json:"id" form:"id"`
 	ProductOfferingID uint    `json:"product_offering_id" form:"product_offering_id" binding:"required" validate:"required" example:"1"`
 	PhaseID           uint    `json:"phase_id" form:"phase_id" binding:"required" validate:"required" example:"1"`
-	Quantity          float32 `json:"quantity" form:"quantity" binding:"required" validate:"required" example:"1"`
+	Quantity          float32 `json:"quantity" form:"quantity" binding:"required,gt=0" validate:"required" example:"1"`
 	Description       string  `json:"description" form:"description" binding:"required" validate:"required" example:"Description"`
 }
 
 type CostBudgetPlanDetailHistoryRequest struct {
 	ID          uint    `json:"id" form:"id"`
 	ProductID   uint    `json:"product_id" form:"product_id" binding:"required" validate:"required"`
-	Quantity    float32 `json:"quantity" form:"quantity" binding:"required" validate:"required"`
+	Quantity    float32 `json:"quantity" form:"quantity" binding:"required,gt=0" validate:"required"`
+	IssuedAt    string  `json:"issued_at" form:"issued_at" binding:"omitempty,datetime=2006-01-02" example:"2026-10-19"` // default hari ini
 	Description string  `json:"description" form:"description" binding:"required" validate:"required"`
 }
+
+type CostBudgetPlanFilterRequest struct {
+	FilterRequest
+	MemberID *uint   `form:"member_id" json:"member_id"`
+	StoreID  *uint   `form:"store_id" json:"store_id"`
+	Status   *string `form:"status" json:"status"`
+}
+
+type CostBudgetPlanTransitionRequest struct {
+	Action  string `json:"action" form:"action" binding:"required,oneof=submit approve reject revise disburse close" example:"approve" enums:"submit,approve,reject,revise,disburse,close"`
+	Comment string `json:"comment" form:"comment" binding:"required_if=Action reject" example:"Harga pupuk terlalu tinggi"` // wajib diisi saat reject
+}
+
+type CostBudgetPlanApprovalLevelRequest struct {
+	Level     int         `json:"level" form:"level" binding:"required,gt=0" example:"1"`
+	MinAmount casts.Money `json:"min_amount" form:"min_amount" binding:"gte=0" example:"0"`
+	RoleID    uint        `json:"role_id" form:"role_id" binding:"required" example:"2"`
+}
+
+type CostBudgetPlanApprovalLevelRequestPut struct {
+	Levels []CostBudgetPlanApprovalLevelRequest `json:"levels" form:"levels" binding:"required,dive"`
+}
@@ -0,0 +1,28 @@
+package requests_test
+
+import (
+	"golang_starter_kit_2025/app/requests"
+
+	"github.com/gin-gonic/gin/binding"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CostBudgetPlanItemRequestPut", func() {
+	item := func(quantity float32) requests.CostBudgetPlanItemRequestPut {
+		return requests.CostBudgetPlanItemRequestPut{ProductOfferingID: 1, PhaseID: 1, Quantity: quantity, Description: "Pupuk dasar"}
+	}
+
+	It("should accept a positive quantity", func() {
+		Expect(binding.Validator.ValidateStruct(item(2.5))).To(Succeed())
+	})
+
+	It("should reject a negative quantity", func() {
+		Expect(binding.Validator.ValidateStruct(item(-1))).NotTo(Succeed())
+	})
+
+	It("should reject a negative quantity in wizard items", func() {
+		wizard := requests.CostBudgetPlanWizardRequestPut{Step: "items", Items: []requests.CostBudgetPlanItemRequestPut{item(1), item(-3)}}
+		Expect(binding.Validator.ValidateStruct(wizard)).NotTo(Succeed())
+	})
+})
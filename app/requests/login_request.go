@@ -3,4 +3,5 @@ package requests
 type LoginRequest struct {
 	Email    string `json:"email" binding:"required,email" example:"user@mail.com"`
 	Password string `json:"password" binding:"required" example:"12345678"`
-}
\ No newline at end of file
+	StoreID  uint   `json:"store_id" example:"1"` // toko aktif yang disimpan pada token, opsional
+}
@@ -1,14 +1,26 @@
 package requests
 
+import "golang_starter_kit_2025/app/casts"
+
 type MemberLandRequestPut struct {
-	ID          uint     `form:"id" json:"id"`
-	MemberID    uint     `form:"member_id" json:"member_id"`
-	JenisTanam  []string `form:"jenis_tanam" json:"jenis_tanam" type:"array:string"`
-	JumlahPanen int      `form:"jumlah_panen" json:"jumlah_panen"`
-	LuasLahan   float32  `form:"luas_lahan" json:"luas_lahan"`
-	Alamat      string   `form:"alamat" json:"alamat"`
-	Latitude    float32  `form:"latitude" json:"latitude"`
-	Longitude   float32  `form:"longitude" json:"longitude"`
-	Sertifikat  string   `form:"sertifikat" json:"sertifikat" swaggertype:"string" format:"base64"`
-	Description string   `form:"description" json:"description"`
+	ID          uint              `form:"id" json:"id"`
+	Version     uint              `form:"version" json:"version" example:"1"` // wajib saat mengubah lahan yang sudah ada
+	MemberID    uint              `form:"member_id" json:"member_id"`
+	JenisTanam  []string          `form:"jenis_tanam" json:"jenis_tanam" type:"array:string" binding:"required,min=1"`
+	JumlahPanen int               `form:"jumlah_panen" json:"jumlah_panen" binding:"gte=0" example:"2"`
+	LuasLahan   float32           `form:"luas_lahan" json:"luas_lahan" binding:"gt=0" example:"1.5"`
+	Alamat      string            `form:"alamat" json:"alamat"`
+	Latitude    float32           `form:"latitude" json:"latitude" binding:"gte=-90,lte=90" example:"-5.135399"`
+	Longitude   float32           `form:"longitude" json:"longitude" binding:"gte=-180,lte=180" example:"119.423790"`
+	Boundary    *casts.GeoPolygon `form:"boundary" json:"boundary"`                                          // GeoJSON Polygon, posisi [longitude, latitude]
+	Sertifikat  string            `form:"sertifikat" json:"sertifikat" swaggertype:"string" format:"base64"` // kosongkan untuk mempertahankan sertifikat lama
+	Description string            `form:"description" json:"description"`
+}
+
+type MemberLandFilterRequest struct {
+	FilterRequest
+	Near     *string  `form:"near" json:"near" example:"-5.135399,119.423790"` // titik pusat pencarian "lat,lng"
+	RadiusKm *float64 `form:"radius_km" json:"radius_km" binding:"omitempty,gt=0" example:"10"`
+	BBox     *string  `form:"bbox" json:"bbox" example:"119.3,-5.2,119.5,-5.0"` // "min_lng,min_lat,max_lng,max_lat"
+	MemberID *uint    `form:"member_id" json:"member_id"`
 }
@@ -1,20 +1,37 @@
 package requests
 
+import "golang_starter_kit_2025/app/models"
+
 type MemberRequestPut struct {
 	ID           uint   `json:"id" form:"id"`
+	Version      uint   `json:"version" form:"version" example:"1"` // wajib saat update, atau melalui header If-Match
 	Nama         string `json:"nama" form:"nama" binding:"required" example:"John Doe" validate:"required"`
 	Phone        string `json:"phone" form:"phone" binding:"required" example:"08123456789" validate:"required"`
 	Alamat       string `json:"alamat" form:"alamat" binding:"required" example:"Jl. Raya No. 1" validate:"required"`
-	NIK          string `json:"nik" form:"nik" binding:"required" example:"1234567890123456" validate:"required"`
-	JenisKelamin string `json:"jenis_kelamin" form:"jenis_kelamin" binding:"required" example:"pria" validate:"required"`
-	FotoKTP      string `json:"foto_ktp" form:"foto_ktp" binding:"required" example:"base64" validate:"required"`
+	NIK          string `json:"nik" form:"nik" binding:"required,nik" example:"7371011201900001" validate:"required"`
+	JenisKelamin string `json:"jenis_kelamin" form:"jenis_kelamin" binding:"required" example:"pria" validate:"required" enums:"pria,wanita"`
+	FotoKTP      string `json:"foto_ktp" form:"foto_ktp" binding:"required" example:"base64" validate:"required"` // base64, atau URL foto lama agar tidak diunggah ulang
+}
+
+// NewMemberRequest membuat dokumen awal JSON merge patch dari member tersimpan,
+// foto KTP berisi URL foto lama.
+func NewMemberRequest(member models.Member) MemberRequestPut {
+	return MemberRequestPut{
+		ID:           member.ID,
+		Nama:         member.Nama,
+		Phone:        member.Phone,
+		Alamat:       member.Alamat,
+		NIK:          member.NIK,
+		JenisKelamin: member.JenisKelamin,
+		FotoKTP:      member.FotoKTP,
+	}
 }
 
 type MemberRequestPutLands struct {
-	MemberLands []MemberLandRequestPut `json:"member_lands" form:"member_lands" binding:"required" validate:"required"`
+	MemberLands []MemberLandRequestPut `json:"member_lands" form:"member_lands" binding:"required,dive" validate:"required"`
 }
 
 type MemberRequestCheckNIK struct {
-	NIK     string `json:"nik" form:"nik" binding:"required" example:"1234567890123456" validate:"required"`
+	NIK     string `json:"nik" form:"nik" binding:"required,nik" example:"7371011201900001" validate:"required"`
 	StoreID uint   `json:"store_id" form:"store_id" binding:"required" example:"1" validate:"required"`
 }
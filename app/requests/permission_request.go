@@ -1,7 +1,15 @@
 package requests
 
+import "golang_starter_kit_2025/app/models"
+
 type PermissionRequest struct {
-	ID    uint   `json:"id" form:"id"`
-	Name  string `json:"name" form:"name" binding:"required" example:"Create User" validate:"required"`
-	Group string `json:"group" form:"group" binding:"required" example:"User" validate:"required"`
+	ID      uint   `json:"id" form:"id"`
+	Version uint   `json:"version" form:"version" example:"1"` // wajib saat update, atau melalui header If-Match
+	Name    string `json:"name" form:"name" binding:"required" example:"Create User" validate:"required"`
+	Group   string `json:"group" form:"group" binding:"required" example:"User" validate:"required"`
+}
+
+// NewPermissionRequest membuat dokumen awal JSON merge patch dari permission tersimpan.
+func NewPermissionRequest(permission models.Permission) PermissionRequest {
+	return PermissionRequest{ID: permission.ID, Name: permission.Name, Group: permission.Group}
 }
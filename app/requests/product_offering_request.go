@@ -1,9 +1,33 @@
 package requests
 
+import "golang_starter_kit_2025/app/casts"
+
 type ProductOfferingRequestPut struct {
-	ID          uint   `json:"id" form:"id"`
-	Name        string `json:"name" form:"name" binding:"required" example:"Product Name" validate:"required"`
-	Description string `json:"description" form:"description" binding:"required" example:"Product Description" validate:"required"`
-	Price       float32 `json:"price" form:"price" binding:"required" example:"100000" validate:"required"`
-	Status      string `json:"status" form:"status" binding:"required" example:"active" validate:"required" enums:"active,inactive"`
-}
\ No newline at end of file
+	ID          uint                            `json:"id" form:"id"`
+	Version     uint                            `json:"version" form:"version" example:"1"` // wajib saat update, atau melalui header If-Match
+	Name        string                          `json:"name" form:"name" binding:"required" example:"Product Name" validate:"required"`
+	Description string                          `json:"description" form:"description" binding:"required" example:"Product Description" validate:"required"`
+	Price       casts.Money                     `json:"price" form:"price" binding:"required" example:"100000" validate:"required"` // harga baru berlaku saat disimpan jika berbeda dari harga berlaku
+	Currency    string                          `json:"currency" form:"currency" binding:"omitempty,iso4217" example:"IDR"`         // default IDR
+	Status      string                          `json:"status" form:"status" binding:"required,oneof=active inactive" example:"active" validate:"required" enums:"active,inactive"`
+	Products    []ProductOfferingProductRequest `json:"products" form:"products" binding:"omitempty,dive"`
+}
+
+type ProductOfferingProductRequest struct {
+	ProductID uint    `json:"product_id" form:"product_id" binding:"required" example:"1"`
+	Quantity  float32 `json:"quantity" form:"quantity" binding:"required,gt=0" example:"1"`
+}
+
+type ProductOfferingRequestStatus struct {
+	Status string `json:"status" form:"status" binding:"required,oneof=active inactive" example:"inactive" enums:"active,inactive"`
+}
+
+type ProductOfferingPriceRequest struct {
+	Price         casts.Money `json:"price" form:"price" binding:"required,gt=0" example:"110000"`
+	EffectiveFrom string      `json:"effective_from" form:"effective_from" binding:"required,datetime=2006-01-02 15:04:05" example:"2026-11-01 00:00:00"`
+}
+
+type ProductOfferingFilterRequest struct {
+	FilterRequest
+	Status *string `form:"status" json:"status" enums:"active,inactive"`
+}
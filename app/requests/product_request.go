@@ -1,18 +1,102 @@
 package requests
 
-import "time"
+import (
+	"time"
+
+	"golang_starter_kit_2025/app/casts"
+	"golang_starter_kit_2025/app/models"
+)
 
 type ProductRequest struct {
-	ID          uint      `json:"id,omitempty" form:"id,omitempty"`
-	Reference   string    `json:"reference" form:"reference" example:"PRD001"`
-	StoreID     uint      `json:"store_id" form:"store_id" binding:"required" example:"1"`
-	CategoryID  uint      `json:"category_id" form:"category_id" binding:"required" example:"2"`
-	Name        string    `json:"name" form:"name" binding:"required" example:"Product Name"`
-	Description string    `json:"description" form:"description" example:"A brief description of the product"`
-	Price       float64   `json:"price" form:"price" binding:"required" example:"99.99"`
-	Margin      float64   `json:"margin" form:"margin" example:"10.0"`
-	Stock       int       `json:"stock" form:"stock" example:"100"`
-	Sold        int       `json:"sold" form:"sold" example:"10"`
-	Images      []string  `json:"images" form:"images" type:"array:string"`
-	ReceivedAt  time.Time `json:"received_at" form:"received_at" example:"2023-10-10T00:00:00Z"`
+	ID          uint        `json:"id,omitempty" form:"id,omitempty"`
+	Version     uint        `json:"version" form:"version" example:"1"` // wajib saat update, atau melalui header If-Match
+	Reference   string      `json:"reference" form:"reference" example:"PRD001"`
+	Barcode     string      `json:"barcode" form:"barcode" binding:"omitempty,max=32" example:"8991234567895"` // kosong berarti dibuat otomatis
+	StoreID     uint        `json:"store_id" form:"store_id" binding:"required" example:"1"`
+	CategoryID  uint        `json:"category_id" form:"category_id" binding:"required" example:"2"`
+	Name        string      `json:"name" form:"name" binding:"required" example:"Product Name"`
+	Description *string     `json:"description" form:"description" example:"A brief description of the product"`
+	Price       casts.Money `json:"price" form:"price" binding:"required" example:"99.99"`
+	Margin      casts.Money `json:"margin" form:"margin" example:"10.0"`
+	Currency    string      `json:"currency" form:"currency" binding:"omitempty,iso4217" example:"IDR"` // default IDR
+	Stock       *int        `json:"stock" form:"stock" binding:"omitempty,min=0" example:"100"`         // stok awal, saat update harus sama dengan stok saat ini
+	Images      []string    `json:"images" form:"images" type:"array:string"`
+	ReceivedAt  *time.Time  `json:"received_at" form:"received_at" example:"2023-10-10T00:00:00Z"` // default sekarang saat dibuat, null dikosongkan
+}
+
+// NewProductRequest membuat request dari produk tersimpan sebagai dokumen awal
+// JSON merge patch. Version tidak disalin sehingga client tetap harus mengirim
+// version atau header If-Match, stok juga tidak disalin agar PATCH tanpa stok
+// tidak mengirim ulang stok yang sudah berubah.
+func NewProductRequest(product models.Product) ProductRequest {
+	request := ProductRequest{
+		ID:          product.ID,
+		Reference:   product.Reference,
+		StoreID:     product.StoreID,
+		CategoryID:  product.CategoryID,
+		Name:        product.Name,
+		Description: product.Description,
+		Price:       product.Price,
+		Margin:      product.Margin,
+		Currency:    product.Currency,
+		Images:      product.Images,
+		ReceivedAt:  product.ReceivedAt,
+	}
+	if product.Barcode != nil {
+		request.Barcode = *product.Barcode
+	}
+	return request
+}
+
+type ProductPriceRequest struct {
+	Price         casts.Money `json:"price" form:"price" binding:"required,gt=0" example:"105000"`
+	Margin        casts.Money `json:"margin" form:"margin" binding:"gte=0" example:"10.0"`
+	EffectiveFrom string      `json:"effective_from" form:"effective_from" binding:"required,datetime=2006-01-02 15:04:05" example:"2026-11-01 00:00:00"`
+}
+
+type ProductFilterRequest struct {
+	FilterRequest
+	CategoryID *uint             `form:"category_id" json:"category_id"`
+	ParentID   *uint             `form:"parent_id" json:"parent_id"` // varian dari produk induk
+	Attributes map[string]string `form:"-" json:"-"`                 // diisi dari query attributes[code]=value
+}
+
+type ProductAttributeRequest struct {
+	AttributeID uint   `json:"attribute_id" form:"attribute_id" binding:"required" example:"1"`
+	Value       string `json:"value" form:"value" binding:"required" example:"25kg"`
+}
+
+type ProductAttributesRequest struct {
+	Attributes []ProductAttributeRequest `json:"attributes" form:"attributes" binding:"dive"`
+}
+
+type ProductVariantRequest struct {
+	ID         uint                      `json:"id" form:"id"`
+	Version    uint                      `json:"version" form:"version" example:"1"` // wajib saat update, atau melalui header If-Match
+	SKU        string                    `json:"sku" form:"sku" binding:"required,max=64" example:"NPK-25"`
+	Name       string                    `json:"name" form:"name" example:"Pupuk NPK 25kg"` // default nama induk dan nilai atribut
+	Price      casts.Money               `json:"price" form:"price" binding:"required" example:"350000"`
+	Margin     casts.Money               `json:"margin" form:"margin" example:"10.0"`
+	Stock      int                       `json:"stock" form:"stock" example:"20"` // stok awal, hanya dipakai saat varian dibuat
+	Images     []string                  `json:"images" form:"images" type:"array:string"`
+	Attributes []ProductAttributeRequest `json:"attributes" form:"attributes" binding:"required,min=1,dive"`
+}
+
+type BarcodeImageRequest struct {
+	Symbology string `form:"symbology" binding:"omitempty,oneof=ean13 code128" example:"ean13"` // default mengikuti kode barcode
+	Format    string `form:"format" binding:"omitempty,oneof=png svg" example:"png"`            // default png
+	Width     int    `form:"width" binding:"omitempty,min=150,max=2000" example:"300"`
+	Height    int    `form:"height" binding:"omitempty,min=20,max=2000" example:"100"`
+}
+
+type QRCodeRequest struct {
+	Format string `form:"format" binding:"omitempty,oneof=png svg" example:"png"` // default png
+	Size   int    `form:"size" binding:"omitempty,min=50,max=2000" example:"256"`
+}
+
+type ProductLabelRequest struct {
+	IDs     []uint `form:"ids" binding:"required,min=1,max=500"`
+	Copies  int    `form:"copies" binding:"omitempty,min=1,max=100" example:"1"` // jumlah label per produk
+	Columns int    `form:"columns" binding:"omitempty,min=1,max=6" example:"3"`
+	Rows    int    `form:"rows" binding:"omitempty,min=1,max=20" example:"8"`
 }
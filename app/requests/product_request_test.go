@@ -0,0 +1,59 @@
+package requests_test
+
+import (
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"golang_starter_kit_2025/app/helpers"
+	"golang_starter_kit_2025/app/models"
+	"golang_starter_kit_2025/app/requests"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ProductRequest", func() {
+	var request requests.ProductRequest
+
+	patch := func(body string) error {
+		ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+		ctx.Request = httptest.NewRequest("PATCH", "/", strings.NewReader(body))
+		ctx.Request.Header.Set("Content-Type", helpers.ContentTypeMergePatch)
+		return helpers.BindMergePatch(ctx, &request)
+	}
+
+	BeforeEach(func() {
+		description := "NPK 25kg"
+		receivedAt := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+		request = requests.NewProductRequest(models.Product{
+			ID:          1,
+			StoreID:     1,
+			CategoryID:  2,
+			Name:        "Pupuk NPK",
+			Description: &description,
+			Price:       350000,
+			ReceivedAt:  &receivedAt,
+		})
+	})
+
+	It("should keep absent nullable members", func() {
+		Expect(patch(`{"name":"Pupuk NPK Mutiara"}`)).To(Succeed())
+		Expect(request.Name).To(Equal("Pupuk NPK Mutiara"))
+		Expect(*request.Description).To(Equal("NPK 25kg"))
+		Expect(request.ReceivedAt.Equal(time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC))).To(BeTrue())
+	})
+
+	It("should clear received_at and description sent as null", func() {
+		Expect(patch(`{"received_at":null,"description":null}`)).To(Succeed())
+		Expect(request.ReceivedAt).To(BeNil())
+		Expect(request.Description).To(BeNil())
+	})
+
+	It("should keep an empty description apart from null", func() {
+		Expect(patch(`{"description":""}`)).To(Succeed())
+		Expect(request.Description).NotTo(BeNil())
+		Expect(*request.Description).To(BeEmpty())
+	})
+})
@@ -0,0 +1,39 @@
+package requests
+
+import "golang_starter_kit_2025/app/casts"
+
+type PurchaseOrderRequestPut struct {
+	ID         uint                       `json:"id" form:"id"`
+	Version    uint                       `json:"version" form:"version" example:"1"` // wajib saat update, atau melalui header If-Match
+	StoreID    uint                       `json:"store_id" form:"store_id" binding:"required" example:"1"`
+	SupplierID uint                       `json:"supplier_id" form:"supplier_id" binding:"required" example:"1"`
+	Currency   string                     `json:"currency" form:"currency" binding:"omitempty,iso4217" example:"IDR"` // default IDR
+	ExpectedAt string                     `json:"expected_at" form:"expected_at" binding:"omitempty,datetime=2006-01-02" example:"2026-10-30"`
+	Note       string                     `json:"note" form:"note" example:"Pengiriman ke gudang utama"`
+	Items      []PurchaseOrderItemRequest `json:"items" form:"items" binding:"required,min=1,dive"`
+}
+
+type PurchaseOrderItemRequest struct {
+	ProductID uint        `json:"product_id" form:"product_id" binding:"required" example:"1"`
+	Quantity  int         `json:"quantity" form:"quantity" binding:"required,gt=0" example:"100"`
+	CostPrice casts.Money `json:"cost_price" form:"cost_price" binding:"required,gt=0" example:"85000"`
+}
+
+type PurchaseOrderFilterRequest struct {
+	FilterRequest
+	StoreID    *uint   `form:"store_id" json:"store_id"`
+	SupplierID *uint   `form:"supplier_id" json:"supplier_id"`
+	Status     *string `form:"status" json:"status" enums:"open,partially_received,closed,cancelled"`
+}
+
+type GoodsReceiptRequest struct {
+	ReceivedAt string                    `json:"received_at" form:"received_at" binding:"omitempty,datetime=2006-01-02 15:04:05" example:"2026-10-19 10:00:00"` // default sekarang
+	Note       string                    `json:"note" form:"note" example:"Surat jalan SJ-001"`
+	Items      []GoodsReceiptItemRequest `json:"items" form:"items" binding:"required,min=1,dive"`
+}
+
+type GoodsReceiptItemRequest struct {
+	PurchaseOrderItemID uint        `json:"purchase_order_item_id" form:"purchase_order_item_id" binding:"required" example:"1"`
+	Quantity            int         `json:"quantity" form:"quantity" binding:"required,gt=0" example:"50"`
+	CostPrice           casts.Money `json:"cost_price" form:"cost_price" binding:"gte=0" example:"85000"` // default harga beli pada PO
+}
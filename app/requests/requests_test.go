@@ -0,0 +1,13 @@
+package requests_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestRequestsSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Requests Test Suite")
+}
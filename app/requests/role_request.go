@@ -1,9 +1,17 @@
 package requests
 
+import "golang_starter_kit_2025/app/models"
+
 type RoleRequestPut struct {
-	ID    uint   `json:"id" form:"id"`
-	Name  string `json:"name" form:"name" binding:"required" example:"Admin" validate:"required"`
-	Group string `json:"group" form:"group" binding:"required" example:"User" validate:"required"`
+	ID      uint   `json:"id" form:"id"`
+	Version uint   `json:"version" form:"version" example:"1"` // wajib saat update, atau melalui header If-Match
+	Name    string `json:"name" form:"name" binding:"required" example:"Admin" validate:"required"`
+	Group   string `json:"group" form:"group" binding:"required" example:"User" validate:"required"`
+}
+
+// NewRoleRequest membuat dokumen awal JSON merge patch dari role tersimpan.
+func NewRoleRequest(role models.Role) RoleRequestPut {
+	return RoleRequestPut{ID: role.ID, Name: role.Name, Group: role.Group}
 }
 
 type RoleRequestAssignPermissions struct {
@@ -0,0 +1,45 @@
+package requests
+
+import "golang_starter_kit_2025/app/casts"
+
+type SaleRequest struct {
+	StoreID       uint              `json:"store_id" form:"store_id" binding:"required" example:"1"`
+	MemberID      *uint             `json:"member_id" form:"member_id" example:"1"` // kosong untuk pembeli umum
+	PaymentMethod string            `json:"payment_method" form:"payment_method" binding:"required,oneof=cash transfer qris debit credit" example:"cash" enums:"cash,transfer,qris,debit,credit"`
+	Currency      string            `json:"currency" form:"currency" binding:"omitempty,iso4217" example:"IDR"` // default IDR
+	Discount      casts.Money       `json:"discount" form:"discount" binding:"gte=0" example:"0"`
+	TaxPercent    *float64          `json:"tax_percent" form:"tax_percent" binding:"omitempty,gte=0,lte=100" example:"11"` // default SALE_TAX_PERCENT
+	Paid          casts.Money       `json:"paid" form:"paid" binding:"gte=0" example:"150000"`                             // default sama dengan total
+	Note          string            `json:"note" form:"note" example:"Pembayaran lunas"`
+	Items         []SaleItemRequest `json:"items" form:"items" binding:"required,min=1,dive"`
+}
+
+type SaleItemRequest struct {
+	ProductID uint        `json:"product_id" form:"product_id" binding:"required" example:"1"`
+	Quantity  int         `json:"quantity" form:"quantity" binding:"required,gt=0" example:"2"`
+	Discount  casts.Money `json:"discount" form:"discount" binding:"gte=0" example:"0"`
+}
+
+type SaleVoidRequest struct {
+	Reason string `json:"reason" form:"reason" binding:"required" example:"Salah input transaksi"`
+}
+
+type SaleReturnRequest struct {
+	Reason string                  `json:"reason" form:"reason" binding:"required" example:"Barang rusak"`
+	Items  []SaleReturnItemRequest `json:"items" form:"items" binding:"required,min=1,dive"`
+}
+
+type SaleReturnItemRequest struct {
+	SaleItemID uint `json:"sale_item_id" form:"sale_item_id" binding:"required" example:"1"`
+	Quantity   int  `json:"quantity" form:"quantity" binding:"required,gt=0" example:"1"`
+}
+
+type SaleFilterRequest struct {
+	FilterRequest
+	StoreID       *uint   `form:"store_id" json:"store_id"`
+	MemberID      *uint   `form:"member_id" json:"member_id"`
+	Status        *string `form:"status" json:"status" enums:"completed,partially_returned,returned,voided"`
+	PaymentMethod *string `form:"payment_method" json:"payment_method" enums:"cash,transfer,qris,debit,credit"`
+	From          *string `form:"from" json:"from" binding:"omitempty,datetime=2006-01-02" example:"2026-10-01"`
+	To            *string `form:"to" json:"to" binding:"omitempty,datetime=2006-01-02" example:"2026-10-31"`
+}
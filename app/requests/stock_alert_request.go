@@ -0,0 +1,13 @@
+package requests
+
+type StockLevelRequest struct {
+	MinStock *int `json:"min_stock" form:"min_stock" binding:"omitempty,gte=0" example:"10"` // kosong untuk menghapus batas
+	MaxStock *int `json:"max_stock" form:"max_stock" binding:"omitempty,gte=0" example:"100"`
+}
+
+type StockAlertFilterRequest struct {
+	FilterRequest
+	StoreID   *uint   `form:"store_id" json:"store_id"`
+	ProductID *uint   `form:"product_id" json:"product_id"`
+	Status    *string `form:"status" json:"status" enums:"open,resolved"`
+}
@@ -0,0 +1,8 @@
+package requests
+
+type StockMovementRequest struct {
+	Type              string `json:"type" form:"type" binding:"required,oneof=receipt sale return adjustment transfer" example:"receipt" enums:"receipt,sale,return,adjustment,transfer"`
+	Quantity          int    `json:"quantity" form:"quantity" binding:"required" example:"10"`
+	Reason            string `json:"reason" form:"reason" example:"Barang masuk dari supplier"`
+	ReferenceDocument string `json:"reference_document" form:"reference_document" example:"PO-0001"`
+}
@@ -0,0 +1,31 @@
+package requests
+
+type StockTakeRequest struct {
+	StoreID uint   `json:"store_id" form:"store_id" binding:"required" example:"1"`
+	Note    string `json:"note" form:"note" example:"Opname akhir Oktober"`
+}
+
+type StockTakeFilterRequest struct {
+	FilterRequest
+	StoreID *uint   `form:"store_id" json:"store_id"`
+	Status  *string `form:"status" json:"status" enums:"counting,submitted,approved,cancelled"`
+}
+
+type StockTakeCountRequest struct {
+	Items []StockTakeCountItemRequest `json:"items" form:"items" binding:"required,min=1,dive"`
+}
+
+type StockTakeCountItemRequest struct {
+	ProductID       uint `json:"product_id" form:"product_id" binding:"required" example:"1"`
+	CountedQuantity *int `json:"counted_quantity" form:"counted_quantity" binding:"required,gte=0" example:"48"`
+}
+
+type StockTakeScanRequest struct {
+	ProductID uint   `json:"product_id" form:"product_id" binding:"required_without=Reference" example:"1"`
+	Reference string `json:"reference" form:"reference" binding:"required_without=ProductID" example:"PRD-..."` // referensi produk hasil scan
+	Quantity  int    `json:"quantity" form:"quantity" binding:"omitempty,gt=0" example:"1"`                     // default 1
+}
+
+type StockTakeTransitionRequest struct {
+	Comment string `json:"comment" form:"comment" example:"Hitung ulang rak B"` // wajib saat menolak
+}
@@ -0,0 +1,37 @@
+package requests
+
+type StockTransferRequestPut struct {
+	ID          uint                       `json:"id" form:"id"`
+	Version     uint                       `json:"version" form:"version" example:"1"` // wajib saat update, atau melalui header If-Match
+	FromStoreID uint                       `json:"from_store_id" form:"from_store_id" binding:"required" example:"1"`
+	ToStoreID   uint                       `json:"to_store_id" form:"to_store_id" binding:"required,nefield=FromStoreID" example:"2"`
+	Note        string                     `json:"note" form:"note" example:"Restock cabang"`
+	Items       []StockTransferItemRequest `json:"items" form:"items" binding:"required,min=1,dive"`
+}
+
+type StockTransferItemRequest struct {
+	ProductID   uint `json:"product_id" form:"product_id" binding:"required" example:"1"`
+	ToProductID uint `json:"to_product_id" form:"to_product_id" example:"5"` // default produk dengan nama yang sama di toko tujuan
+	Quantity    int  `json:"quantity" form:"quantity" binding:"required,gt=0" example:"10"`
+}
+
+type StockTransferFilterRequest struct {
+	FilterRequest
+	StoreID *uint   `form:"store_id" json:"store_id"` // toko asal atau tujuan
+	Status  *string `form:"status" json:"status" enums:"draft,in_transit,received,cancelled"`
+}
+
+type StockTransferReceiveRequest struct {
+	Note  string                            `json:"note" form:"note" example:"Diterima oleh kepala gudang"`
+	Items []StockTransferReceiveItemRequest `json:"items" form:"items" binding:"omitempty,dive"` // item yang tidak dicantumkan dianggap diterima penuh
+}
+
+type StockTransferReceiveItemRequest struct {
+	StockTransferItemID uint   `json:"stock_transfer_item_id" form:"stock_transfer_item_id" binding:"required" example:"1"`
+	ReceivedQuantity    *int   `json:"received_quantity" form:"received_quantity" binding:"required,gte=0" example:"9"`
+	DiscrepancyNote     string `json:"discrepancy_note" form:"discrepancy_note" example:"1 karung rusak"` // wajib jika quantity diterima berbeda
+}
+
+type StockInTransitFilterRequest struct {
+	StoreID *uint `form:"store_id" json:"store_id"` // toko tujuan
+}
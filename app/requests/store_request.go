@@ -1,16 +1,37 @@
 package requests
 
+import "golang_starter_kit_2025/app/models"
+
 type StoreRequestPut struct {
-	ID      uint   `json:"id" form:"id"`
-	Name    string `json:"name" form:"name" example:"John Doe"`
-	Phone   string `json:"phone" form:"phone" example:"08123456789"`
-	Address string `json:"address" form:"address" example:"Jl. Raya No. 1"`
-	City    string `json:"city" form:"city" example:"Jakarta"`
-	State   string `json:"state" form:"state" example:"DKI Jakarta"`
-	Country string `json:"country" form:"country" example:"Indonesia"`
-	Zip     string `json:"zip" form:"zip" example:"12345"`
+	ID      uint    `json:"id" form:"id"`
+	Version uint    `json:"version" form:"version" example:"1"` // wajib saat update, atau melalui header If-Match
+	Name    string  `json:"name" form:"name" binding:"required" example:"Toko Tani Makmur" validate:"required"`
+	Phone   *string `json:"phone" form:"phone" example:"08123456789"` // null dikosongkan
+	Address *string `json:"address" form:"address" example:"Jl. Raya No. 1"`
+	City    *string `json:"city" form:"city" example:"Jakarta"`
+	State   *string `json:"state" form:"state" example:"DKI Jakarta"`
+	Country *string `json:"country" form:"country" example:"Indonesia"`
+	Zip     *string `json:"zip" form:"zip" example:"12345"`
+}
+
+// NewStoreRequest membuat dokumen awal JSON merge patch dari toko tersimpan.
+func NewStoreRequest(store models.Store) StoreRequestPut {
+	return StoreRequestPut{
+		ID:      store.ID,
+		Name:    store.Name,
+		Phone:   store.Phone,
+		Address: store.Address,
+		City:    store.City,
+		State:   store.State,
+		Country: store.Country,
+		Zip:     store.Zip,
+	}
 }
 
 type StoreRequestMember struct {
 	MemberID uint `json:"member_id" form:"member_id" binding:"required" validate:"required" example:"1"`
-}
\ No newline at end of file
+}
+
+type StoreRequestUsers struct {
+	UserIDs []uint `json:"user_ids" form:"user_ids" binding:"required" validate:"required"`
+}
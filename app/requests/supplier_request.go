@@ -0,0 +1,32 @@
+package requests
+
+import "golang_starter_kit_2025/app/models"
+
+type SupplierRequestPut struct {
+	ID            uint    `json:"id" form:"id"`
+	Version       uint    `json:"version" form:"version" example:"1"` // wajib saat update, atau melalui header If-Match
+	StoreID       uint    `json:"store_id" form:"store_id" binding:"required" example:"1"`
+	Name          string  `json:"name" form:"name" binding:"required" example:"CV Tani Makmur"`
+	ContactPerson *string `json:"contact_person" form:"contact_person" example:"Budi"` // null dikosongkan
+	Phone         *string `json:"phone" form:"phone" example:"08123456789"`
+	Email         *string `json:"email" form:"email" binding:"omitempty,email" example:"sales@tanimakmur.co.id"`
+	Address       *string `json:"address" form:"address" example:"Jl. Raya No. 1"`
+}
+
+// NewSupplierRequest membuat dokumen awal JSON merge patch dari supplier tersimpan.
+func NewSupplierRequest(supplier models.Supplier) SupplierRequestPut {
+	return SupplierRequestPut{
+		ID:            supplier.ID,
+		StoreID:       supplier.StoreID,
+		Name:          supplier.Name,
+		ContactPerson: supplier.ContactPerson,
+		Phone:         supplier.Phone,
+		Email:         supplier.Email,
+		Address:       supplier.Address,
+	}
+}
+
+type SupplierFilterRequest struct {
+	FilterRequest
+	StoreID *uint `form:"store_id" json:"store_id"`
+}
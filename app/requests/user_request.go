@@ -0,0 +1,24 @@
+package requests
+
+import "golang_starter_kit_2025/app/models"
+
+type UserRequest struct {
+	ID       uint    `json:"id" form:"id"`
+	Version  uint    `json:"version" form:"version" example:"1"` // wajib saat update, atau melalui header If-Match
+	Username string  `json:"username" form:"username" binding:"required,max=100" example:"johndoe"`
+	Email    string  `json:"email" form:"email" binding:"required,email,max=100" example:"john@example.com"`
+	Password *string `json:"password,omitempty" form:"password" example:"secret"` // wajib saat membuat user, kosong berarti tidak diubah
+	Pin      *string `json:"pin,omitempty" form:"pin" example:"123456"`           // kosong berarti tidak diubah
+	FcmToken *string `json:"fcm_token" form:"fcm_token"`                          // null dikosongkan
+}
+
+// NewUserRequest membuat dokumen awal JSON merge patch dari user tersimpan,
+// password dan PIN tidak disalin karena hanya dapat ditulis.
+func NewUserRequest(user models.User) UserRequest {
+	return UserRequest{
+		ID:       user.ID,
+		Username: user.Username,
+		Email:    user.Email,
+		FcmToken: user.FcmToken,
+	}
+}
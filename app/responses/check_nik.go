@@ -1,6 +1,7 @@
 package responses
 
 type CheckNIK struct {
-	IsRegistered bool `json:"is_registered"`
+	IsRegistered        bool `json:"is_registered"`
 	IsRegisteredOnStore bool `json:"is_registered_on_store"`
-}
\ No newline at end of file
+	IsTrashed           bool `json:"is_trashed"` // member dengan NIK ini sudah dihapus, NIK tetap tidak dapat dipakai
+}
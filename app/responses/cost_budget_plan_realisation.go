@@ -0,0 +1,49 @@
+package responses
+
+import "golang_starter_kit_2025/app/casts"
+
+// CostBudgetPlanRealisation adalah perbandingan rencana dan realisasi RAB.
+type CostBudgetPlanRealisation struct {
+	CostBudgetPlanID uint                             `json:"cost_budget_plan_id"`
+	PlannedCost      casts.Money                      `json:"planned_cost"`
+	ActualCost       casts.Money                      `json:"actual_cost"`
+	VarianceCost     casts.Money                      `json:"variance_cost"` // actual - planned
+	Phases           []CostBudgetPlanPhaseRealisation `json:"phases"`
+}
+
+type CostBudgetPlanPhaseRealisation struct {
+	PhaseID      uint                            `json:"phase_id"`
+	PhaseName    string                          `json:"phase_name"`
+	PlannedCost  casts.Money                     `json:"planned_cost"`
+	ActualCost   casts.Money                     `json:"actual_cost"`
+	VarianceCost casts.Money                     `json:"variance_cost"`
+	Items        []CostBudgetPlanItemRealisation `json:"items"`
+}
+
+// CostBudgetPlanItemRealisation membandingkan item RAB dalam satuan offering.
+// ActualQuantity adalah jumlah unit offering yang seluruh produk penyusunnya
+// sudah diberikan, rincian per produk (dalam satuan produk) ada di Products.
+type CostBudgetPlanItemRealisation struct {
+	CostBudgetPlanItemID uint                               `json:"cost_budget_plan_item_id"`
+	ProductOfferingID    uint                               `json:"product_offering_id"`
+	ProductOfferingName  string                             `json:"product_offering_name"`
+	PlannedQuantity      float64                            `json:"planned_quantity"`
+	ActualQuantity       float64                            `json:"actual_quantity"`
+	VarianceQuantity     float64                            `json:"variance_quantity"`
+	PlannedCost          casts.Money                        `json:"planned_cost"`
+	ActualCost           casts.Money                        `json:"actual_cost"`
+	VarianceCost         casts.Money                        `json:"variance_cost"`
+	VariancePercent      *float64                           `json:"variance_percent"` // terhadap biaya rencana, kosong jika biaya rencana 0
+	Products             []CostBudgetPlanProductRealisation `json:"products"`
+}
+
+// CostBudgetPlanProductRealisation membandingkan satu produk penyusun item RAB
+// dalam satuan produk: rencana adalah quantity item dikali quantity produk per
+// unit offering.
+type CostBudgetPlanProductRealisation struct {
+	ProductID        uint    `json:"product_id"`
+	ProductName      string  `json:"product_name"`
+	PlannedQuantity  float64 `json:"planned_quantity"`
+	ActualQuantity   float64 `json:"actual_quantity"`
+	VarianceQuantity float64 `json:"variance_quantity"`
+}
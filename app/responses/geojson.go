@@ -0,0 +1,18 @@
+package responses
+
+// FeatureCollection adalah GeoJSON FeatureCollection (RFC 7946).
+type FeatureCollection struct {
+	Type     string    `json:"type" example:"FeatureCollection"`
+	Features []Feature `json:"features"`
+}
+
+type Feature struct {
+	Type       string         `json:"type" example:"Feature"`
+	Geometry   Geometry       `json:"geometry"`
+	Properties map[string]any `json:"properties"`
+}
+
+type Geometry struct {
+	Type        string `json:"type" example:"Point"`
+	Coordinates any    `json:"coordinates" swaggertype:"array,number"`
+}
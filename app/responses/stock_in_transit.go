@@ -0,0 +1,10 @@
+package responses
+
+// StockInTransit adalah jumlah stok yang sedang dikirim ke suatu produk di
+// toko tujuan dan belum diterima.
+type StockInTransit struct {
+	StoreID   uint   `json:"store_id"`
+	ProductID uint   `json:"product_id"`
+	Name      string `json:"name"`
+	Quantity  int    `json:"quantity"`
+}
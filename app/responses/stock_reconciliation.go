@@ -0,0 +1,7 @@
+package responses
+
+type StockReconciliation struct {
+	ProductID   uint `json:"product_id"`
+	StockBefore int  `json:"stock_before"`
+	StockAfter  int  `json:"stock_after"`
+}
@@ -20,6 +20,18 @@ type AuthService struct {
 	jwt *JwtService
 }
 
+// canAccessStore mengecek apakah user terdaftar di toko storeID atau merupakan admin.
+func (auth *AuthService) canAccessStore(userID uint, storeID uint) bool {
+	if storeID == 0 {
+		return true
+	}
+	store, err := NewStoreService().ResolveContext(userID, storeID)
+	if err != nil {
+		return false
+	}
+	return store.CanAccess(storeID)
+}
+
 func (auth *AuthService) Login(request requests.LoginRequest) (*casts.Token, error) {
 	var user models.User
 	if err := facades.DB.Where("email = ?", request.Email).First(&user).Error; err != nil {
@@ -42,18 +54,22 @@ func (auth *AuthService) Login(request requests.LoginRequest) (*casts.Token, err
 	// 	return "", errors.New("Logout terlebih dahulu")
 	// }
 
+	if !auth.canAccessStore(user.ID, request.StoreID) {
+		return nil, ErrStoreForbidden
+	}
+
 	expires := helpers.GetEnvInt("JWT_EXPIRE_MINUTES", 60)
 	expireAt := time.Now().Add(time.Minute * time.Duration(expires)).Unix()
 	// Generate JWT token
-	tokenString, err := auth.jwt.GenerateToken(casts.NewJwtClaims(user.ID, expireAt))
+	tokenString, err := auth.jwt.GenerateToken(casts.NewStoreJwtClaims(user.ID, request.StoreID, expireAt))
 
 	if err != nil {
 		return nil, err
 	}
 
-	// Update user with the new token
+	// Update user with the new token, UpdateColumn agar version user tidak berubah
 	user.JwtToken = tokenString
-	if err := facades.DB.Save(&user).Error; err != nil {
+	if err := facades.DB.Model(&user).UpdateColumn("jwt_token", tokenString).Error; err != nil {
 		return nil, err
 	}
 
@@ -83,7 +99,7 @@ func (auth *AuthService) Logout(tokenString string) error {
 	}
 
 	user.JwtToken = ""
-	if err := facades.DB.Save(&user).Error; err != nil {
+	if err := facades.DB.Model(&user).UpdateColumn("jwt_token", "").Error; err != nil {
 		return err
 	}
 
@@ -102,6 +118,7 @@ func (auth *AuthService) RefreshToken(tokenString string) (*casts.Token, error)
 
 	claims := token.Claims.(jwt.MapClaims)
 	userId := claims["user_id"]
+	storeID := casts.ParseJwtClaims(claims).StoreID
 
 	// Ambil user dari database
 	var user models.User
@@ -112,11 +129,15 @@ func (auth *AuthService) RefreshToken(tokenString string) (*casts.Token, error)
 	// Generate JWT token
 	expires := helpers.GetEnvInt("JWT_EXPIRE_MINUTES", 60)
 	expireAt := time.Now().Add(time.Minute * time.Duration(expires)).Unix()
-	tokenString, err = auth.jwt.GenerateToken(casts.NewJwtClaims(user.ID, expireAt))
+	// toko aktif tetap dibawa selama user masih terdaftar di toko tersebut
+	if !auth.canAccessStore(user.ID, storeID) {
+		storeID = 0
+	}
+	tokenString, err = auth.jwt.GenerateToken(casts.NewStoreJwtClaims(user.ID, storeID, expireAt))
 
 	// Update user dengan token baru
 	user.JwtToken = tokenString
-	if err := facades.DB.Save(&user).Error; err != nil {
+	if err := facades.DB.Model(&user).UpdateColumn("jwt_token", tokenString).Error; err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"golang_starter_kit_2025/app/models"
+	"golang_starter_kit_2025/app/requests"
+	"golang_starter_kit_2025/facades"
+
+	"gorm.io/gorm"
+)
+
+var ErrInvalidAttribute = errors.New("atribut produk tidak valid")
+
+// CategoryAttributeService mengelola definisi atribut varian per kategori.
+type CategoryAttributeService struct{}
+
+func NewCategoryAttributeService() *CategoryAttributeService {
+	return &CategoryAttributeService{}
+}
+
+func (service *CategoryAttributeService) GetAll(categoryID string) ([]models.CategoryAttribute, error) {
+	var category models.Category
+	if err := facades.DB.Select("id").First(&category, categoryID).Error; err != nil {
+		return nil, err
+	}
+
+	var attributes []models.CategoryAttribute
+	err := facades.DB.Where("category_id = ?", category.ID).Order("sort_order asc, id asc").Find(&attributes).Error
+	return attributes, err
+}
+
+// Put membuat atau mengupdate atribut kategori. Kode atribut unik per kategori.
+func (service *CategoryAttributeService) Put(categoryID string, request requests.CategoryAttributeRequest) (*models.CategoryAttribute, error) {
+	var category models.Category
+	if err := facades.DB.Select("id").First(&category, categoryID).Error; err != nil {
+		return nil, err
+	}
+
+	var duplicate int64
+	if err := facades.DB.Model(&models.CategoryAttribute{}).
+		Where("category_id = ? AND code = ? AND id <> ?", category.ID, request.Code, request.ID).
+		Count(&duplicate).Error; err != nil {
+		return nil, err
+	}
+	if duplicate > 0 {
+		return nil, fmt.Errorf("%w: kode %s sudah digunakan", ErrInvalidAttribute, request.Code)
+	}
+
+	attribute := models.CategoryAttribute{
+		ID:         request.ID,
+		CategoryID: category.ID,
+		Code:       request.Code,
+		Name:       request.Name,
+		Type:       request.Type,
+		Options:    request.Options,
+		Unit:       request.Unit,
+		Required:   request.Required,
+		SortOrder:  request.SortOrder,
+	}
+	if attribute.Type != models.CategoryAttributeOption {
+		attribute.Options = nil
+	}
+
+	if request.ID == 0 {
+		if err := facades.DB.Create(&attribute).Error; err != nil {
+			return nil, err
+		}
+	} else {
+		result := facades.DB.Model(&models.CategoryAttribute{}).
+			Where("id = ? AND category_id = ?", request.ID, category.ID).
+			Select("code", "name", "type", "options", "unit", "required", "sort_order").
+			Updates(&attribute)
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil, gorm.ErrRecordNotFound
+		}
+	}
+
+	if err := facades.DB.First(&attribute, attribute.ID).Error; err != nil {
+		return nil, err
+	}
+	return &attribute, nil
+}
+
+// Delete menghapus atribut kategori beserta nilainya pada seluruh produk.
+func (service *CategoryAttributeService) Delete(categoryID string, id string) error {
+	result := facades.DB.Where("category_id = ?", categoryID).Delete(&models.CategoryAttribute{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
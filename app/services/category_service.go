@@ -1,45 +1,354 @@
 package services
 
 import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang_starter_kit_2025/app/helpers"
 	"golang_starter_kit_2025/app/models"
 	"golang_starter_kit_2025/facades"
 
+	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
+var (
+	ErrInvalidCategory = errors.New("kategori tidak valid")
+	ErrCategoryInUse   = errors.New("kategori masih memiliki produk atau sub kategori")
+)
+
 type CategoryService struct{}
 
 func (*CategoryService) GetAllCategories() ([]models.Category, error) {
 	var categories []models.Category
-	if err := facades.DB.Find(&categories).Error; err != nil {
+	if err := facades.DB.Order("path asc").Find(&categories).Error; err != nil {
 		return nil, err
 	}
 	return categories, nil
 }
 
-func (*CategoryService) GetCategoryByID(id string) (models.Category, error) {
+// GetCategoryByID mencari kategori berdasarkan ID atau slug.
+func (service *CategoryService) GetCategoryByID(id string) (models.Category, error) {
 	var category models.Category
-	if err := facades.DB.First(&category, id).Error; err != nil {
+	query := facades.DB.Preload("Parent").Preload("Children", func(db *gorm.DB) *gorm.DB {
+		return db.Order("sort_order asc, id asc")
+	}).Preload("Attributes", func(db *gorm.DB) *gorm.DB {
+		return db.Order("sort_order asc, id asc")
+	})
+	if _, err := strconv.ParseUint(id, 10, 64); err != nil {
+		query = query.Where("slug = ?", id)
+	} else {
+		query = query.Where("id = ?", id)
+	}
+	if err := query.First(&category).Error; err != nil {
+		return category, err
+	}
+
+	counts, err := service.productCounts()
+	if err != nil {
+		return category, err
+	}
+	category.ProductCount = service.subtreeCount(facades.DB, category.Path, counts)
+	for i := range category.Children {
+		category.Children[i].ProductCount = service.subtreeCount(facades.DB, category.Children[i].Path, counts)
+	}
+	return category, nil
+}
+
+// Tree mengembalikan kategori root beserta seluruh turunannya, jumlah produk
+// setiap node sudah termasuk produk pada kategori turunan.
+func (service *CategoryService) Tree() ([]models.Category, error) {
+	var categories []models.Category
+	if err := facades.DB.Order("depth desc, sort_order asc, id asc").Find(&categories).Error; err != nil {
+		return nil, err
+	}
+	counts, err := service.productCounts()
+	if err != nil {
+		return nil, err
+	}
+
+	// diproses dari kategori terdalam sehingga children dan jumlah produk
+	// sudah lengkap saat node ditempelkan ke parent
+	children := make(map[uint][]models.Category)
+	for _, category := range categories {
+		category.ProductCount = counts[category.ID]
+		category.Children = children[category.ID]
+		for _, child := range category.Children {
+			category.ProductCount += child.ProductCount
+		}
+		var parentID uint
+		if category.ParentID != nil {
+			parentID = *category.ParentID
+		}
+		children[parentID] = append(children[parentID], category)
+	}
+
+	tree := children[0]
+	if tree == nil {
+		tree = []models.Category{}
+	}
+	return tree, nil
+}
+
+// productCounts menghitung jumlah produk langsung per kategori.
+func (*CategoryService) productCounts() (map[uint]int64, error) {
+	var rows []struct {
+		CategoryID uint
+		Total      int64
+	}
+	if err := facades.DB.Model(&models.Product{}).Select("category_id, COUNT(*) AS total").
+		Where("category_id IS NOT NULL").Group("category_id").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	counts := make(map[uint]int64, len(rows))
+	for _, row := range rows {
+		counts[row.CategoryID] = row.Total
+	}
+	return counts, nil
+}
+
+func (*CategoryService) subtreeCount(db *gorm.DB, path string, counts map[uint]int64) int64 {
+	var ids []uint
+	db.Model(&models.Category{}).Where("path LIKE ?", path+"%").Pluck("id", &ids)
+	var total int64
+	for _, id := range ids {
+		total += counts[id]
+	}
+	return total
+}
+
+// uniqueSlug menambahkan akhiran angka jika slug sudah dipakai kategori lain,
+// termasuk kategori yang sudah dihapus karena index unik tetap berlaku.
+func (*CategoryService) uniqueSlug(tx *gorm.DB, slug string, id uint) (string, error) {
+	base := slug
+	for i := 2; ; i++ {
+		var count int64
+		if err := tx.Unscoped().Model(&models.Category{}).Where("slug = ? AND id <> ?", slug, id).Count(&count).Error; err != nil {
+			return "", err
+		}
+		if count == 0 {
+			return slug, nil
+		}
+		slug = fmt.Sprintf("%s-%d", base, i)
+	}
+}
+
+// Menggabungkan Create dan Update dalam satu fungsi PutCategory. Kategori
+// dengan ID diganti seluruhnya, perubahan parent diproses seperti Move.
+func (service *CategoryService) PutCategory(category models.Category) (models.Category, error) {
+	slug := helpers.Slugify(category.Slug)
+	if slug == "" {
+		slug = helpers.Slugify(category.Category)
+	}
+	if slug == "" {
+		return category, fmt.Errorf("%w: slug tidak dapat dibuat dari nama kategori", ErrInvalidCategory)
+	}
+
+	var existing models.Category
+	if category.ID != 0 {
+		if err := facades.DB.Select("id", "parent_id").First(&existing, category.ID).Error; err != nil {
+			return category, err
+		}
+		if category.Version == 0 {
+			return category, ErrVersionRequired
+		}
+	}
+
+	err := facades.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if category.Slug, err = service.uniqueSlug(tx, slug, category.ID); err != nil {
+			return err
+		}
+
+		if existing.ID != 0 {
+			if err := tx.Model(&models.Category{}).Where("id = ?", category.ID).
+				Updates(map[string]interface{}{"category": category.Category, "slug": category.Slug, "version": category.Version}).Error; err != nil {
+				return err
+			}
+			if !sameParent(existing.ParentID, category.ParentID) {
+				return service.move(tx, category.ID, category.ParentID, nil)
+			}
+			return nil
+		}
+
+		parentPath, depth, err := service.parentPath(tx, category.ParentID)
+		if err != nil {
+			return err
+		}
+		var last struct{ Max *int }
+		if err := siblings(tx, category.ParentID).Select("MAX(sort_order) AS max").Scan(&last).Error; err != nil {
+			return err
+		}
+		if last.Max != nil {
+			category.SortOrder = *last.Max + 1
+		}
+		category.Depth = depth
+		if err := tx.Omit(clause.Associations).Create(&category).Error; err != nil {
+			return err
+		}
+		category.Path = parentPath + strconv.FormatUint(uint64(category.ID), 10) + "/"
+		return tx.Model(&models.Category{}).Where("id = ?", category.ID).Update("path", category.Path).Error
+	})
+	if err != nil {
+		return category, err
+	}
+
+	if err := facades.DB.First(&category, category.ID).Error; err != nil {
 		return category, err
 	}
 	return category, nil
 }
 
-// Menggabungkan Create dan Update dalam satu fungsi PutCategory
-func (*CategoryService) PutCategory(category models.Category) (models.Category, error) {
-	if err := facades.DB.Clauses(clause.OnConflict{
-		Columns:   []clause.Column{{Name: "id"}}, // Kolom yang digunakan untuk menentukan konflik
-		DoUpdates: clause.AssignmentColumns([]string{"category", "updated_at"}),
-	}).Create(&category).Error; err != nil {
+// Move memindahkan kategori beserta turunannya ke parent lain dan posisi tertentu.
+func (service *CategoryService) Move(id string, parentID *uint, position *int) (models.Category, error) {
+	var category models.Category
+	if err := facades.DB.First(&category, id).Error; err != nil {
+		return category, err
+	}
+
+	if err := facades.DB.Transaction(func(tx *gorm.DB) error {
+		return service.move(tx, category.ID, parentID, position)
+	}); err != nil {
+		return category, err
+	}
+
+	if err := facades.DB.First(&category, category.ID).Error; err != nil {
 		return category, err
 	}
 	return category, nil
 }
 
+func (service *CategoryService) move(tx *gorm.DB, id uint, parentID *uint, position *int) error {
+	var category models.Category
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&category, id).Error; err != nil {
+		return err
+	}
+
+	parentPath, depth, err := service.parentPath(tx, parentID)
+	if err != nil {
+		return err
+	}
+	// kategori tidak boleh dipindahkan ke dirinya sendiri atau ke turunannya
+	if strings.HasPrefix(parentPath, category.Path) {
+		return fmt.Errorf("%w: kategori tidak dapat dipindahkan ke dalam turunannya sendiri", ErrInvalidCategory)
+	}
+
+	if !sameParent(category.ParentID, parentID) {
+		path := parentPath + strconv.FormatUint(uint64(category.ID), 10) + "/"
+		if err := tx.Model(&models.Category{}).Where("id = ?", category.ID).
+			Updates(map[string]interface{}{"parent_id": parentID}).Error; err != nil {
+			return err
+		}
+		// path dan depth seluruh turunan ikut diganti prefix-nya
+		if err := tx.Unscoped().Model(&models.Category{}).Where("path LIKE ?", category.Path+"%").
+			UpdateColumns(map[string]interface{}{
+				"path":  gorm.Expr("CONCAT(?, SUBSTRING(path, ?))", path, len(category.Path)+1),
+				"depth": gorm.Expr("depth + ?", depth-category.Depth),
+			}).Error; err != nil {
+			return err
+		}
+	}
+
+	var ids []uint
+	if err := siblings(tx, parentID).Where("id <> ?", category.ID).Order("sort_order asc, id asc").Pluck("id", &ids).Error; err != nil {
+		return err
+	}
+	index := len(ids)
+	if position != nil && *position < index {
+		index = *position
+	}
+	ids = append(ids[:index], append([]uint{category.ID}, ids[index:]...)...)
+	return applySortOrder(tx, ids)
+}
+
+// Reorder mengurutkan ulang kategori dengan parent yang sama sesuai urutan ids.
+func (service *CategoryService) Reorder(parentID *uint, ids []uint) error {
+	return facades.DB.Transaction(func(tx *gorm.DB) error {
+		var current []uint
+		if err := siblings(tx, parentID).Order("sort_order asc, id asc").Pluck("id", &current).Error; err != nil {
+			return err
+		}
+		known := make(map[uint]bool, len(current))
+		for _, id := range current {
+			known[id] = true
+		}
+
+		ordered := make([]uint, 0, len(current))
+		for _, id := range ids {
+			if !known[id] {
+				return fmt.Errorf("%w: kategori %d bukan sub kategori dari parent yang sama", ErrInvalidCategory, id)
+			}
+			ordered = append(ordered, id)
+			delete(known, id)
+		}
+		// kategori yang tidak disebutkan tetap berada di akhir dengan urutan lama
+		for _, id := range current {
+			if known[id] {
+				ordered = append(ordered, id)
+			}
+		}
+		return applySortOrder(tx, ordered)
+	})
+}
+
+func (*CategoryService) parentPath(tx *gorm.DB, parentID *uint) (string, int, error) {
+	if parentID == nil {
+		return "/", 0, nil
+	}
+	var parent models.Category
+	if err := tx.Select("id", "path", "depth").First(&parent, *parentID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", 0, fmt.Errorf("%w: parent kategori %d tidak ditemukan", ErrInvalidCategory, *parentID)
+		}
+		return "", 0, err
+	}
+	return parent.Path, parent.Depth + 1, nil
+}
+
+func siblings(tx *gorm.DB, parentID *uint) *gorm.DB {
+	query := tx.Model(&models.Category{})
+	if parentID == nil {
+		return query.Where("parent_id IS NULL")
+	}
+	return query.Where("parent_id = ?", *parentID)
+}
+
+func applySortOrder(tx *gorm.DB, ids []uint) error {
+	for i, id := range ids {
+		if err := tx.Model(&models.Category{}).Where("id = ?", id).UpdateColumn("sort_order", i).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sameParent(a *uint, b *uint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// DeleteCategory menolak penghapusan kategori yang masih memiliki produk atau
+// sub kategori, soft delete tidak memicu ON DELETE SET NULL pada products.
 func (*CategoryService) DeleteCategory(id string) error {
 	var category models.Category
 	if err := facades.DB.First(&category, id).Error; err != nil {
 		return err
 	}
+
+	var children int64
+	if err := facades.DB.Model(&models.Category{}).Where("parent_id = ?", category.ID).Count(&children).Error; err != nil {
+		return err
+	}
+	var products int64
+	if err := facades.DB.Model(&models.Product{}).Where("category_id = ?", category.ID).Count(&products).Error; err != nil {
+		return err
+	}
+	if children > 0 || products > 0 {
+		return fmt.Errorf("%w: %d produk, %d sub kategori", ErrCategoryInUse, products, children)
+	}
 	return facades.DB.Delete(&category).Error
 }
@@ -0,0 +1,339 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"golang_starter_kit_2025/app/casts"
+	"golang_starter_kit_2025/app/models"
+	"golang_starter_kit_2025/app/requests"
+	"golang_starter_kit_2025/app/responses"
+	"golang_starter_kit_2025/facades"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrPlanNotRealisable = errors.New("realisasi hanya dapat dicatat untuk RAB yang sudah disetujui")
+
+// CostBudgetPlanRealisationService mencatat realisasi item RAB dan
+// membandingkannya dengan rencana.
+type CostBudgetPlanRealisationService struct {
+	planService          *CostBudgetPlanService
+	offeringService      *ProductOfferingService
+	stockMovementService *StockMovementService
+}
+
+func NewCostBudgetPlanRealisationService() *CostBudgetPlanRealisationService {
+	return &CostBudgetPlanRealisationService{
+		planService:          NewCostBudgetPlanService(),
+		offeringService:      NewProductOfferingService(),
+		stockMovementService: NewStockMovementService(),
+	}
+}
+
+// findItem mengembalikan RAB dan item itemID di dalamnya.
+func (service *CostBudgetPlanRealisationService) findItem(store casts.StoreContext, planID string, itemID string) (models.CostBudgetPlan, models.CostBudgetPlanItem, error) {
+	plan, err := service.planService.find(facades.DB, store, planID)
+	if err != nil {
+		return plan, models.CostBudgetPlanItem{}, err
+	}
+
+	id, err := strconv.ParseUint(itemID, 10, 64)
+	if err != nil {
+		return plan, models.CostBudgetPlanItem{}, gorm.ErrRecordNotFound
+	}
+	for _, item := range plan.Items {
+		if item.ID == uint(id) {
+			return plan, item, nil
+		}
+	}
+	return plan, models.CostBudgetPlanItem{}, gorm.ErrRecordNotFound
+}
+
+// PutHistory mencatat atau mengoreksi realisasi item RAB. Produk harus milik
+// toko RAB dan termasuk produk penyusun offering item, biaya dihitung dari
+// harga offering yang berlaku pada tanggal realisasi. Produk yang diberikan
+// mengurangi stok, koreksi hanya memposting selisihnya ke ledger.
+func (service *CostBudgetPlanRealisationService) PutHistory(store casts.StoreContext, planID string, itemID string, request requests.CostBudgetPlanDetailHistoryRequest) (*models.CostBudgetPlanItemHistory, error) {
+	plan, item, err := service.findItem(store, planID, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if plan.Status != models.CostBudgetPlanApproved && plan.Status != models.CostBudgetPlanDisbursed {
+		return nil, ErrPlanNotRealisable
+	}
+
+	var product models.Product
+	if err := facades.DB.Where("store_id = ?", plan.StoreID).First(&product, request.ProductID).Error; err != nil {
+		return nil, err
+	}
+
+	var linked int64
+	if err := facades.DB.Model(&models.ProductOfferingProduct{}).
+		Where("product_offering_id = ? AND product_id = ?", item.ProductOfferingID, product.ID).
+		Count(&linked).Error; err != nil {
+		return nil, err
+	}
+	if linked == 0 {
+		return nil, fmt.Errorf("%w: produk %s bukan penyusun product offering item", ErrInvalidPlan, product.Name)
+	}
+
+	var offering models.ProductOffering
+	if err := facades.DB.First(&offering, item.ProductOfferingID).Error; err != nil {
+		return nil, err
+	}
+	if casts.NormalizeCurrency(offering.Currency) != casts.NormalizeCurrency(plan.Currency) {
+		return nil, fmt.Errorf("%w: %s", ErrCurrencyMismatch, offering.Name)
+	}
+
+	issuedAt := time.Now()
+	if request.IssuedAt != "" {
+		if issuedAt, err = time.ParseInLocation(time.DateOnly, request.IssuedAt, time.Local); err != nil {
+			return nil, err
+		}
+	}
+
+	price, err := service.offeringService.EffectivePrice(facades.DB, offering, issuedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	quantity := roundQuantity(float64(request.Quantity))
+	if quantity != math.Trunc(quantity) {
+		return nil, fmt.Errorf("%w: quantity realisasi %s harus bilangan bulat karena mengurangi stok", ErrInvalidPlan, product.Name)
+	}
+	history := models.CostBudgetPlanItemHistory{
+		ID:                   request.ID,
+		CostBudgetPlanItemID: item.ID,
+		ProductID:            product.ID,
+		Quantity:             quantity,
+		Price:                price,
+		Amount:               price.Mul(quantity),
+		IssuedAt:             issuedAt,
+		Description:          request.Description,
+		ActorID:              &store.UserID,
+	}
+
+	if err := facades.DB.Transaction(func(tx *gorm.DB) error {
+		// stok keluar sebesar quantity baru, quantity lama dikembalikan saat koreksi
+		products := []uint{product.ID}
+		issued := map[uint]int{product.ID: -int(quantity)}
+		if request.ID == 0 {
+			if err := tx.Create(&history).Error; err != nil {
+				return err
+			}
+		} else {
+			var previous models.CostBudgetPlanItemHistory
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("cost_budget_plan_item_id = ?", item.ID).
+				First(&previous, request.ID).Error; err != nil {
+				return err
+			}
+			if previous.ProductID != product.ID {
+				products = append(products, previous.ProductID)
+			}
+			issued[previous.ProductID] += int(previous.Quantity)
+
+			if err := tx.Model(&previous).Updates(&history).Error; err != nil {
+				return err
+			}
+		}
+
+		for _, productID := range products {
+			if issued[productID] == 0 {
+				continue
+			}
+			movement := models.StockMovement{
+				ProductID:         productID,
+				Type:              models.StockMovementAdjustment,
+				Quantity:          issued[productID],
+				Reason:            "Realisasi RAB",
+				ActorID:           &store.UserID,
+				ReferenceDocument: plan.Reference,
+			}
+			if err := service.stockMovementService.Record(tx, &movement); err != nil {
+				if errors.Is(err, ErrInsufficientStock) {
+					return fmt.Errorf("%w: %s", err, product.Name)
+				}
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := facades.DB.Preload("Product").First(&history, history.ID).Error; err != nil {
+		return nil, err
+	}
+	return &history, nil
+}
+
+func (service *CostBudgetPlanRealisationService) Histories(store casts.StoreContext, planID string, itemID string) ([]models.CostBudgetPlanItemHistory, error) {
+	_, item, err := service.findItem(store, planID, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	var histories []models.CostBudgetPlanItemHistory
+	err = facades.DB.Preload("Product").Preload("Actor").
+		Where("cost_budget_plan_item_id = ?", item.ID).
+		Order("issued_at asc, id asc").
+		Find(&histories).Error
+	return histories, err
+}
+
+// realisedProduct adalah total realisasi satu produk pada satu item RAB,
+// quantity dalam satuan produk.
+type realisedProduct struct {
+	CostBudgetPlanItemID uint
+	ProductID            uint
+	ProductName          string
+	Quantity             float64
+	Amount               casts.Money
+}
+
+// realiseProducts membandingkan realisasi item per produk penyusun offering
+// dalam satuan produk, lalu menghitung jumlah unit offering yang seluruh produk
+// penyusunnya sudah diberikan. Produk yang sudah tidak menjadi penyusun
+// offering tetap dilaporkan dengan rencana 0.
+func realiseProducts(item models.CostBudgetPlanItem, components []models.ProductOfferingProduct, actuals []realisedProduct) ([]responses.CostBudgetPlanProductRealisation, float64, casts.Money) {
+	var cost casts.Money
+	actualByProduct := make(map[uint]realisedProduct, len(actuals))
+	for _, actual := range actuals {
+		actualByProduct[actual.ProductID] = actual
+		cost = cost.Add(actual.Amount)
+	}
+
+	rows := make([]responses.CostBudgetPlanProductRealisation, 0, len(components)+len(actuals))
+	units := math.Inf(1)
+	for _, component := range components {
+		actual := actualByProduct[component.ProductID]
+		delete(actualByProduct, component.ProductID)
+
+		row := responses.CostBudgetPlanProductRealisation{
+			ProductID:       component.ProductID,
+			PlannedQuantity: roundQuantity(item.Quantity * component.Quantity),
+			ActualQuantity:  roundQuantity(actual.Quantity),
+		}
+		if component.Product != nil {
+			row.ProductName = component.Product.Name
+		}
+		row.VarianceQuantity = roundQuantity(row.ActualQuantity - row.PlannedQuantity)
+		rows = append(rows, row)
+
+		if component.Quantity > 0 {
+			units = math.Min(units, actual.Quantity/component.Quantity)
+		}
+	}
+	for _, actual := range actuals {
+		if _, ok := actualByProduct[actual.ProductID]; !ok {
+			continue
+		}
+		rows = append(rows, responses.CostBudgetPlanProductRealisation{
+			ProductID:        actual.ProductID,
+			ProductName:      actual.ProductName,
+			ActualQuantity:   roundQuantity(actual.Quantity),
+			VarianceQuantity: roundQuantity(actual.Quantity),
+		})
+	}
+
+	if math.IsInf(units, 1) {
+		units = 0
+	}
+	// dibulatkan ke bawah agar unit yang belum lengkap tidak terhitung,
+	// epsilon menahan galat float seperti 0.3 / 0.1 = 2.9999...
+	return rows, math.Floor(units*100+1e-9) / 100, cost
+}
+
+// Breakdown membandingkan quantity dan biaya rencana dengan realisasi per
+// item dan per fase. Quantity item dibandingkan dalam satuan offering, rincian
+// per produk dalam satuan produk. Variance bernilai positif jika realisasi
+// melebihi rencana.
+func (service *CostBudgetPlanRealisationService) Breakdown(store casts.StoreContext, planID string) (*responses.CostBudgetPlanRealisation, error) {
+	plan, err := service.planService.find(facades.DB, store, planID)
+	if err != nil {
+		return nil, err
+	}
+
+	var actuals []realisedProduct
+	if err := facades.DB.Model(&models.CostBudgetPlanItemHistory{}).
+		Select("cost_budget_plan_item_histories.cost_budget_plan_item_id, cost_budget_plan_item_histories.product_id, products.name AS product_name, SUM(cost_budget_plan_item_histories.quantity) AS quantity, SUM(cost_budget_plan_item_histories.amount) AS amount").
+		Joins("join cost_budget_plan_items on cost_budget_plan_items.id = cost_budget_plan_item_histories.cost_budget_plan_item_id").
+		Joins("join products on products.id = cost_budget_plan_item_histories.product_id").
+		Where("cost_budget_plan_items.cost_budget_plan_id = ?", plan.ID).
+		Group("cost_budget_plan_item_histories.cost_budget_plan_item_id, cost_budget_plan_item_histories.product_id, products.name").
+		Scan(&actuals).Error; err != nil {
+		return nil, err
+	}
+	actualsByItem := make(map[uint][]realisedProduct, len(actuals))
+	for _, actual := range actuals {
+		actualsByItem[actual.CostBudgetPlanItemID] = append(actualsByItem[actual.CostBudgetPlanItemID], actual)
+	}
+
+	offeringIDs := make([]uint, 0, len(plan.Items))
+	for _, item := range plan.Items {
+		offeringIDs = append(offeringIDs, item.ProductOfferingID)
+	}
+	var components []models.ProductOfferingProduct
+	if len(offeringIDs) > 0 {
+		if err := facades.DB.Preload("Product").
+			Where("product_offering_id IN ?", offeringIDs).
+			Order("id asc").
+			Find(&components).Error; err != nil {
+			return nil, err
+		}
+	}
+	componentsByOffering := make(map[uint][]models.ProductOfferingProduct, len(offeringIDs))
+	for _, component := range components {
+		componentsByOffering[component.ProductOfferingID] = append(componentsByOffering[component.ProductOfferingID], component)
+	}
+
+	result := responses.CostBudgetPlanRealisation{CostBudgetPlanID: plan.ID, Phases: []responses.CostBudgetPlanPhaseRealisation{}}
+	phaseIndex := map[uint]int{}
+	// plan.Items sudah berurutan berdasarkan fase, lihat CostBudgetPlanService.find
+	for _, item := range plan.Items {
+		row := responses.CostBudgetPlanItemRealisation{
+			CostBudgetPlanItemID: item.ID,
+			ProductOfferingID:    item.ProductOfferingID,
+			PlannedQuantity:      item.Quantity,
+			PlannedCost:          item.Subtotal,
+		}
+		if item.ProductOffering != nil {
+			row.ProductOfferingName = item.ProductOffering.Name
+		}
+		row.Products, row.ActualQuantity, row.ActualCost = realiseProducts(item, componentsByOffering[item.ProductOfferingID], actualsByItem[item.ID])
+		row.VarianceQuantity = roundQuantity(row.ActualQuantity - row.PlannedQuantity)
+		row.VarianceCost = row.ActualCost.Sub(row.PlannedCost)
+		if row.PlannedCost != 0 {
+			percent := row.VarianceCost.Percent(row.PlannedCost)
+			row.VariancePercent = &percent
+		}
+
+		i, ok := phaseIndex[item.PhaseID]
+		if !ok {
+			phase := responses.CostBudgetPlanPhaseRealisation{PhaseID: item.PhaseID, Items: []responses.CostBudgetPlanItemRealisation{}}
+			if item.Phase != nil {
+				phase.PhaseName = item.Phase.Name
+			}
+			result.Phases = append(result.Phases, phase)
+			i = len(result.Phases) - 1
+			phaseIndex[item.PhaseID] = i
+		}
+		phase := &result.Phases[i]
+		phase.Items = append(phase.Items, row)
+		phase.PlannedCost = phase.PlannedCost.Add(row.PlannedCost)
+		phase.ActualCost = phase.ActualCost.Add(row.ActualCost)
+		phase.VarianceCost = phase.ActualCost.Sub(phase.PlannedCost)
+
+		result.PlannedCost = result.PlannedCost.Add(row.PlannedCost)
+		result.ActualCost = result.ActualCost.Add(row.ActualCost)
+	}
+	result.VarianceCost = result.ActualCost.Sub(result.PlannedCost)
+
+	return &result, nil
+}
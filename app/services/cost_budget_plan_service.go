@@ -0,0 +1,342 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"golang_starter_kit_2025/app/casts"
+	"golang_starter_kit_2025/app/models"
+	"golang_starter_kit_2025/app/models/scopes"
+	"golang_starter_kit_2025/app/requests"
+	"golang_starter_kit_2025/facades"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrPlanNotEditable  = errors.New("RAB tidak dapat diubah pada status saat ini")
+	ErrInvalidPlan      = errors.New("data RAB tidak valid")
+	ErrOfferingInactive = errors.New("product offering tidak aktif")
+	ErrCurrencyMismatch = errors.New("mata uang tidak sesuai dengan mata uang RAB")
+)
+
+type CostBudgetPlanService struct {
+	memberService   *MemberService
+	offeringService *ProductOfferingService
+}
+
+func NewCostBudgetPlanService() *CostBudgetPlanService {
+	return &CostBudgetPlanService{
+		memberService:   NewMemberService(),
+		offeringService: NewProductOfferingService(),
+	}
+}
+
+func (service *CostBudgetPlanService) GetAll(store casts.StoreContext, filters requests.CostBudgetPlanFilterRequest) ([]models.CostBudgetPlan, int64, error) {
+	var plans []models.CostBudgetPlan
+	var total int64
+
+	query := facades.DB.Model(&models.CostBudgetPlan{}).Scopes(scopes.StoreScope(store, "store_id"))
+	if filters.MemberID != nil {
+		query = query.Where("member_id = ?", *filters.MemberID)
+	}
+	if filters.StoreID != nil {
+		query = query.Where("store_id = ?", *filters.StoreID)
+	}
+	if filters.Status != nil {
+		query = query.Where("status = ?", *filters.Status)
+	}
+	if filters.Search != nil {
+		query = query.Where("reference LIKE ? OR jenis_tanam LIKE ?", "%"+*filters.Search+"%", "%"+*filters.Search+"%")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if filters.OrderBy != nil {
+		direction := "asc"
+		if filters.OrderDirection != nil {
+			direction = *filters.OrderDirection
+		}
+		query = query.Order(*filters.OrderBy + " " + direction)
+	} else {
+		query = query.Order("updated_at desc")
+	}
+
+	if err := query.Preload("Member").Preload("Store").Scopes(scopes.Paginate(filters.FilterRequest)).Find(&plans).Error; err != nil {
+		return nil, 0, err
+	}
+	return plans, total, nil
+}
+
+func (service *CostBudgetPlanService) GetByID(store casts.StoreContext, id string) (models.CostBudgetPlan, error) {
+	return service.find(facades.DB, store, id)
+}
+
+func (service *CostBudgetPlanService) find(db *gorm.DB, store casts.StoreContext, id any) (models.CostBudgetPlan, error) {
+	var plan models.CostBudgetPlan
+	err := db.Scopes(scopes.StoreScope(store, "store_id")).
+		Preload("Member").
+		Preload("Store").
+		Preload("MemberLand").
+		Preload("Items", func(db *gorm.DB) *gorm.DB {
+			return db.Order("(SELECT sort_order FROM phases WHERE phases.id = cost_budget_plan_items.phase_id) asc, id asc")
+		}).
+		Preload("Items.Phase").
+		Preload("Items.ProductOffering").
+		First(&plan, id).Error
+	return plan, err
+}
+
+// validate memastikan member terdaftar di toko RAB, lahan milik member dan
+// jenis tanam termasuk tanaman di lahan tersebut.
+func (service *CostBudgetPlanService) validate(store casts.StoreContext, request requests.CostBudgetPlanRequestPut) error {
+	if err := service.validateMember(store, request.StoreID, request.MemberID); err != nil {
+		return err
+	}
+	land, err := service.validateLand(request.MemberID, request.MemberLandID)
+	if err != nil {
+		return err
+	}
+	return service.validateCrop(land, request.JenisTanam)
+}
+
+func (service *CostBudgetPlanService) validateMember(store casts.StoreContext, storeID uint, memberID uint) error {
+	if !store.CanAccess(storeID) {
+		return ErrStoreForbidden
+	}
+
+	if _, err := service.memberService.find(store, memberID); err != nil {
+		return err
+	}
+
+	var registered int64
+	if err := facades.DB.Model(&models.StoreMember{}).
+		Where("store_id = ? AND member_id = ?", storeID, memberID).
+		Count(&registered).Error; err != nil {
+		return err
+	}
+	if registered == 0 {
+		return fmt.Errorf("%w: member tidak terdaftar di toko", ErrInvalidPlan)
+	}
+	return nil
+}
+
+func (service *CostBudgetPlanService) validateLand(memberID uint, landID uint) (models.MemberLand, error) {
+	var land models.MemberLand
+	if err := facades.DB.Where("member_id = ?", memberID).First(&land, landID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return land, fmt.Errorf("%w: lahan bukan milik member", ErrInvalidPlan)
+		}
+		return land, err
+	}
+	return land, nil
+}
+
+func (service *CostBudgetPlanService) validateCrop(land models.MemberLand, jenisTanam string) error {
+	if !slices.Contains(land.JenisTanam, jenisTanam) {
+		return fmt.Errorf("%w: jenis tanam %q tidak terdaftar di lahan", ErrInvalidPlan, jenisTanam)
+	}
+	return nil
+}
+
+func (service *CostBudgetPlanService) Put(store casts.StoreContext, request requests.CostBudgetPlanRequestPut) (*models.CostBudgetPlan, error) {
+	if request.ID != 0 {
+		existing, err := service.find(facades.DB, store, request.ID)
+		if err != nil {
+			return nil, err
+		}
+		if !existing.Editable() {
+			return nil, ErrPlanNotEditable
+		}
+		if request.Version == 0 {
+			return nil, ErrVersionRequired
+		}
+		// harga item tersimpan dalam mata uang RAB
+		if request.Currency != "" && len(existing.Items) > 0 &&
+			casts.NormalizeCurrency(request.Currency) != casts.NormalizeCurrency(existing.Currency) {
+			return nil, ErrCurrencyMismatch
+		}
+	}
+
+	if err := service.validate(store, request); err != nil {
+		return nil, err
+	}
+
+	plan := models.CostBudgetPlan{
+		ID:           request.ID,
+		MemberID:     request.MemberID,
+		StoreID:      request.StoreID,
+		MemberLandID: request.MemberLandID,
+		JenisTanam:   request.JenisTanam,
+		Version:      request.Version,
+	}
+	if request.Currency != "" {
+		plan.Currency = casts.NormalizeCurrency(request.Currency)
+	}
+
+	if request.ID == 0 {
+		// status selanjutnya hanya berubah melalui CostBudgetPlanWorkflowService
+		plan.Status = models.CostBudgetPlanDraft
+		plan.CreatedBy = &store.UserID
+		if err := facades.DB.Create(&plan).Error; err != nil {
+			return nil, err
+		}
+	} else {
+		if err := facades.DB.Model(&models.CostBudgetPlan{}).Where("id = ?", request.ID).Updates(&plan).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := service.find(facades.DB, store, plan.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (service *CostBudgetPlanService) Delete(store casts.StoreContext, id string) error {
+	plan, err := service.find(facades.DB, store, id)
+	if err != nil {
+		return err
+	}
+	if !plan.Editable() {
+		return ErrPlanNotEditable
+	}
+	return facades.DB.Delete(&plan).Error
+}
+
+// PutItem membuat atau mengupdate item RAB. Harga diambil dari product
+// offering yang aktif lalu total RAB dihitung ulang.
+func (service *CostBudgetPlanService) PutItem(store casts.StoreContext, planID string, request requests.CostBudgetPlanItemRequestPut) (*models.CostBudgetPlan, error) {
+	plan, err := service.find(facades.DB, store, planID)
+	if err != nil {
+		return nil, err
+	}
+	if !plan.Editable() {
+		return nil, ErrPlanNotEditable
+	}
+
+	if err := facades.DB.Transaction(func(tx *gorm.DB) error {
+		item, err := service.buildItem(tx, plan, request)
+		if err != nil {
+			return err
+		}
+
+		if request.ID == 0 {
+			if err := tx.Create(&item).Error; err != nil {
+				return err
+			}
+		} else {
+			result := tx.Model(&models.CostBudgetPlanItem{}).
+				Where("id = ? AND cost_budget_plan_id = ?", request.ID, plan.ID).
+				Updates(&item)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return gorm.ErrRecordNotFound
+			}
+		}
+
+		return service.recalculate(tx, plan.ID)
+	}); err != nil {
+		return nil, err
+	}
+
+	result, err := service.find(facades.DB, store, plan.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (service *CostBudgetPlanService) DeleteItem(store casts.StoreContext, planID string, itemID string) (*models.CostBudgetPlan, error) {
+	plan, err := service.find(facades.DB, store, planID)
+	if err != nil {
+		return nil, err
+	}
+	if !plan.Editable() {
+		return nil, ErrPlanNotEditable
+	}
+
+	if err := facades.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ? AND cost_budget_plan_id = ?", itemID, plan.ID).Delete(&models.CostBudgetPlanItem{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return service.recalculate(tx, plan.ID)
+	}); err != nil {
+		return nil, err
+	}
+
+	result, err := service.find(facades.DB, store, plan.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// buildItem menyusun item RAB dari request. Harga diambil dari harga product
+// offering yang berlaku saat ini, offering harus berstatus aktif dan memakai
+// mata uang yang sama dengan RAB.
+func (service *CostBudgetPlanService) buildItem(tx *gorm.DB, plan models.CostBudgetPlan, request requests.CostBudgetPlanItemRequestPut) (models.CostBudgetPlanItem, error) {
+	var offering models.ProductOffering
+	if err := tx.First(&offering, request.ProductOfferingID).Error; err != nil {
+		return models.CostBudgetPlanItem{}, err
+	}
+	if offering.Status != models.ProductOfferingActive {
+		return models.CostBudgetPlanItem{}, fmt.Errorf("%w: %s", ErrOfferingInactive, offering.Name)
+	}
+	if casts.NormalizeCurrency(offering.Currency) != casts.NormalizeCurrency(plan.Currency) {
+		return models.CostBudgetPlanItem{}, fmt.Errorf("%w: %s", ErrCurrencyMismatch, offering.Name)
+	}
+
+	if err := tx.First(&models.Phase{}, request.PhaseID).Error; err != nil {
+		return models.CostBudgetPlanItem{}, err
+	}
+
+	price, err := service.offeringService.EffectivePrice(tx, offering, time.Now())
+	if err != nil {
+		return models.CostBudgetPlanItem{}, err
+	}
+
+	quantity := roundQuantity(float64(request.Quantity))
+	return models.CostBudgetPlanItem{
+		ID:                request.ID,
+		CostBudgetPlanID:  plan.ID,
+		ProductOfferingID: offering.ID,
+		PhaseID:           request.PhaseID,
+		Quantity:          quantity,
+		Price:             price,
+		Subtotal:          price.Mul(quantity),
+		Description:       request.Description,
+	}, nil
+}
+
+// recalculate menghitung ulang total RAB dari subtotal seluruh item.
+func (service *CostBudgetPlanService) recalculate(tx *gorm.DB, planID uint) error {
+	return tx.Model(&models.CostBudgetPlan{}).Where("id = ?", planID).
+		UpdateColumn("total", tx.Model(&models.CostBudgetPlanItem{}).
+			Select("COALESCE(SUM(subtotal), 0)").
+			Where("cost_budget_plan_id = ?", planID)).Error
+}
+
+func (service *CostBudgetPlanService) GetPhases() ([]models.Phase, error) {
+	var phases []models.Phase
+	err := facades.DB.Order("sort_order asc, id asc").Find(&phases).Error
+	return phases, err
+}
+
+// roundQuantity membulatkan kuantitas ke 2 angka desimal sesuai kolom DECIMAL.
+// Nominal uang memakai casts.Money.
+func roundQuantity(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,282 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"golang_starter_kit_2025/app/casts"
+	"golang_starter_kit_2025/app/helpers"
+	"golang_starter_kit_2025/app/models"
+	"golang_starter_kit_2025/app/requests"
+	"golang_starter_kit_2025/facades"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrWizardExpired = errors.New("draft RAB sudah kedaluwarsa")
+	ErrWizardStep    = errors.New("langkah sebelumnya belum diisi")
+)
+
+// CostBudgetPlanWizardService menyimpan wizard RAB per langkah
+// (member → land → crop → items → review) dan memfinalisasinya menjadi RAB.
+type CostBudgetPlanWizardService struct {
+	planService *CostBudgetPlanService
+}
+
+func NewCostBudgetPlanWizardService() *CostBudgetPlanWizardService {
+	return &CostBudgetPlanWizardService{
+		planService: NewCostBudgetPlanService(),
+	}
+}
+
+// ttl adalah masa berlaku draft sejak terakhir disimpan, diatur lewat env
+// COST_BUDGET_PLAN_WIZARD_TTL_HOURS.
+func (service *CostBudgetPlanWizardService) ttl() time.Duration {
+	return time.Duration(helpers.GetEnvInt("COST_BUDGET_PLAN_WIZARD_TTL_HOURS", 72)) * time.Hour
+}
+
+// GetAll mengembalikan draft milik user yang belum kedaluwarsa sehingga wizard
+// dapat dilanjutkan dari perangkat mana pun.
+func (service *CostBudgetPlanWizardService) GetAll(store casts.StoreContext) ([]models.CostBudgetPlanWizard, error) {
+	var wizards []models.CostBudgetPlanWizard
+	err := facades.DB.Where("user_id = ? AND expires_at > ?", store.UserID, time.Now()).
+		Order("updated_at desc").
+		Find(&wizards).Error
+	return wizards, err
+}
+
+func (service *CostBudgetPlanWizardService) GetByID(store casts.StoreContext, id string) (models.CostBudgetPlanWizard, error) {
+	return service.find(facades.DB, store, id)
+}
+
+func (service *CostBudgetPlanWizardService) find(db *gorm.DB, store casts.StoreContext, id any) (models.CostBudgetPlanWizard, error) {
+	var wizard models.CostBudgetPlanWizard
+	if err := db.Where("user_id = ?", store.UserID).First(&wizard, id).Error; err != nil {
+		return wizard, err
+	}
+	if wizard.Expired() {
+		return wizard, ErrWizardExpired
+	}
+	return wizard, nil
+}
+
+// wizardStore mengembalikan konteks toko draft. Draft divalidasi terhadap
+// tokonya sendiri, bukan toko aktif yang dapat berganti selama wizard dibuka,
+// selama user masih boleh mengakses toko tersebut.
+func (service *CostBudgetPlanWizardService) wizardStore(store casts.StoreContext, wizard models.CostBudgetPlanWizard) (casts.StoreContext, error) {
+	if !store.CanAccess(wizard.StoreID) {
+		return store, ErrStoreForbidden
+	}
+	store.StoreID = wizard.StoreID
+	return store, nil
+}
+
+// Put memvalidasi dan menyimpan satu langkah wizard. Langkah hanya dapat
+// diisi jika langkah sebelumnya sudah tersimpan. Mengganti member atau lahan
+// mengosongkan isian langkah yang bergantung padanya.
+func (service *CostBudgetPlanWizardService) Put(store casts.StoreContext, request requests.CostBudgetPlanWizardRequestPut) (*models.CostBudgetPlanWizard, error) {
+	wizard := models.CostBudgetPlanWizard{UserID: store.UserID, StoreID: store.StoreID}
+	completed := -1
+	if request.ID != 0 {
+		existing, err := service.find(facades.DB, store, request.ID)
+		if err != nil {
+			return nil, err
+		}
+		wizard = existing
+		completed = models.StepIndex(wizard.Step)
+	} else if store.StoreID == 0 {
+		return nil, fmt.Errorf("%w: pilih toko aktif terlebih dahulu", ErrInvalidPlan)
+	}
+
+	planStore, err := service.wizardStore(store, wizard)
+	if err != nil {
+		return nil, err
+	}
+
+	step := models.StepIndex(request.Step)
+	if step > completed+1 {
+		return nil, fmt.Errorf("%w: %s", ErrWizardStep, models.CostBudgetPlanSteps[completed+1])
+	}
+
+	data := &wizard.Data
+	reset := false
+	switch request.Step {
+	case models.CostBudgetPlanStepMember:
+		if err := service.planService.validateMember(planStore, wizard.StoreID, request.MemberID); err != nil {
+			return nil, err
+		}
+		if data.MemberID != request.MemberID {
+			data.MemberLandID, data.JenisTanam = 0, ""
+			reset = completed > step
+		}
+		data.MemberID = request.MemberID
+
+	case models.CostBudgetPlanStepLand:
+		if _, err := service.planService.validateLand(data.MemberID, request.MemberLandID); err != nil {
+			return nil, err
+		}
+		if data.MemberLandID != request.MemberLandID {
+			data.JenisTanam = ""
+			reset = completed > step
+		}
+		data.MemberLandID = request.MemberLandID
+
+	case models.CostBudgetPlanStepCrop:
+		land, err := service.planService.validateLand(data.MemberID, data.MemberLandID)
+		if err != nil {
+			return nil, err
+		}
+		if err := service.planService.validateCrop(land, request.JenisTanam); err != nil {
+			return nil, err
+		}
+		data.JenisTanam = request.JenisTanam
+
+	case models.CostBudgetPlanStepItems:
+		if err := service.validateItems(request.Items); err != nil {
+			return nil, err
+		}
+		data.Items = make([]models.CostBudgetPlanWizardItem, 0, len(request.Items))
+		for _, item := range request.Items {
+			data.Items = append(data.Items, models.CostBudgetPlanWizardItem{
+				ProductOfferingID: item.ProductOfferingID,
+				PhaseID:           item.PhaseID,
+				Quantity:          item.Quantity,
+				Description:       item.Description,
+			})
+		}
+
+	case models.CostBudgetPlanStepReview:
+		if err := service.planService.validate(planStore, service.planRequest(wizard)); err != nil {
+			return nil, err
+		}
+		if err := service.validateItems(service.itemRequests(wizard)); err != nil {
+			return nil, err
+		}
+	}
+
+	if step > completed || reset {
+		wizard.Step = request.Step
+	}
+	wizard.ExpiresAt = time.Now().Add(service.ttl())
+
+	if err := facades.DB.Save(&wizard).Error; err != nil {
+		return nil, err
+	}
+	return &wizard, nil
+}
+
+func (service *CostBudgetPlanWizardService) validateItems(items []requests.CostBudgetPlanItemRequestPut) error {
+	if len(items) == 0 {
+		return fmt.Errorf("%w: RAB belum memiliki item", ErrInvalidPlan)
+	}
+	for _, item := range items {
+		if _, err := service.planService.buildItem(facades.DB, models.CostBudgetPlan{}, item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (service *CostBudgetPlanWizardService) planRequest(wizard models.CostBudgetPlanWizard) requests.CostBudgetPlanRequestPut {
+	return requests.CostBudgetPlanRequestPut{
+		MemberID:     wizard.Data.MemberID,
+		StoreID:      wizard.StoreID,
+		MemberLandID: wizard.Data.MemberLandID,
+		JenisTanam:   wizard.Data.JenisTanam,
+	}
+}
+
+func (service *CostBudgetPlanWizardService) itemRequests(wizard models.CostBudgetPlanWizard) []requests.CostBudgetPlanItemRequestPut {
+	items := make([]requests.CostBudgetPlanItemRequestPut, 0, len(wizard.Data.Items))
+	for _, item := range wizard.Data.Items {
+		items = append(items, requests.CostBudgetPlanItemRequestPut{
+			ProductOfferingID: item.ProductOfferingID,
+			PhaseID:           item.PhaseID,
+			Quantity:          item.Quantity,
+			Description:       item.Description,
+		})
+	}
+	return items
+}
+
+// Finalize membuat RAB beserta item dari draft yang sudah sampai langkah
+// review, lalu menghapus draft, dalam satu transaksi. Draft dikunci sehingga
+// finalisasi ganda dari dua perangkat hanya menghasilkan satu RAB. Draft
+// divalidasi dan RAB dibaca ulang pada toko draft, lihat wizardStore.
+func (service *CostBudgetPlanWizardService) Finalize(store casts.StoreContext, id string) (*models.CostBudgetPlan, error) {
+	var result models.CostBudgetPlan
+	if err := facades.DB.Transaction(func(tx *gorm.DB) error {
+		wizard, err := service.find(tx.Clauses(clause.Locking{Strength: "UPDATE"}), store, id)
+		if err != nil {
+			return err
+		}
+		if wizard.Step != models.CostBudgetPlanStepReview {
+			return fmt.Errorf("%w: %s", ErrWizardStep, models.CostBudgetPlanStepReview)
+		}
+
+		planStore, err := service.wizardStore(store, wizard)
+		if err != nil {
+			return err
+		}
+		request := service.planRequest(wizard)
+		if err := service.planService.validate(planStore, request); err != nil {
+			return err
+		}
+
+		plan := models.CostBudgetPlan{
+			MemberID:     request.MemberID,
+			StoreID:      request.StoreID,
+			MemberLandID: request.MemberLandID,
+			JenisTanam:   request.JenisTanam,
+			Status:       models.CostBudgetPlanDraft,
+			CreatedBy:    &store.UserID,
+		}
+		if err := tx.Create(&plan).Error; err != nil {
+			return err
+		}
+
+		for _, itemRequest := range service.itemRequests(wizard) {
+			item, err := service.planService.buildItem(tx, plan, itemRequest)
+			if err != nil {
+				return err
+			}
+			if err := tx.Create(&item).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := service.planService.recalculate(tx, plan.ID); err != nil {
+			return err
+		}
+		if err := tx.Delete(&wizard).Error; err != nil {
+			return err
+		}
+
+		result, err = service.planService.find(tx, planStore, plan.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (service *CostBudgetPlanWizardService) Delete(store casts.StoreContext, id string) error {
+	result := facades.DB.Where("user_id = ?", store.UserID).Delete(&models.CostBudgetPlanWizard{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// Purge menghapus seluruh draft yang sudah kedaluwarsa.
+func (service *CostBudgetPlanWizardService) Purge() (int64, error) {
+	result := facades.DB.Where("expires_at <= ?", time.Now()).Delete(&models.CostBudgetPlanWizard{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,378 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"golang_starter_kit_2025/app/casts"
+	"golang_starter_kit_2025/app/models"
+	"golang_starter_kit_2025/app/models/scopes"
+	"golang_starter_kit_2025/app/requests"
+	"golang_starter_kit_2025/facades"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrPermissionDenied = errors.New("anda tidak memiliki izin untuk aksi ini")
+	ErrCommentRequired  = errors.New("komentar wajib diisi saat menolak RAB")
+)
+
+const NotificationCostBudgetPlan = "cost_budget_plan"
+
+// CostBudgetPlanWorkflowService menjalankan state machine approval RAB,
+// lihat models.CostBudgetPlanTransitionRules.
+type CostBudgetPlanWorkflowService struct {
+	planService         *CostBudgetPlanService
+	userService         UserService
+	notificationService *NotificationService
+}
+
+func NewCostBudgetPlanWorkflowService() *CostBudgetPlanWorkflowService {
+	return &CostBudgetPlanWorkflowService{
+		planService:         NewCostBudgetPlanService(),
+		userService:         UserService{},
+		notificationService: NewNotificationService(),
+	}
+}
+
+func (service *CostBudgetPlanWorkflowService) can(store casts.StoreContext, permission string) (bool, error) {
+	if store.IsAdmin {
+		return true, nil
+	}
+	return service.userService.HasPermission(store.UserID, permission)
+}
+
+// Transition menjalankan aksi pada RAB. Baris RAB dikunci selama transisi
+// sehingga dua approver tidak dapat menyetujui level yang sama bersamaan.
+func (service *CostBudgetPlanWorkflowService) Transition(store casts.StoreContext, id string, request requests.CostBudgetPlanTransitionRequest) (*models.CostBudgetPlan, error) {
+	if request.Action == models.CostBudgetPlanActionReject && request.Comment == "" {
+		return nil, ErrCommentRequired
+	}
+
+	var planID uint
+	if err := facades.DB.Transaction(func(tx *gorm.DB) error {
+		var plan models.CostBudgetPlan
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Scopes(scopes.StoreScope(store, "store_id")).
+			First(&plan, id).Error; err != nil {
+			return err
+		}
+		planID = plan.ID
+
+		rule, err := plan.Rule(request.Action)
+		if err != nil {
+			return err
+		}
+
+		allowed, err := service.can(store, rule.Permission)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return ErrPermissionDenied
+		}
+
+		transition := models.CostBudgetPlanTransition{
+			CostBudgetPlanID: plan.ID,
+			Action:           request.Action,
+			FromStatus:       plan.Status,
+			ToStatus:         rule.To,
+			ActorID:          &store.UserID,
+			Comment:          request.Comment,
+		}
+		updates := map[string]any{"status": rule.To}
+		now := time.Now()
+		var notify []uint
+		var title string
+
+		switch request.Action {
+		case models.CostBudgetPlanActionSubmit:
+			var items int64
+			if err := tx.Model(&models.CostBudgetPlanItem{}).Where("cost_budget_plan_id = ?", plan.ID).Count(&items).Error; err != nil {
+				return err
+			}
+			if items == 0 {
+				return fmt.Errorf("%w: RAB belum memiliki item", ErrInvalidPlan)
+			}
+			// level approval disalin ke RAB agar perubahan konfigurasi tidak
+			// memengaruhi RAB yang sedang diproses
+			steps, err := service.levels(tx, plan)
+			if err != nil {
+				return err
+			}
+			updates["approval_level"] = 0
+			updates["approval_steps"] = steps
+			updates["submitted_at"] = now
+			if notify, err = service.approvers(tx, plan, steps, 1); err != nil {
+				return err
+			}
+			title = "RAB menunggu persetujuan"
+
+		case models.CostBudgetPlanActionApprove:
+			steps := plan.ApprovalSteps
+			if len(steps) == 0 {
+				// RAB yang diajukan sebelum level approval disalin ke RAB
+				if steps, err = service.levels(tx, plan); err != nil {
+					return err
+				}
+			}
+			next := plan.ApprovalLevel + 1
+			transition.Level = next
+			if err := service.checkApprover(tx, store, plan, steps, next); err != nil {
+				return err
+			}
+
+			updates["approval_level"] = next
+			if next < len(steps) {
+				// masih ada level berikutnya, status tetap submitted
+				transition.ToStatus = models.CostBudgetPlanSubmitted
+				updates["status"] = models.CostBudgetPlanSubmitted
+				if notify, err = service.approvers(tx, plan, steps, next+1); err != nil {
+					return err
+				}
+				title = "RAB menunggu persetujuan"
+			} else {
+				updates["approved_at"] = now
+				notify = service.creator(plan)
+				title = "RAB disetujui"
+			}
+
+		case models.CostBudgetPlanActionReject:
+			transition.Level = plan.ApprovalLevel + 1
+			updates["approval_level"] = 0
+			notify = service.creator(plan)
+			title = "RAB ditolak"
+
+		case models.CostBudgetPlanActionRevise:
+			updates["submitted_at"] = nil
+
+		case models.CostBudgetPlanActionDisburse:
+			updates["disbursed_at"] = now
+			notify = service.creator(plan)
+			title = "RAB dicairkan"
+
+		case models.CostBudgetPlanActionClose:
+			updates["closed_at"] = now
+		}
+
+		if err := tx.Model(&plan).Updates(updates).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&transition).Error; err != nil {
+			return err
+		}
+
+		return service.notificationService.Send(tx, notify, models.Notification{
+			Type:  NotificationCostBudgetPlan,
+			Title: title,
+			Body:  fmt.Sprintf("RAB %s (%s) berstatus %s", plan.Reference, plan.JenisTanam, transition.ToStatus),
+			Data: map[string]any{
+				"cost_budget_plan_id": plan.ID,
+				"reference":           plan.Reference,
+				"action":              request.Action,
+				"status":              transition.ToStatus,
+			},
+		})
+	}); err != nil {
+		return nil, err
+	}
+
+	result, err := service.planService.find(facades.DB, store, planID)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// levels mengembalikan level approval yang berlaku untuk RAB: konfigurasi toko
+// RAB jika ada, selain itu konfigurasi default, yang MinAmount-nya tidak
+// melebihi total RAB. Tanpa konfigurasi, RAB cukup disetujui satu kali oleh
+// user dengan permission approve.
+func (service *CostBudgetPlanWorkflowService) levels(tx *gorm.DB, plan models.CostBudgetPlan) (models.CostBudgetPlanApprovalSteps, error) {
+	var configured int64
+	if err := tx.Model(&models.CostBudgetPlanApprovalLevel{}).Where("store_id = ?", plan.StoreID).Count(&configured).Error; err != nil {
+		return nil, err
+	}
+
+	query := tx.Where("min_amount <= ?", plan.Total).Order("level asc")
+	if configured > 0 {
+		query = query.Where("store_id = ?", plan.StoreID)
+	} else {
+		query = query.Where("store_id IS NULL")
+	}
+
+	var levels []models.CostBudgetPlanApprovalLevel
+	if err := query.Find(&levels).Error; err != nil {
+		return nil, err
+	}
+	if len(levels) == 0 {
+		return models.CostBudgetPlanApprovalSteps{{Level: 1}}, nil
+	}
+
+	steps := make(models.CostBudgetPlanApprovalSteps, 0, len(levels))
+	for _, level := range levels {
+		steps = append(steps, models.CostBudgetPlanApprovalStep{Level: level.Level, MinAmount: level.MinAmount, RoleID: level.RoleID})
+	}
+	return steps, nil
+}
+
+// checkApprover memastikan user bukan pembuat RAB, memiliki role level
+// approval ke-next dan belum menyetujui level sebelumnya untuk pengajuan yang
+// sama.
+func (service *CostBudgetPlanWorkflowService) checkApprover(tx *gorm.DB, store casts.StoreContext, plan models.CostBudgetPlan, steps models.CostBudgetPlanApprovalSteps, next int) error {
+	if next > len(steps) {
+		return models.ErrInvalidTransition
+	}
+	level := steps[next-1]
+
+	if plan.CreatedBy != nil && *plan.CreatedBy == store.UserID {
+		return fmt.Errorf("%w: pembuat RAB tidak dapat menyetujui RAB sendiri", ErrPermissionDenied)
+	}
+
+	if level.RoleID != 0 && !store.IsAdmin {
+		var count int64
+		if err := tx.Model(&models.UserHasRole{}).
+			Where("user_id = ? AND role_id = ?", store.UserID, level.RoleID).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return fmt.Errorf("%w: bukan approver level %d", ErrPermissionDenied, next)
+		}
+	}
+
+	if next > 1 && plan.SubmittedAt != nil {
+		var approved int64
+		if err := tx.Model(&models.CostBudgetPlanTransition{}).
+			Where("cost_budget_plan_id = ? AND action = ? AND actor_id = ? AND created_at >= ?",
+				plan.ID, models.CostBudgetPlanActionApprove, store.UserID, *plan.SubmittedAt).
+			Count(&approved).Error; err != nil {
+			return err
+		}
+		if approved > 0 {
+			return fmt.Errorf("%w: anda sudah menyetujui level sebelumnya", ErrPermissionDenied)
+		}
+	}
+
+	return nil
+}
+
+// approvers mengembalikan user di toko RAB yang menjadi approver level,
+// selain pembuat RAB. Level tanpa role disetujui user dengan permission approve.
+func (service *CostBudgetPlanWorkflowService) approvers(tx *gorm.DB, plan models.CostBudgetPlan, steps models.CostBudgetPlanApprovalSteps, level int) ([]uint, error) {
+	if level > len(steps) {
+		return nil, nil
+	}
+
+	query := tx.Model(&models.StoreUser{}).Where("store_users.store_id = ?", plan.StoreID)
+	if roleID := steps[level-1].RoleID; roleID != 0 {
+		query = query.Joins("join user_has_roles on user_has_roles.user_id = store_users.user_id").
+			Where("user_has_roles.role_id = ?", roleID)
+	} else {
+		permission := tx.Model(&models.Permission{}).Select("id").Where("name = ?", models.PermissionCostBudgetPlanApprove)
+		viaRoles := tx.Model(&models.UserHasRole{}).
+			Select("user_has_roles.user_id").
+			Joins("join role_has_permissions on role_has_permissions.role_id = user_has_roles.role_id").
+			Where("role_has_permissions.permission_id IN (?)", permission)
+		direct := tx.Model(&models.UserHasPermissions{}).Select("user_id").Where("permission_id IN (?)", permission)
+		query = query.Where("store_users.user_id IN (?) OR store_users.user_id IN (?)", viaRoles, direct)
+	}
+	if plan.CreatedBy != nil {
+		query = query.Where("store_users.user_id <> ?", *plan.CreatedBy)
+	}
+
+	var userIDs []uint
+	err := query.Distinct().Pluck("store_users.user_id", &userIDs).Error
+	return userIDs, err
+}
+
+func (service *CostBudgetPlanWorkflowService) creator(plan models.CostBudgetPlan) []uint {
+	if plan.CreatedBy == nil {
+		return nil
+	}
+	return []uint{*plan.CreatedBy}
+}
+
+func (service *CostBudgetPlanWorkflowService) History(store casts.StoreContext, id string) ([]models.CostBudgetPlanTransition, error) {
+	plan, err := service.planService.find(facades.DB, store, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var transitions []models.CostBudgetPlanTransition
+	err = facades.DB.Preload("Actor").
+		Where("cost_budget_plan_id = ?", plan.ID).
+		Order("created_at asc, id asc").
+		Find(&transitions).Error
+	return transitions, err
+}
+
+func (service *CostBudgetPlanWorkflowService) GetApprovalLevels(store casts.StoreContext, storeID uint) ([]models.CostBudgetPlanApprovalLevel, error) {
+	if !store.CanAccess(storeID) {
+		return nil, ErrStoreForbidden
+	}
+
+	var levels []models.CostBudgetPlanApprovalLevel
+	err := facades.DB.Preload("Role").Where("store_id = ?", storeID).Order("level asc").Find(&levels).Error
+	return levels, err
+}
+
+// PutApprovalLevels mengganti seluruh level approval toko storeID.
+// Daftar kosong menghapus konfigurasi toko sehingga level default berlaku.
+func (service *CostBudgetPlanWorkflowService) PutApprovalLevels(store casts.StoreContext, storeID uint, request requests.CostBudgetPlanApprovalLevelRequestPut) ([]models.CostBudgetPlanApprovalLevel, error) {
+	if !store.IsAdmin {
+		return nil, ErrStoreForbidden
+	}
+
+	seen := make(map[int]bool, len(request.Levels))
+	roleIDs := make([]uint, 0, len(request.Levels))
+	for _, item := range request.Levels {
+		if seen[item.Level] {
+			return nil, fmt.Errorf("%w: level %d duplikat", ErrInvalidPlan, item.Level)
+		}
+		// level tanpa role dapat disetujui siapa pun dan tidak memiliki penerima notifikasi
+		if item.RoleID == 0 {
+			return nil, fmt.Errorf("%w: role_id level %d wajib diisi", ErrInvalidPlan, item.Level)
+		}
+		seen[item.Level] = true
+		roleIDs = append(roleIDs, item.RoleID)
+	}
+	if len(roleIDs) > 0 {
+		var roles int64
+		if err := facades.DB.Model(&models.Role{}).Where("id IN ?", roleIDs).Distinct("id").Count(&roles).Error; err != nil {
+			return nil, err
+		}
+		if int(roles) != len(slices.Compact(slices.Sorted(slices.Values(roleIDs)))) {
+			return nil, fmt.Errorf("%w: role approval tidak ditemukan", ErrInvalidPlan)
+		}
+	}
+
+	if err := facades.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&models.Store{}, storeID).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("store_id = ?", storeID).Delete(&models.CostBudgetPlanApprovalLevel{}).Error; err != nil {
+			return err
+		}
+		for _, item := range request.Levels {
+			level := models.CostBudgetPlanApprovalLevel{
+				StoreID:   &storeID,
+				Level:     item.Level,
+				MinAmount: item.MinAmount,
+				RoleID:    item.RoleID,
+			}
+			if err := tx.Create(&level).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return service.GetApprovalLevels(store, storeID)
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"os"
 	"path/filepath"
 
 	"golang_starter_kit_2025/app/helpers"
@@ -36,3 +37,11 @@ func (service FileService) StoreBase64File(base64 string, key string, path strin
 
 	return &fileName, nil
 }
+
+// DeleteFiles menghapus file yang sudah disimpan, misalnya gambar baru saat
+// transaksi penyimpanan data gagal. File yang sudah tidak ada diabaikan.
+func (service FileService) DeleteFiles(path string, fileNames []string) {
+	for _, fileName := range fileNames {
+		_ = os.Remove(helpers.StoragePath() + filepath.Join(path, fileName))
+	}
+}
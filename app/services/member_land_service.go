@@ -0,0 +1,317 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"golang_starter_kit_2025/app/casts"
+	"golang_starter_kit_2025/app/helpers"
+	"golang_starter_kit_2025/app/models"
+	"golang_starter_kit_2025/app/models/scopes"
+	"golang_starter_kit_2025/app/requests"
+	"golang_starter_kit_2025/app/responses"
+	"golang_starter_kit_2025/facades"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrLandAreaMismatch = errors.New("luas lahan tidak sesuai dengan luas boundary")
+	ErrInvalidGeoFilter = errors.New("filter lokasi tidak valid")
+	ErrInvalidBoundary  = errors.New("boundary lahan tidak valid")
+)
+
+// defaultRadiusKm dipakai jika filter near dikirim tanpa radius_km.
+const defaultRadiusKm = 10.0
+
+type MemberLandService struct {
+	memberService *MemberService
+	fileService   FileService
+}
+
+func NewMemberLandService() *MemberLandService {
+	return &MemberLandService{
+		memberService: NewMemberService(),
+		fileService:   FileService{},
+	}
+}
+
+func (service *MemberLandService) GetByMember(store casts.StoreContext, memberID string) ([]models.MemberLand, error) {
+	member, err := service.memberService.find(store, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	var lands []models.MemberLand
+	if err := facades.DB.Where("member_id = ?", member.ID).Order("id asc").Find(&lands).Error; err != nil {
+		return nil, err
+	}
+	return lands, nil
+}
+
+// PutLands menyinkronkan lahan member dengan request: lahan dengan ID diupdate,
+// lahan tanpa ID dibuat, dan lahan lama yang tidak ada di request dihapus.
+func (service *MemberLandService) PutLands(store casts.StoreContext, memberID string, request requests.MemberRequestPutLands) ([]models.MemberLand, error) {
+	member, err := service.memberService.find(store, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	var uploaded []string
+	if err := facades.DB.Transaction(func(tx *gorm.DB) error {
+		var existing []uint
+		if err := tx.Model(&models.MemberLand{}).Where("member_id = ?", member.ID).Pluck("id", &existing).Error; err != nil {
+			return err
+		}
+		owned := make(map[uint]bool, len(existing))
+		for _, id := range existing {
+			owned[id] = true
+		}
+
+		keep := make([]uint, 0, len(request.MemberLands))
+		for _, item := range request.MemberLands {
+			if item.ID != 0 && !owned[item.ID] {
+				return fmt.Errorf("lahan %d bukan milik member: %w", item.ID, gorm.ErrRecordNotFound)
+			}
+			if item.ID != 0 && item.Version == 0 {
+				return fmt.Errorf("%w: lahan %d", ErrVersionRequired, item.ID)
+			}
+
+			land := models.MemberLand{
+				ID:          item.ID,
+				MemberID:    member.ID,
+				JenisTanam:  item.JenisTanam,
+				JumlahPanen: item.JumlahPanen,
+				LuasLahan:   float64(item.LuasLahan),
+				Alamat:      item.Alamat,
+				Latitude:    float64(item.Latitude),
+				Longitude:   float64(item.Longitude),
+				Description: item.Description,
+				Version:     item.Version,
+			}
+
+			if item.Boundary != nil {
+				if err := service.measure(&land, *item.Boundary); err != nil {
+					return err
+				}
+			}
+
+			if item.Sertifikat != "" {
+				filename, err := service.fileService.StoreBase64File(item.Sertifikat, "sertifikat", "member_lands")
+				if err != nil {
+					return err
+				}
+				land.Sertifikat = *filename
+				uploaded = append(uploaded, *filename)
+			}
+
+			if item.ID == 0 {
+				if err := tx.Create(&land).Error; err != nil {
+					return err
+				}
+			} else {
+				// Select("*") agar nilai nol (mis. jumlah_panen 0) ikut tersimpan,
+				// sertifikat hanya diganti jika file baru dikirim.
+				query := tx.Model(&models.MemberLand{}).Where("id = ?", item.ID).
+					Select("*").Omit("id", "reference", "created_at", "deleted_at")
+				if land.Sertifikat == "" {
+					query = query.Omit("sertifikat")
+				}
+				if err := query.Updates(&land).Error; err != nil {
+					return err
+				}
+			}
+			keep = append(keep, land.ID)
+		}
+
+		query := tx.Where("member_id = ?", member.ID)
+		if len(keep) > 0 {
+			query = query.Where("id NOT IN ?", keep)
+		}
+		return query.Delete(&models.MemberLand{}).Error
+	}); err != nil {
+		service.fileService.DeleteFiles("member_lands", uploaded)
+		return nil, err
+	}
+
+	return service.GetByMember(casts.StoreContext{IsAdmin: true}, memberID)
+}
+
+// measure memvalidasi boundary lahan, menghitung luasnya dan membandingkan
+// dengan luas_lahan yang diinput. Selisih maksimal diatur lewat env
+// LAND_AREA_TOLERANCE_PERCENT. Jika koordinat lahan kosong, titik tengah
+// boundary dipakai sebagai koordinat.
+func (service *MemberLandService) measure(land *models.MemberLand, boundary casts.GeoPolygon) error {
+	if err := boundary.Validate(); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidBoundary, err.Error())
+	}
+
+	area := math.Round(boundary.AreaHectares()*10000) / 10000
+	tolerance := float64(helpers.GetEnvInt("LAND_AREA_TOLERANCE_PERCENT", 10))
+	if deviation := math.Abs(area-land.LuasLahan) / land.LuasLahan * 100; deviation > tolerance {
+		return fmt.Errorf("%w: luas_lahan %.4f ha, boundary %.4f ha (selisih %.1f%%, maksimal %.0f%%)",
+			ErrLandAreaMismatch, land.LuasLahan, area, deviation, tolerance)
+	}
+
+	if land.Latitude == 0 && land.Longitude == 0 {
+		centroid := boundary.Centroid()
+		land.Longitude, land.Latitude = centroid[0], centroid[1]
+	}
+	land.Boundary = &boundary
+	land.LuasTerukur = &area
+	return nil
+}
+
+// query menyusun query lahan dari member yang dapat diakses user beserta
+// filter member, jarak (near + radius_km) dan bounding box. Jika filter near
+// dipakai, ekspresi urutan berdasarkan jarak ikut dikembalikan.
+func (service *MemberLandService) query(store casts.StoreContext, filters requests.MemberLandFilterRequest) (*gorm.DB, *clause.OrderBy, error) {
+	var nearest *clause.OrderBy
+
+	members := facades.DB.Model(&models.Member{}).Select("id").Scopes(service.memberService.memberScope(store))
+	query := facades.DB.Model(&models.MemberLand{}).Where("member_lands.member_id IN (?)", members)
+
+	if filters.MemberID != nil {
+		query = query.Where("member_lands.member_id = ?", *filters.MemberID)
+	}
+	if filters.Search != nil {
+		query = query.Where("member_lands.alamat LIKE ? OR member_lands.reference LIKE ? OR JSON_SEARCH(member_lands.jenis_tanam, 'one', ?) IS NOT NULL",
+			"%"+*filters.Search+"%", "%"+*filters.Search+"%", *filters.Search)
+	}
+
+	if filters.BBox != nil {
+		bbox, err := parseCoordinates(*filters.BBox, 4)
+		if err != nil {
+			return nil, nil, err
+		}
+		query = query.Where("member_lands.longitude BETWEEN ? AND ? AND member_lands.latitude BETWEEN ? AND ?",
+			bbox[0], bbox[2], bbox[1], bbox[3])
+	}
+
+	if filters.Near != nil {
+		point, err := parseCoordinates(*filters.Near, 2)
+		if err != nil {
+			return nil, nil, err
+		}
+		lat, lng := point[0], point[1]
+		if lat < -90 || lat > 90 || lng < -180 || lng > 180 {
+			return nil, nil, fmt.Errorf("%w: near di luar rentang", ErrInvalidGeoFilter)
+		}
+
+		radius := defaultRadiusKm
+		if filters.RadiusKm != nil {
+			radius = *filters.RadiusKm
+		}
+
+		// rentang latitude dipersempit lebih dulu agar index koordinat terpakai,
+		// lalu jarak dihitung dengan rumus haversine.
+		delta := radius / 111.32
+		distance := "6371 * 2 * ASIN(SQRT(POWER(SIN(RADIANS(member_lands.latitude - ?) / 2), 2) + " +
+			"COS(RADIANS(?)) * COS(RADIANS(member_lands.latitude)) * POWER(SIN(RADIANS(member_lands.longitude - ?) / 2), 2)))"
+		query = query.
+			Where("member_lands.latitude BETWEEN ? AND ?", lat-delta, lat+delta).
+			Where(distance+" <= ?", lat, lat, lng, radius)
+		nearest = &clause.OrderBy{Expression: clause.Expr{SQL: distance + " ASC", Vars: []any{lat, lat, lng}}}
+	}
+
+	return query, nearest, nil
+}
+
+func (service *MemberLandService) GetAll(store casts.StoreContext, filters requests.MemberLandFilterRequest) ([]models.MemberLand, int64, error) {
+	var lands []models.MemberLand
+	var total int64
+
+	query, nearest, err := service.query(store, filters)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	switch {
+	case filters.OrderBy != nil:
+		direction := "asc"
+		if filters.OrderDirection != nil {
+			direction = *filters.OrderDirection
+		}
+		query = query.Order(*filters.OrderBy + " " + direction)
+	case nearest != nil:
+		query = query.Order(*nearest)
+	default:
+		query = query.Order("member_lands.updated_at desc")
+	}
+
+	if err := query.Preload("Member").Scopes(scopes.Paginate(filters.FilterRequest)).Find(&lands).Error; err != nil {
+		return nil, 0, err
+	}
+	return lands, total, nil
+}
+
+// GeoJSON mengekspor lahan sebagai FeatureCollection. Lahan dengan boundary
+// diekspor sebagai Polygon, lahan lain sebagai Point dari koordinatnya.
+func (service *MemberLandService) GeoJSON(store casts.StoreContext, filters requests.MemberLandFilterRequest) (*responses.FeatureCollection, error) {
+	query, _, err := service.query(store, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	var lands []models.MemberLand
+	if err := query.Preload("Member").Order("member_lands.id asc").Find(&lands).Error; err != nil {
+		return nil, err
+	}
+
+	collection := responses.FeatureCollection{Type: "FeatureCollection", Features: make([]responses.Feature, 0, len(lands))}
+	for _, land := range lands {
+		geometry := responses.Geometry{Type: "Point", Coordinates: [2]float64{land.Longitude, land.Latitude}}
+		if land.Boundary != nil && len(land.Boundary.Coordinates) > 0 {
+			geometry = responses.Geometry{Type: land.Boundary.Type, Coordinates: land.Boundary.Coordinates}
+		}
+
+		properties := map[string]any{
+			"id":           land.ID,
+			"reference":    land.Reference,
+			"member_id":    land.MemberID,
+			"jenis_tanam":  land.JenisTanam,
+			"jumlah_panen": land.JumlahPanen,
+			"luas_lahan":   land.LuasLahan,
+			"luas_terukur": land.LuasTerukur,
+			"alamat":       land.Alamat,
+		}
+		if land.Member != nil {
+			properties["member_reference"] = land.Member.Reference
+			properties["member_nama"] = land.Member.Nama
+		}
+
+		collection.Features = append(collection.Features, responses.Feature{
+			Type:       "Feature",
+			Geometry:   geometry,
+			Properties: properties,
+		})
+	}
+
+	return &collection, nil
+}
+
+// parseCoordinates membaca daftar angka yang dipisahkan koma, mis. "lat,lng".
+func parseCoordinates(value string, length int) ([]float64, error) {
+	parts := strings.Split(value, ",")
+	if len(parts) != length {
+		return nil, fmt.Errorf("%w: %q harus berisi %d angka", ErrInvalidGeoFilter, value, length)
+	}
+
+	numbers := make([]float64, length)
+	for i, part := range parts {
+		number, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q bukan angka", ErrInvalidGeoFilter, part)
+		}
+		numbers[i] = number
+	}
+	return numbers, nil
+}
@@ -0,0 +1,227 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"golang_starter_kit_2025/app/casts"
+	"golang_starter_kit_2025/app/helpers"
+	"golang_starter_kit_2025/app/models"
+	"golang_starter_kit_2025/app/models/scopes"
+	"golang_starter_kit_2025/app/requests"
+	"golang_starter_kit_2025/app/responses"
+	"golang_starter_kit_2025/facades"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrNIKRegistered = errors.New("NIK sudah terdaftar")
+	ErrStoreRequired = errors.New("pilih toko aktif terlebih dahulu untuk mendaftarkan member")
+)
+
+type MemberService struct {
+	fileService FileService
+}
+
+func NewMemberService() *MemberService {
+	return &MemberService{
+		fileService: FileService{},
+	}
+}
+
+// memberScope membatasi member ke member yang terdaftar di toko yang dapat diakses user.
+func (service *MemberService) memberScope(store casts.StoreContext) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if store.IsAdmin && store.StoreID == 0 {
+			return db
+		}
+		storeMembers := facades.DB.Model(&models.StoreMember{}).
+			Select("member_id").
+			Scopes(scopes.StoreScope(store, "store_id"))
+		return db.Where("members.id IN (?)", storeMembers)
+	}
+}
+
+func (service *MemberService) GetAll(store casts.StoreContext, filters requests.FilterRequest) ([]models.Member, int64, error) {
+	var members []models.Member
+	var total int64
+
+	query := facades.DB.Model(&models.Member{}).Scopes(service.memberScope(store))
+	if filters.Search != nil {
+		query = query.Where("nama LIKE ? OR nik LIKE ? OR phone LIKE ? OR reference LIKE ?",
+			"%"+*filters.Search+"%", "%"+*filters.Search+"%", "%"+*filters.Search+"%", "%"+*filters.Search+"%")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if filters.OrderBy != nil {
+		direction := "asc"
+		if filters.OrderDirection != nil {
+			direction = *filters.OrderDirection
+		}
+		query = query.Order(*filters.OrderBy + " " + direction)
+	} else {
+		query = query.Order("updated_at desc")
+	}
+
+	if err := query.Preload("Stores").Scopes(scopes.Paginate(filters)).Find(&members).Error; err != nil {
+		return nil, 0, err
+	}
+	return members, total, nil
+}
+
+func (service *MemberService) GetByID(store casts.StoreContext, id string) (models.Member, error) {
+	return service.find(store, id)
+}
+
+func (service *MemberService) find(store casts.StoreContext, id any) (models.Member, error) {
+	var member models.Member
+	if err := facades.DB.Preload("Stores").Preload("Lands").Scopes(service.memberScope(store)).First(&member, id).Error; err != nil {
+		return member, err
+	}
+	return member, nil
+}
+
+// Put membuat member atau mengganti seluruh data member. Member baru otomatis
+// didaftarkan ke toko aktif, tanpa toko aktif member tidak dapat dibuat karena
+// tidak akan terlihat pada endpoint yang dibatasi toko.
+func (service *MemberService) Put(store casts.StoreContext, request requests.MemberRequestPut) (*models.Member, error) {
+	var existing models.Member
+	if request.ID == 0 && store.StoreID == 0 {
+		return nil, ErrStoreRequired
+	}
+	if request.ID != 0 {
+		var err error
+		if existing, err = service.find(store, request.ID); err != nil {
+			return nil, err
+		}
+		if request.Version == 0 {
+			return nil, ErrVersionRequired
+		}
+	}
+
+	// member yang sudah dihapus tetap memegang NIK-nya, sama seperti CheckNIK
+	var owner models.Member
+	if err := facades.DB.Unscoped().Select("id", "deleted_at").
+		Where("nik = ? AND id <> ?", request.NIK, request.ID).
+		Limit(1).Find(&owner).Error; err != nil {
+		return nil, err
+	}
+	if owner.ID != 0 {
+		if owner.DeletedAt.Valid {
+			return nil, fmt.Errorf("%w: member dengan NIK ini sudah dihapus", ErrNIKRegistered)
+		}
+		return nil, ErrNIKRegistered
+	}
+
+	// foto lama yang dikirim kembali sebagai URL tidak diunggah ulang
+	current, _ := helpers.FileKeyFromURL(existing.FotoKTP, "members")
+	filename, ok := helpers.FileKeyFromURL(request.FotoKTP, "members")
+	var uploaded []string
+	if !ok || filename != current {
+		stored, err := service.fileService.StoreBase64File(request.FotoKTP, "foto_ktp", "members")
+		if err != nil {
+			return nil, err
+		}
+		filename = *stored
+		uploaded = append(uploaded, filename)
+	}
+
+	member := models.Member{
+		ID:           request.ID,
+		Version:      request.Version,
+		Nama:         request.Nama,
+		Phone:        request.Phone,
+		Alamat:       request.Alamat,
+		NIK:          request.NIK,
+		JenisKelamin: request.JenisKelamin,
+		FotoKTP:      filename,
+	}
+
+	var err error
+	if request.ID == 0 {
+		err = facades.DB.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Create(&member).Error; err != nil {
+				return err
+			}
+			return tx.Create(&models.StoreMember{StoreID: store.StoreID, MemberID: member.ID}).Error
+		})
+	} else {
+		err = facades.DB.Model(&models.Member{}).Where("id = ?", request.ID).
+			Select("nama", "phone", "alamat", "nik", "jenis_kelamin", "foto_ktp", "version").Updates(&member).Error
+	}
+	if err != nil {
+		service.fileService.DeleteFiles("members", uploaded)
+		return nil, err
+	}
+
+	result, err := service.find(casts.StoreContext{IsAdmin: true}, member.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (service *MemberService) Delete(store casts.StoreContext, id string) error {
+	member, err := service.GetByID(store, id)
+	if err != nil {
+		return err
+	}
+	return facades.DB.Delete(&member).Error
+}
+
+// CheckNIK mengecek apakah NIK sudah terdaftar sebagai member dan apakah
+// member tersebut sudah terdaftar di toko request.StoreID. Member yang sudah
+// dihapus ikut dicek karena Put juga menolak NIK tersebut.
+func (service *MemberService) CheckNIK(store casts.StoreContext, request requests.MemberRequestCheckNIK) (*responses.CheckNIK, error) {
+	if !store.CanAccess(request.StoreID) {
+		return nil, ErrStoreForbidden
+	}
+
+	var member models.Member
+	if err := facades.DB.Unscoped().Where("nik = ?", request.NIK).Limit(1).Find(&member).Error; err != nil {
+		return nil, err
+	}
+
+	result := responses.CheckNIK{IsRegistered: member.ID != 0, IsTrashed: member.DeletedAt.Valid}
+	if !result.IsRegistered || result.IsTrashed {
+		return &result, nil
+	}
+
+	var count int64
+	if err := facades.DB.Model(&models.StoreMember{}).
+		Where("store_id = ? AND member_id = ?", request.StoreID, member.ID).
+		Count(&count).Error; err != nil {
+		return nil, err
+	}
+	result.IsRegisteredOnStore = count > 0
+
+	return &result, nil
+}
+
+// AttachStore mendaftarkan member yang sudah ada ke toko storeID.
+func (service *MemberService) AttachStore(store casts.StoreContext, storeID uint, request requests.StoreRequestMember) error {
+	if !store.CanAccess(storeID) {
+		return ErrStoreForbidden
+	}
+
+	var member models.Member
+	if err := facades.DB.Select("id").First(&member, request.MemberID).Error; err != nil {
+		return err
+	}
+
+	return facades.DB.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.StoreMember{StoreID: storeID, MemberID: member.ID}).Error
+}
+
+func (service *MemberService) DetachStore(store casts.StoreContext, storeID uint, memberID string) error {
+	if !store.CanAccess(storeID) {
+		return ErrStoreForbidden
+	}
+
+	return facades.DB.Where("store_id = ? AND member_id = ?", storeID, memberID).Delete(&models.StoreMember{}).Error
+}
@@ -0,0 +1,19 @@
+package services_test
+
+import (
+	"golang_starter_kit_2025/app/casts"
+	"golang_starter_kit_2025/app/requests"
+	"golang_starter_kit_2025/app/services"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MemberService", func() {
+	Context("when creating a member without an active store", func() {
+		It("should reject the member before inserting", func() {
+			_, err := services.NewMemberService().Put(casts.StoreContext{UserID: 1, IsAdmin: true}, requests.MemberRequestPut{Nama: "Budi"})
+			Expect(err).To(MatchError(services.ErrStoreRequired))
+		})
+	})
+})
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"time"
+
+	"golang_starter_kit_2025/app/models"
+	"golang_starter_kit_2025/app/models/scopes"
+	"golang_starter_kit_2025/app/requests"
+	"golang_starter_kit_2025/facades"
+
+	"gorm.io/gorm"
+)
+
+type NotificationService struct{}
+
+func NewNotificationService() *NotificationService {
+	return &NotificationService{}
+}
+
+// Send membuat notifikasi yang sama untuk setiap user di userIDs dalam
+// transaksi tx sehingga notifikasi hanya tersimpan jika perubahan data berhasil.
+func (service *NotificationService) Send(tx *gorm.DB, userIDs []uint, notification models.Notification) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	notifications := make([]models.Notification, 0, len(userIDs))
+	for _, userID := range userIDs {
+		item := notification
+		item.UserID = userID
+		notifications = append(notifications, item)
+	}
+	return tx.Create(&notifications).Error
+}
+
+func (service *NotificationService) GetAll(userID uint, filters requests.FilterRequest) ([]models.Notification, int64, error) {
+	var notifications []models.Notification
+	var total int64
+
+	query := facades.DB.Model(&models.Notification{}).Where("user_id = ?", userID)
+	if filters.Search != nil {
+		query = query.Where("title LIKE ? OR body LIKE ?", "%"+*filters.Search+"%", "%"+*filters.Search+"%")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Order("created_at desc, id desc").Scopes(scopes.Paginate(filters)).Find(&notifications).Error; err != nil {
+		return nil, 0, err
+	}
+	return notifications, total, nil
+}
+
+func (service *NotificationService) MarkRead(userID uint, id string) error {
+	result := facades.DB.Model(&models.Notification{}).
+		Where("id = ? AND user_id = ?", id, userID).
+		Where("read_at IS NULL").
+		UpdateColumn("read_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+
+	var count int64
+	if err := facades.DB.Model(&models.Notification{}).Where("id = ? AND user_id = ?", id, userID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
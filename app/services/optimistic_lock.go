@@ -0,0 +1,14 @@
+package services
+
+import (
+	"errors"
+
+	"golang_starter_kit_2025/app/database/plugins"
+)
+
+var (
+	// ErrVersionConflict dikembalikan plugin OptimisticLock saat data sudah
+	// diubah request lain sejak version yang dikirim client.
+	ErrVersionConflict = plugins.ErrVersionConflict
+	ErrVersionRequired = errors.New("version data wajib dikirim melalui field version atau header If-Match")
+)
@@ -2,6 +2,7 @@ package services
 
 import (
 	"golang_starter_kit_2025/app/models"
+	"golang_starter_kit_2025/app/requests"
 	"golang_starter_kit_2025/facades"
 )
 
@@ -15,23 +16,42 @@ func (*PermissionService) GetAll() ([]models.Permission, error) {
 	return permissions, nil
 }
 
-func (*PermissionService) Put(updatedPermission models.Permission) (models.Permission, error) {
+func (*PermissionService) Find(id string) (models.Permission, error) {
 	var permission models.Permission
+	if err := facades.DB.First(&permission, id).Error; err != nil {
+		return permission, err
+	}
+	return permission, nil
+}
 
-	if count := facades.DB.Model(&models.Permission{}).Where("id = ?", updatedPermission.ID).Find(&map[string]interface{}{}).RowsAffected; count == 0 {
-		if err := facades.DB.Create(&updatedPermission).Error; err != nil {
-			return permission, err
-		}
-	} else {
-		if err := facades.DB.Where("id = ?", updatedPermission.ID).Updates(&updatedPermission).Error; err != nil {
-			return permission, err
-		}
+// Put membuat permission baru jika ID kosong atau mengganti seluruh field permission yang sudah ada.
+func (*PermissionService) Put(request requests.PermissionRequest) (models.Permission, error) {
+	permission := models.Permission{
+		ID:      request.ID,
+		Name:    request.Name,
+		Group:   request.Group,
+		Version: request.Version,
+	}
 
-		if err := facades.DB.First(&permission, updatedPermission.ID).Error; err != nil {
+	if request.ID == 0 {
+		if err := facades.DB.Create(&permission).Error; err != nil {
 			return permission, err
 		}
+		return permission, nil
 	}
 
+	if err := facades.DB.Select("id").First(&models.Permission{}, request.ID).Error; err != nil {
+		return permission, err
+	}
+	if request.Version == 0 {
+		return permission, ErrVersionRequired
+	}
+	if err := facades.DB.Model(&models.Permission{}).Where("id = ?", request.ID).Select("name", "group", "version").Updates(&permission).Error; err != nil {
+		return permission, err
+	}
+	if err := facades.DB.First(&permission, request.ID).Error; err != nil {
+		return permission, err
+	}
 	return permission, nil
 }
 
@@ -0,0 +1,284 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image/color"
+	"image/png"
+	"strconv"
+
+	"golang_starter_kit_2025/app/casts"
+	"golang_starter_kit_2025/app/helpers"
+	"golang_starter_kit_2025/app/models"
+	"golang_starter_kit_2025/app/models/scopes"
+	"golang_starter_kit_2025/app/requests"
+	"golang_starter_kit_2025/facades"
+
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/code128"
+	"github.com/boombuler/barcode/ean"
+	"github.com/boombuler/barcode/qr"
+	"github.com/go-pdf/fpdf"
+)
+
+var ErrInvalidBarcode = errors.New("barcode tidak valid")
+
+const (
+	ContentTypePNG = "image/png"
+	ContentTypeSVG = "image/svg+xml"
+	ContentTypePDF = "application/pdf"
+)
+
+// ProductBarcodeService mencari produk dari hasil scan dan merender barcode,
+// QR code serta lembar label produk.
+type ProductBarcodeService struct{}
+
+func NewProductBarcodeService() *ProductBarcodeService {
+	return &ProductBarcodeService{}
+}
+
+// validateBarcode memastikan barcode dapat dirender, kode 13 digit harus
+// memiliki check digit EAN-13 yang benar.
+func validateBarcode(code string) error {
+	if _, err := strconv.ParseUint(code, 10, 64); err == nil && len(code) == 13 {
+		if !helpers.IsValidEAN13(code) {
+			return fmt.Errorf("%w: check digit EAN-13 %s salah", ErrInvalidBarcode, code)
+		}
+		return nil
+	}
+	if _, err := code128.Encode(code); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidBarcode, err.Error())
+	}
+	return nil
+}
+
+// GetByBarcode mencari produk dari hasil scan barcode, SKU atau reference.
+func (service *ProductBarcodeService) GetByBarcode(store casts.StoreContext, code string) (models.Product, error) {
+	var product models.Product
+	err := facades.DB.Preload("Store").Preload("Attributes.Attribute").Scopes(scopes.StoreScope(store, "store_id")).
+		Where("barcode = ? OR sku = ? OR reference = ?", code, code, code).
+		Order("id asc").First(&product).Error
+	return product, err
+}
+
+func (service *ProductBarcodeService) find(store casts.StoreContext, id string) (*models.Product, error) {
+	var product models.Product
+	if err := facades.DB.Scopes(scopes.StoreScope(store, "store_id")).First(&product, id).Error; err != nil {
+		return nil, err
+	}
+	if err := service.ensureBarcode(&product); err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+// ensureBarcode membuat barcode untuk produk lama yang dibuat sebelum kolom barcode ada.
+func (service *ProductBarcodeService) ensureBarcode(product *models.Product) error {
+	if product.Barcode != nil && *product.Barcode != "" {
+		return nil
+	}
+	code, err := helpers.GenerateProductBarcode(product.ID)
+	if err != nil {
+		return err
+	}
+	if err := facades.DB.Model(&models.Product{}).Where("id = ?", product.ID).UpdateColumn("barcode", code).Error; err != nil {
+		return err
+	}
+	product.Barcode = &code
+	return nil
+}
+
+// Generate mengisi barcode seluruh produk yang belum memiliki barcode.
+func (service *ProductBarcodeService) Generate() (int, error) {
+	var products []models.Product
+	if err := facades.DB.Select("id", "barcode").Where("barcode IS NULL OR barcode = ''").Find(&products).Error; err != nil {
+		return 0, err
+	}
+	for i := range products {
+		if err := service.ensureBarcode(&products[i]); err != nil {
+			return i, err
+		}
+	}
+	return len(products), nil
+}
+
+// encodeBarcode memilih simbologi dari kode jika tidak ditentukan, kode EAN-13
+// yang valid dirender sebagai EAN-13 dan selain itu sebagai Code128.
+func encodeBarcode(code string, symbology string) (barcode.Barcode, error) {
+	if symbology == "" {
+		symbology = helpers.BarcodeCode128
+		if helpers.IsValidEAN13(code) {
+			symbology = helpers.BarcodeEAN13
+		}
+	}
+
+	var encoded barcode.Barcode
+	var err error
+	if symbology == helpers.BarcodeEAN13 {
+		encoded, err = ean.Encode(code)
+	} else {
+		encoded, err = code128.Encode(code)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidBarcode, err.Error())
+	}
+	return encoded, nil
+}
+
+// Barcode merender barcode produk sebagai PNG atau SVG.
+func (service *ProductBarcodeService) Barcode(store casts.StoreContext, id string, request requests.BarcodeImageRequest) ([]byte, string, error) {
+	product, err := service.find(store, id)
+	if err != nil {
+		return nil, "", err
+	}
+	encoded, err := encodeBarcode(*product.Barcode, request.Symbology)
+	if err != nil {
+		return nil, "", err
+	}
+
+	width, height := request.Width, request.Height
+	if width == 0 {
+		width = 300
+	}
+	if height == 0 {
+		height = 100
+	}
+	return render(encoded, request.Format, width, height)
+}
+
+// QRCode merender QR code berisi reference produk sebagai PNG atau SVG.
+func (service *ProductBarcodeService) QRCode(store casts.StoreContext, id string, request requests.QRCodeRequest) ([]byte, string, error) {
+	product, err := service.find(store, id)
+	if err != nil {
+		return nil, "", err
+	}
+	encoded, err := qr.Encode(product.Reference, qr.M, qr.Auto)
+	if err != nil {
+		return nil, "", err
+	}
+
+	size := request.Size
+	if size == 0 {
+		size = 256
+	}
+	return render(encoded, request.Format, size, size)
+}
+
+func render(encoded barcode.Barcode, format string, width int, height int) ([]byte, string, error) {
+	if format == "svg" {
+		return renderSVG(encoded, width, height), ContentTypeSVG, nil
+	}
+
+	scaled, err := barcode.Scale(encoded, width, height)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %s", ErrInvalidBarcode, err.Error())
+	}
+	var buffer bytes.Buffer
+	if err := png.Encode(&buffer, scaled); err != nil {
+		return nil, "", err
+	}
+	return buffer.Bytes(), ContentTypePNG, nil
+}
+
+// renderSVG menggambar setiap modul gelap sebagai persegi pada viewBox ukuran
+// asli barcode sehingga hasilnya tetap tajam saat diperbesar.
+func renderSVG(encoded barcode.Barcode, width int, height int) []byte {
+	bounds := encoded.Bounds()
+	dark := func(x, y int) bool {
+		return color.GrayModel.Convert(encoded.At(x, y)).(color.Gray).Y < 128
+	}
+
+	var buffer bytes.Buffer
+	fmt.Fprintf(&buffer, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" preserveAspectRatio="none" shape-rendering="crispEdges">`,
+		width, height, bounds.Dx(), bounds.Dy())
+	fmt.Fprintf(&buffer, `<rect width="%d" height="%d" fill="#fff"/>`, bounds.Dx(), bounds.Dy())
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		// modul gelap yang berurutan digabung menjadi satu persegi
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if !dark(x, y) {
+				continue
+			}
+			start := x
+			for x < bounds.Max.X && dark(x, y) {
+				x++
+			}
+			fmt.Fprintf(&buffer, `<rect x="%d" y="%d" width="%d" height="1"/>`, start-bounds.Min.X, y-bounds.Min.Y, x-start)
+		}
+	}
+	buffer.WriteString(`</svg>`)
+	return buffer.Bytes()
+}
+
+// Labels membuat lembar label A4 berisi nama, harga dan barcode produk.
+func (service *ProductBarcodeService) Labels(store casts.StoreContext, request requests.ProductLabelRequest) ([]byte, error) {
+	var products []models.Product
+	if err := facades.DB.Scopes(scopes.StoreScope(store, "store_id")).Where("id IN ?", request.IDs).Order("id asc").Find(&products).Error; err != nil {
+		return nil, err
+	}
+	if len(products) == 0 {
+		return nil, fmt.Errorf("%w: produk tidak ditemukan", ErrInvalidBarcode)
+	}
+
+	copies, columns, rows := request.Copies, request.Columns, request.Rows
+	if copies == 0 {
+		copies = 1
+	}
+	if columns == 0 {
+		columns = 3
+	}
+	if rows == 0 {
+		rows = 8
+	}
+
+	const margin = 10.0
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(margin, margin, margin)
+	pdf.SetAutoPageBreak(false, margin)
+	translate := pdf.UnicodeTranslatorFromDescriptor("")
+	pageWidth, pageHeight := pdf.GetPageSize()
+	labelWidth := (pageWidth - 2*margin) / float64(columns)
+	labelHeight := (pageHeight - 2*margin) / float64(rows)
+
+	slot := 0
+	for i := range products {
+		product := &products[i]
+		if err := service.ensureBarcode(product); err != nil {
+			return nil, err
+		}
+		encoded, err := encodeBarcode(*product.Barcode, "")
+		if err != nil {
+			return nil, err
+		}
+		image, _, err := render(encoded, "png", 600, 160)
+		if err != nil {
+			return nil, err
+		}
+		imageName := "barcode-" + strconv.FormatUint(uint64(product.ID), 10)
+		pdf.RegisterImageOptionsReader(imageName, fpdf.ImageOptions{ImageType: "PNG"}, bytes.NewReader(image))
+
+		for c := 0; c < copies; c++ {
+			if slot%(columns*rows) == 0 {
+				pdf.AddPage()
+			}
+			x := margin + float64(slot%columns)*labelWidth
+			y := margin + float64((slot/columns)%rows)*labelHeight
+			slot++
+
+			pdf.SetXY(x+2, y+2)
+			pdf.SetFont("Helvetica", "B", 8)
+			pdf.CellFormat(labelWidth-4, 4, translate(product.Name), "", 2, "L", false, 0, "")
+			pdf.SetFont("Helvetica", "", 8)
+			pdf.CellFormat(labelWidth-4, 4, product.Price.Format(product.Currency), "", 2, "L", false, 0, "")
+			pdf.ImageOptions(imageName, x+2, y+11, labelWidth-4, labelHeight-17, false, fpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+			pdf.SetXY(x+2, y+labelHeight-6)
+			pdf.CellFormat(labelWidth-4, 4, *product.Barcode, "", 0, "C", false, 0, "")
+		}
+	}
+
+	var buffer bytes.Buffer
+	if err := pdf.Output(&buffer); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,296 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"golang_starter_kit_2025/app/casts"
+	"golang_starter_kit_2025/app/models"
+	"golang_starter_kit_2025/app/models/scopes"
+	"golang_starter_kit_2025/app/requests"
+	"golang_starter_kit_2025/facades"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrInvalidEffectiveDate = errors.New("tanggal berlaku harga tidak boleh di masa lalu")
+
+// currentOfferingPrice memilih harga yang berlaku pada waktu ? untuk setiap baris product_offerings.
+const currentOfferingPrice = `(SELECT product_offering_prices.price FROM product_offering_prices
+	WHERE product_offering_prices.product_offering_id = product_offerings.id
+	AND product_offering_prices.effective_from <= ?
+	AND (product_offering_prices.effective_to IS NULL OR product_offering_prices.effective_to > ?)
+	ORDER BY product_offering_prices.effective_from DESC LIMIT 1)`
+
+// ProductOfferingService mengelola katalog product offering. Katalog berlaku
+// untuk seluruh toko sehingga perubahan hanya dapat dilakukan admin.
+type ProductOfferingService struct{}
+
+func NewProductOfferingService() *ProductOfferingService {
+	return &ProductOfferingService{}
+}
+
+func (service *ProductOfferingService) withCurrentPrice(db *gorm.DB) *gorm.DB {
+	now := time.Now()
+	return db.Select("product_offerings.*, "+currentOfferingPrice+" AS current_price", now, now)
+}
+
+func (service *ProductOfferingService) GetAll(filters requests.ProductOfferingFilterRequest) ([]models.ProductOffering, int64, error) {
+	var offerings []models.ProductOffering
+	var total int64
+
+	query := facades.DB.Model(&models.ProductOffering{})
+	if filters.Status != nil {
+		query = query.Where("status = ?", *filters.Status)
+	}
+	if filters.Search != nil {
+		query = query.Where("name LIKE ? OR description LIKE ? OR reference LIKE ?",
+			"%"+*filters.Search+"%", "%"+*filters.Search+"%", "%"+*filters.Search+"%")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if filters.OrderBy != nil {
+		direction := "asc"
+		if filters.OrderDirection != nil {
+			direction = *filters.OrderDirection
+		}
+		query = query.Order(*filters.OrderBy + " " + direction)
+	} else {
+		query = query.Order("updated_at desc")
+	}
+
+	if err := query.Scopes(service.withCurrentPrice, scopes.Paginate(filters.FilterRequest)).Find(&offerings).Error; err != nil {
+		return nil, 0, err
+	}
+	return offerings, total, nil
+}
+
+func (service *ProductOfferingService) GetByID(id any) (models.ProductOffering, error) {
+	var offering models.ProductOffering
+	err := facades.DB.Scopes(service.withCurrentPrice).
+		Preload("Products.Product").
+		Preload("Prices", func(db *gorm.DB) *gorm.DB {
+			return db.Order("effective_from desc")
+		}).
+		First(&offering, id).Error
+	return offering, err
+}
+
+// EffectivePrice mengembalikan harga offering yang berlaku pada waktu at,
+// atau harga dasar offering jika belum ada riwayat harga.
+func (service *ProductOfferingService) EffectivePrice(tx *gorm.DB, offering models.ProductOffering, at time.Time) (casts.Money, error) {
+	var prices []casts.Money
+	if err := tx.Model(&models.ProductOfferingPrice{}).
+		Where("product_offering_id = ? AND effective_from <= ?", offering.ID, at).
+		Where("effective_to IS NULL OR effective_to > ?", at).
+		Order("effective_from desc").
+		Limit(1).
+		Pluck("price", &prices).Error; err != nil {
+		return 0, err
+	}
+	if len(prices) == 0 {
+		return offering.Price, nil
+	}
+	return prices[0], nil
+}
+
+func (service *ProductOfferingService) Put(store casts.StoreContext, request requests.ProductOfferingRequestPut) (*models.ProductOffering, error) {
+	if !store.IsAdmin {
+		return nil, ErrStoreForbidden
+	}
+
+	offering := models.ProductOffering{
+		ID:          request.ID,
+		Name:        request.Name,
+		Description: request.Description,
+		Price:       request.Price,
+		Status:      request.Status,
+		Version:     request.Version,
+	}
+	if request.Currency != "" {
+		offering.Currency = casts.NormalizeCurrency(request.Currency)
+	}
+
+	if err := facades.DB.Transaction(func(tx *gorm.DB) error {
+		if request.ID == 0 {
+			if err := tx.Create(&offering).Error; err != nil {
+				return err
+			}
+		} else {
+			var existing models.ProductOffering
+			if err := tx.First(&existing, request.ID).Error; err != nil {
+				return err
+			}
+			if request.Version == 0 {
+				return ErrVersionRequired
+			}
+			if err := tx.Model(&existing).Updates(&offering).Error; err != nil {
+				return err
+			}
+		}
+
+		current, err := service.EffectivePrice(tx, models.ProductOffering{ID: offering.ID}, time.Now())
+		if err != nil {
+			return err
+		}
+		if request.ID == 0 || current != offering.Price {
+			if err := service.schedulePrice(tx, offering.ID, offering.Price, time.Now(), store.UserID); err != nil {
+				return err
+			}
+		}
+
+		return service.syncProducts(tx, offering.ID, request.Products)
+	}); err != nil {
+		return nil, err
+	}
+
+	result, err := service.GetByID(offering.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// syncProducts menyamakan produk penyusun offering dengan request.
+func (service *ProductOfferingService) syncProducts(tx *gorm.DB, offeringID uint, products []requests.ProductOfferingProductRequest) error {
+	keep := make([]uint, 0, len(products))
+	for _, item := range products {
+		if err := tx.Select("id").First(&models.Product{}, item.ProductID).Error; err != nil {
+			return err
+		}
+
+		link := models.ProductOfferingProduct{
+			ProductOfferingID: offeringID,
+			ProductID:         item.ProductID,
+			Quantity:          roundQuantity(float64(item.Quantity)),
+		}
+		if err := tx.Clauses(clause.OnConflict{
+			DoUpdates: clause.AssignmentColumns([]string{"quantity", "updated_at"}),
+		}).Create(&link).Error; err != nil {
+			return err
+		}
+		keep = append(keep, item.ProductID)
+	}
+
+	query := tx.Where("product_offering_id = ?", offeringID)
+	if len(keep) > 0 {
+		query = query.Where("product_id NOT IN ?", keep)
+	}
+	return query.Delete(&models.ProductOfferingProduct{}).Error
+}
+
+func (service *ProductOfferingService) SetStatus(store casts.StoreContext, id string, request requests.ProductOfferingRequestStatus) (*models.ProductOffering, error) {
+	if !store.IsAdmin {
+		return nil, ErrStoreForbidden
+	}
+
+	var offering models.ProductOffering
+	if err := facades.DB.First(&offering, id).Error; err != nil {
+		return nil, err
+	}
+	if err := facades.DB.Model(&offering).Update("status", request.Status).Error; err != nil {
+		return nil, err
+	}
+
+	result, err := service.GetByID(offering.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (service *ProductOfferingService) Delete(store casts.StoreContext, id string) error {
+	if !store.IsAdmin {
+		return ErrStoreForbidden
+	}
+
+	var offering models.ProductOffering
+	if err := facades.DB.First(&offering, id).Error; err != nil {
+		return err
+	}
+	return facades.DB.Delete(&offering).Error
+}
+
+func (service *ProductOfferingService) Prices(id string) ([]models.ProductOfferingPrice, error) {
+	var offering models.ProductOffering
+	if err := facades.DB.Select("id").First(&offering, id).Error; err != nil {
+		return nil, err
+	}
+
+	var prices []models.ProductOfferingPrice
+	err := facades.DB.Where("product_offering_id = ?", offering.ID).Order("effective_from desc").Find(&prices).Error
+	return prices, err
+}
+
+// SchedulePrice menjadwalkan harga offering yang berlaku mulai EffectiveFrom.
+func (service *ProductOfferingService) SchedulePrice(store casts.StoreContext, id string, request requests.ProductOfferingPriceRequest) ([]models.ProductOfferingPrice, error) {
+	if !store.IsAdmin {
+		return nil, ErrStoreForbidden
+	}
+
+	effectiveFrom, err := time.ParseInLocation(time.DateTime, request.EffectiveFrom, time.Local)
+	if err != nil {
+		return nil, err
+	}
+	if effectiveFrom.Before(time.Now().Add(-time.Minute)) {
+		return nil, ErrInvalidEffectiveDate
+	}
+
+	var offering models.ProductOffering
+	if err := facades.DB.Select("id").First(&offering, id).Error; err != nil {
+		return nil, err
+	}
+
+	if err := facades.DB.Transaction(func(tx *gorm.DB) error {
+		return service.schedulePrice(tx, offering.ID, request.Price, effectiveFrom, store.UserID)
+	}); err != nil {
+		return nil, err
+	}
+	return service.Prices(id)
+}
+
+// schedulePrice menyisipkan harga ke riwayat harga offering dan menyesuaikan
+// effective_to harga sebelum dan sesudahnya sehingga periode tidak tumpang
+// tindih. Harga dengan effective_from yang sama diganti.
+func (service *ProductOfferingService) schedulePrice(tx *gorm.DB, offeringID uint, price casts.Money, from time.Time, actorID uint) error {
+	// kunci offering agar penjadwalan harga yang bersamaan diproses berurutan
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&models.ProductOffering{}, offeringID).Error; err != nil {
+		return err
+	}
+
+	var same models.ProductOfferingPrice
+	if err := tx.Where("product_offering_id = ? AND effective_from = ?", offeringID, from).Limit(1).Find(&same).Error; err != nil {
+		return err
+	}
+	if same.ID != 0 {
+		return tx.Model(&same).Updates(map[string]any{"price": price, "created_by": actorID}).Error
+	}
+
+	var next models.ProductOfferingPrice
+	if err := tx.Where("product_offering_id = ? AND effective_from > ?", offeringID, from).
+		Order("effective_from asc").Limit(1).Find(&next).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Model(&models.ProductOfferingPrice{}).
+		Where("product_offering_id = ? AND effective_from < ?", offeringID, from).
+		Where("effective_to IS NULL OR effective_to > ?", from).
+		Update("effective_to", from).Error; err != nil {
+		return err
+	}
+
+	record := models.ProductOfferingPrice{
+		ProductOfferingID: offeringID,
+		Price:             price,
+		EffectiveFrom:     from,
+		CreatedBy:         &actorID,
+	}
+	if next.ID != 0 {
+		record.EffectiveTo = &next.EffectiveFrom
+	}
+	return tx.Create(&record).Error
+}
@@ -0,0 +1,202 @@
+package services
+
+import (
+	"log"
+	"time"
+
+	"golang_starter_kit_2025/app/casts"
+	"golang_starter_kit_2025/app/helpers"
+	"golang_starter_kit_2025/app/models"
+	"golang_starter_kit_2025/app/models/scopes"
+	"golang_starter_kit_2025/app/requests"
+	"golang_starter_kit_2025/facades"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ProductPriceService mengelola riwayat harga produk. Harga yang sedang
+// berlaku selalu tersimpan juga di products.price dan products.margin, baris
+// product_prices dengan applied_at kosong adalah perubahan terjadwal.
+type ProductPriceService struct{}
+
+func NewProductPriceService() *ProductPriceService {
+	return &ProductPriceService{}
+}
+
+func (service *ProductPriceService) findProduct(store casts.StoreContext, id any) (models.Product, error) {
+	var product models.Product
+	err := facades.DB.Select("id", "store_id").Scopes(scopes.StoreScope(store, "store_id")).First(&product, id).Error
+	return product, err
+}
+
+func (service *ProductPriceService) History(store casts.StoreContext, id string) ([]models.ProductPrice, error) {
+	product, err := service.findProduct(store, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var prices []models.ProductPrice
+	err = facades.DB.Preload("Creator").
+		Where("product_id = ?", product.ID).
+		Order("effective_from desc, id desc").
+		Find(&prices).Error
+	return prices, err
+}
+
+// Record mencatat harga yang langsung berlaku pada waktu at dalam transaksi tx
+// dan menutup periode harga sebelumnya. Dipanggil setelah products.price diubah.
+func (service *ProductPriceService) Record(tx *gorm.DB, productID uint, price casts.Money, margin casts.Money, at time.Time, actorID *uint) error {
+	if err := service.close(tx, productID, at); err != nil {
+		return err
+	}
+	return tx.Create(&models.ProductPrice{
+		ProductID:     productID,
+		Price:         price,
+		Margin:        margin,
+		EffectiveFrom: at,
+		AppliedAt:     &at,
+		CreatedBy:     actorID,
+	}).Error
+}
+
+// close menutup periode harga yang sudah diterapkan dan masih terbuka.
+func (service *ProductPriceService) close(tx *gorm.DB, productID uint, at time.Time) error {
+	return tx.Model(&models.ProductPrice{}).
+		Where("product_id = ? AND applied_at IS NOT NULL AND effective_to IS NULL", productID).
+		Update("effective_to", at).Error
+}
+
+// Schedule menjadwalkan perubahan harga produk mulai EffectiveFrom. Jadwal
+// dengan effective_from yang sama diganti, jadwal yang sudah jatuh tempo
+// langsung diterapkan.
+func (service *ProductPriceService) Schedule(store casts.StoreContext, id string, request requests.ProductPriceRequest) ([]models.ProductPrice, error) {
+	effectiveFrom, err := time.ParseInLocation(time.DateTime, request.EffectiveFrom, time.Local)
+	if err != nil {
+		return nil, err
+	}
+	if effectiveFrom.Before(time.Now().Add(-time.Minute)) {
+		return nil, ErrInvalidEffectiveDate
+	}
+
+	product, err := service.findProduct(store, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := facades.DB.Transaction(func(tx *gorm.DB) error {
+		var pending models.ProductPrice
+		if err := tx.Where("product_id = ? AND applied_at IS NULL AND effective_from = ?", product.ID, effectiveFrom).
+			Limit(1).Find(&pending).Error; err != nil {
+			return err
+		}
+
+		pending.ProductID = product.ID
+		pending.Price = request.Price
+		pending.Margin = request.Margin
+		pending.EffectiveFrom = effectiveFrom
+		pending.CreatedBy = &store.UserID
+		return tx.Save(&pending).Error
+	}); err != nil {
+		return nil, err
+	}
+
+	if !effectiveFrom.After(time.Now()) {
+		if _, err := service.ApplyDue(time.Now()); err != nil {
+			return nil, err
+		}
+	}
+	return service.History(store, id)
+}
+
+// Cancel membatalkan perubahan harga terjadwal yang belum diterapkan.
+func (service *ProductPriceService) Cancel(store casts.StoreContext, id string, priceID string) error {
+	product, err := service.findProduct(store, id)
+	if err != nil {
+		return err
+	}
+
+	result := facades.DB.Where("product_id = ? AND applied_at IS NULL", product.ID).Delete(&models.ProductPrice{}, priceID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ApplyDue menerapkan seluruh perubahan harga terjadwal yang effective_from-nya
+// sudah lewat dari now, berurutan per produk. Setiap jadwal diterapkan dalam
+// transaksinya sendiri dan dikunci dengan SKIP LOCKED sehingga beberapa worker
+// dapat berjalan bersamaan tanpa menerapkan jadwal yang sama dua kali.
+func (service *ProductPriceService) ApplyDue(now time.Time) (int, error) {
+	var due []models.ProductPrice
+	if err := facades.DB.Select("id").
+		Where("applied_at IS NULL AND effective_from <= ?", now).
+		Order("effective_from asc, id asc").
+		Find(&due).Error; err != nil {
+		return 0, err
+	}
+
+	applied := 0
+	for _, item := range due {
+		err := facades.DB.Transaction(func(tx *gorm.DB) error {
+			var price models.ProductPrice
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+				Where("applied_at IS NULL").Limit(1).Find(&price, item.ID).Error; err != nil {
+				return err
+			}
+			if price.ID == 0 {
+				// sudah diterapkan atau dibatalkan oleh proses lain
+				return nil
+			}
+
+			var product models.Product
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&product, price.ProductID).Error; err != nil {
+				return err
+			}
+			if err := service.close(tx, price.ProductID, price.EffectiveFrom); err != nil {
+				return err
+			}
+			if err := tx.Model(&product).UpdateColumns(map[string]any{
+				"price":  price.Price,
+				"margin": price.Margin,
+			}).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&price).Update("applied_at", now).Error; err != nil {
+				return err
+			}
+			applied++
+			return nil
+		})
+		if err != nil {
+			return applied, err
+		}
+	}
+	return applied, nil
+}
+
+// RunScheduler menjalankan ApplyDue secara berkala setiap
+// PRICE_SCHEDULER_INTERVAL_SECONDS detik. Dijalankan sebagai goroutine oleh
+// server HTTP, nilai interval 0 menonaktifkan worker.
+func (service *ProductPriceService) RunScheduler() {
+	interval := helpers.GetEnvInt("PRICE_SCHEDULER_INTERVAL_SECONDS", 60)
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(time.Duration(interval) * time.Second)
+	defer ticker.Stop()
+	for range ticker.C {
+		applied, err := service.ApplyDue(time.Now())
+		if err != nil {
+			log.Println("Gagal menerapkan jadwal harga produk:", err)
+			continue
+		}
+		if applied > 0 {
+			log.Printf("%d jadwal harga produk diterapkan\n", applied)
+		}
+	}
+}
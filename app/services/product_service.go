@@ -1,33 +1,61 @@
 package services
 
 import (
-	"log"
+	"errors"
+	"fmt"
+	"time"
 
+	"golang_starter_kit_2025/app/casts"
+	"golang_starter_kit_2025/app/helpers"
 	"golang_starter_kit_2025/app/models"
+	"golang_starter_kit_2025/app/models/scopes"
 	"golang_starter_kit_2025/app/requests"
 	"golang_starter_kit_2025/facades"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ErrStockChanged dikembalikan saat update produk mengirim stok yang berbeda
+// dari stok saat ini, perubahan stok harus dicatat melalui stock movement.
+var ErrStockChanged = errors.New("stok tidak dapat diubah melalui update produk, gunakan penyesuaian stok")
+
 type ProductService struct {
-	fileService FileService
+	fileService          FileService
+	stockMovementService *StockMovementService
+	productPriceService  *ProductPriceService
 }
 
 func NewProductService() *ProductService {
 	return &ProductService{
-		fileService: FileService{},
+		fileService:          FileService{},
+		stockMovementService: NewStockMovementService(),
+		productPriceService:  NewProductPriceService(),
 	}
 }
 
-func (service *ProductService) GetAll(filters requests.FilterRequest) ([]models.Product, error) {
+func (service *ProductService) GetAll(store casts.StoreContext, filters requests.ProductFilterRequest) ([]models.Product, error) {
 	var products []models.Product
-	query := facades.DB
+	query := facades.DB.Preload("Store").Preload("Attributes.Attribute").Scopes(scopes.StoreScope(store, "store_id"))
 
 	if filters.Search != nil {
-		query = query.Where("name LIKE ?", "%"+*filters.Search+"%").
-			Or("description LIKE ?", "%"+*filters.Search+"%").
-			Or("reference LIKE ?", "%"+*filters.Search+"%")
+		search := "%" + *filters.Search + "%"
+		query = query.Where("name LIKE ? OR description LIKE ? OR reference LIKE ? OR sku LIKE ? OR EXISTS (SELECT 1 FROM product_attribute_values pav WHERE pav.product_id = products.id AND pav.value LIKE ?)",
+			search, search, search, search, search)
+	}
+	if filters.CategoryID != nil {
+		// termasuk produk pada seluruh turunan kategori
+		query = query.Where("category_id IN (SELECT c.id FROM categories c JOIN categories p ON c.path LIKE CONCAT(p.path, '%') WHERE p.id = ? AND c.deleted_at IS NULL)",
+			*filters.CategoryID)
+	}
+	if filters.ParentID != nil {
+		query = query.Where("parent_id = ?", *filters.ParentID)
+	}
+	// setiap atribut pada filter harus cocok (AND)
+	for code, value := range filters.Attributes {
+		query = query.Where("EXISTS (SELECT 1 FROM product_attribute_values pav JOIN category_attributes ca ON ca.id = pav.category_attribute_id WHERE pav.product_id = products.id AND ca.code = ? AND pav.value = ?)",
+			code, value)
 	}
 
 	if filters.OrderBy != nil {
@@ -42,81 +70,193 @@ func (service *ProductService) GetAll(filters requests.FilterRequest) ([]models.
 	return products, nil
 }
 
-func (service *ProductService) GetByID(id string) (models.Product, error) {
+func (service *ProductService) GetByID(store casts.StoreContext, id string) (models.Product, error) {
 	var product models.Product
-	if err := facades.DB.First(&product, id).Error; err != nil {
+	if err := facades.DB.Preload("Store").Preload("Attributes.Attribute").Preload("Variants.Attributes.Attribute").
+		Scopes(scopes.StoreScope(store, "store_id")).First(&product, id).Error; err != nil {
 		return product, err
 	}
 	return product, nil
 }
 
-func (service *ProductService) Put(ctx *gin.Context, request requests.ProductRequest) (*models.Product, error) {
-	var product models.Product
+// Put membuat produk baru jika ID kosong atau mengganti seluruh field produk
+// yang sudah ada (PUT). Field kosong ikut disimpan sehingga margin 0 atau
+// deskripsi kosong tetap berlaku, PATCH memakai fungsi ini setelah merge patch.
+func (service *ProductService) Put(ctx *gin.Context, store casts.StoreContext, request requests.ProductRequest) (*models.Product, error) {
+	var product *models.Product
+	var uploaded []string
+	if err := facades.DB.Transaction(func(tx *gorm.DB) (err error) {
+		product, uploaded, err = service.put(tx, ctx, store, request)
+		return err
+	}); err != nil {
+		service.fileService.DeleteFiles("products", uploaded)
+		return product, err
+	}
 
-	// Mengunggah file gambar produk jika ada
-	var filenames []string
-	if request.Images != nil {
-		for _, image := range request.Images {
-			filename, err := service.fileService.StoreBase64File(image, "images", "products")
-			if err != nil {
-				return nil, err
-			}
-			filenames = append(filenames, *filename)
-			log.Println(&filename)
-		}
+	if err := facades.DB.Preload("Store").First(product, product.ID).Error; err != nil {
+		return product, err
 	}
+	return product, nil
+}
 
+// put menjalankan Put dalam transaksi tx sehingga service lain, misalnya
+// varian produk, dapat menyimpan data tambahan dalam transaksi yang sama.
+// Gambar baru yang sudah diunggah dikembalikan agar pemilik transaksi dapat
+// menghapusnya jika transaksi gagal.
+func (service *ProductService) put(tx *gorm.DB, ctx *gin.Context, store casts.StoreContext, request requests.ProductRequest) (*models.Product, []string, error) {
+	var existing models.Product
+
+	if !store.CanAccess(request.StoreID) {
+		return nil, nil, ErrStoreForbidden
+	}
+	if err := tx.Select("id").First(&models.Store{}, request.StoreID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrStoreNotFound
+		}
+		return nil, nil, err
+	}
 	if request.ID != 0 {
-		product.ID = request.ID
+		// produk milik toko lain tidak boleh diubah
+		if err := tx.First(&existing, request.ID).Error; err != nil {
+			return nil, nil, err
+		}
+		if !store.CanAccess(existing.StoreID) {
+			return nil, nil, ErrStoreForbidden
+		}
+		if request.Version == 0 {
+			return nil, nil, ErrVersionRequired
+		}
 	}
 
-	if request.CategoryID != 0 {
-		product.CategoryID = request.CategoryID
+	if request.Barcode != "" {
+		if err := validateBarcode(request.Barcode); err != nil {
+			return nil, nil, err
+		}
 	}
 
-	if request.Name != "" {
-		product.Name = request.Name
+	images, uploaded, err := service.images(request.Images, existing.Images)
+	if err != nil {
+		return nil, uploaded, err
 	}
-	if request.Description != "" {
-		product.Description = request.Description
+
+	product := models.Product{
+		ID:          request.ID,
+		Version:     request.Version,
+		StoreID:     request.StoreID,
+		CategoryID:  request.CategoryID,
+		Name:        request.Name,
+		Description: request.Description,
+		Price:       request.Price,
+		Margin:      request.Margin,
+		Currency:    casts.NormalizeCurrency(request.Currency),
+		Images:      images,
+		ReceivedAt:  request.ReceivedAt,
 	}
-	if request.Price != 0 {
-		product.Price = request.Price
+	if request.Barcode != "" {
+		product.Barcode = &request.Barcode
 	}
-	if request.Margin != 0 {
-		product.Margin = request.Margin
+
+	actorID := ctx.GetUint("user_id")
+	if request.ID == 0 {
+		// stok hanya berubah melalui ledger, stok dari request dicatat sebagai stok awal
+		if err := tx.Create(&product).Error; err != nil {
+			return &product, uploaded, err
+		}
+		if err := service.productPriceService.Record(tx, product.ID, product.Price, product.Margin, time.Now(), &actorID); err != nil {
+			return &product, uploaded, err
+		}
+		if request.Stock == nil || *request.Stock == 0 {
+			return &product, uploaded, nil
+		}
+
+		movement := models.StockMovement{
+			ProductID: product.ID,
+			Type:      models.StockMovementAdjustment,
+			Quantity:  *request.Stock,
+			Reason:    "Stok awal",
+			ActorID:   &actorID,
+		}
+		if err := service.stockMovementService.Record(tx, &movement); err != nil {
+			return &product, uploaded, err
+		}
+		product.Stock = movement.StockAfter
+		return &product, uploaded, nil
 	}
-	if request.Stock != 0 {
-		product.Stock = request.Stock
+
+	// barcode kosong dikembalikan ke barcode otomatis dari ID produk
+	if product.Barcode == nil {
+		code, err := helpers.GenerateProductBarcode(product.ID)
+		if err != nil {
+			return nil, uploaded, err
+		}
+		product.Barcode = &code
 	}
-	if request.Sold != 0 {
-		product.Sold = request.Sold
+
+	// perubahan harga langsung dicatat ke riwayat harga, jadwal harga melalui ProductPriceService.Schedule
+	if err := tx.Model(&models.Product{}).Where("id = ?", request.ID).
+		Select(productColumns).Updates(&product).Error; err != nil {
+		return &product, uploaded, err
 	}
-	if !request.ReceivedAt.IsZero() {
-		product.ReceivedAt = request.ReceivedAt
+	if err := service.checkStock(tx, request.ID, request.Stock); err != nil {
+		return &product, uploaded, err
 	}
-	if request.Images != nil {
-		product.Images = filenames
+	if product.Price != existing.Price || product.Margin != existing.Margin {
+		if err := service.productPriceService.Record(tx, request.ID, product.Price, product.Margin, time.Now(), &actorID); err != nil {
+			return &product, uploaded, err
+		}
 	}
+	return &product, uploaded, nil
+}
 
-	if count := facades.DB.Model(&models.Product{}).Where("id = ?", request.ID).Find(&map[string]interface{}{}).RowsAffected; count == 0 {
-		if err := facades.DB.Create(&product).Error; err != nil {
-			return &product, err
+// productColumns adalah field yang diganti oleh PUT dan PATCH produk, stok
+// dan harga pokok hanya berubah melalui ledger.
+var productColumns = []string{"store_id", "category_id", "name", "description", "barcode", "price", "margin", "currency", "images", "received_at", "version"}
+
+// images mengunggah gambar base64 baru dan mempertahankan gambar lama yang
+// dikirim kembali sebagai URL hasil GetFileURL. uploaded berisi gambar baru
+// yang sudah tersimpan, termasuk saat salah satu unggahan gagal.
+func (service *ProductService) images(values []string, current []string) (filenames []string, uploaded []string, err error) {
+	existing := make(map[string]bool, len(current))
+	for _, image := range current {
+		if key, ok := helpers.FileKeyFromURL(image, "products"); ok {
+			existing[key] = true
 		}
-	} else {
-		if err := facades.DB.Model(&models.Product{}).Where("id = ?", request.ID).Updates(&product).Error; err != nil {
-			return &product, err
+	}
+
+	for _, value := range values {
+		if key, ok := helpers.FileKeyFromURL(value, "products"); ok && existing[key] {
+			filenames = append(filenames, key)
+			continue
 		}
-		if err := facades.DB.First(&product, request.ID).Error; err != nil {
-			return &product, err
+		filename, err := service.fileService.StoreBase64File(value, "images", "products")
+		if err != nil {
+			return nil, uploaded, err
 		}
+		filenames = append(filenames, *filename)
+		uploaded = append(uploaded, *filename)
 	}
+	return filenames, uploaded, nil
+}
 
-	return &product, nil
+// checkStock membandingkan stok yang dikirim saat update dengan stok saat ini.
+// Stok nil atau sama dengan stok saat ini diterima, selisih ditolak karena
+// stok request bisa saja dibaca sebelum ada transaksi stok lain.
+func (service *ProductService) checkStock(tx *gorm.DB, productID uint, stock *int) error {
+	if stock == nil {
+		return nil
+	}
+	var product models.Product
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "stock").First(&product, productID).Error; err != nil {
+		return err
+	}
+	if *stock != product.Stock {
+		return fmt.Errorf("%w: stok saat ini %d", ErrStockChanged, product.Stock)
+	}
+	return nil
 }
 
-func (service *ProductService) Delete(id string) error {
-	result := facades.DB.Delete(&models.Product{}, id)
+func (service *ProductService) Delete(store casts.StoreContext, id string) error {
+	result := facades.DB.Scopes(scopes.StoreScope(store, "store_id")).Delete(&models.Product{}, id)
 	if result.Error != nil {
 		return result.Error
 	}
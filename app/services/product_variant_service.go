@@ -0,0 +1,215 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang_starter_kit_2025/app/casts"
+	"golang_starter_kit_2025/app/models"
+	"golang_starter_kit_2025/app/models/scopes"
+	"golang_starter_kit_2025/app/requests"
+	"golang_starter_kit_2025/facades"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+var ErrInvalidVariant = errors.New("varian produk tidak valid")
+
+// ProductVariantService mengelola varian produk. Setiap varian adalah baris
+// produk dengan parent_id sehingga stok, penjualan, pembelian, transfer dan
+// stock opname berjalan per varian tanpa perubahan.
+type ProductVariantService struct {
+	productService *ProductService
+}
+
+func NewProductVariantService() *ProductVariantService {
+	return &ProductVariantService{
+		productService: NewProductService(),
+	}
+}
+
+func (service *ProductVariantService) parent(store casts.StoreContext, productID string) (*models.Product, error) {
+	var product models.Product
+	if err := facades.DB.Scopes(scopes.StoreScope(store, "store_id")).First(&product, productID).Error; err != nil {
+		return nil, err
+	}
+	if product.ParentID != nil {
+		return nil, fmt.Errorf("%w: produk %s adalah varian, bukan produk induk", ErrInvalidVariant, product.Name)
+	}
+	return &product, nil
+}
+
+func (service *ProductVariantService) GetAll(store casts.StoreContext, productID string) ([]models.Product, error) {
+	parent, err := service.parent(store, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	var variants []models.Product
+	err = facades.DB.Preload("Attributes.Attribute").Where("parent_id = ?", parent.ID).Order("id asc").Find(&variants).Error
+	return variants, err
+}
+
+// Put membuat atau mengupdate varian dari produk induk. Toko, kategori,
+// mata uang dan deskripsi mengikuti produk induk.
+func (service *ProductVariantService) Put(ctx *gin.Context, store casts.StoreContext, productID string, request requests.ProductVariantRequest) (*models.Product, error) {
+	parent, err := service.parent(store, productID)
+	if err != nil {
+		return nil, err
+	}
+	var existing models.Product
+	if request.ID != 0 {
+		if err := facades.DB.Select("id", "barcode", "received_at").Where("id = ? AND parent_id = ?", request.ID, parent.ID).First(&existing).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	var duplicate int64
+	if err := facades.DB.Model(&models.Product{}).
+		Where("store_id = ? AND sku = ? AND id <> ?", parent.StoreID, request.SKU, request.ID).
+		Count(&duplicate).Error; err != nil {
+		return nil, err
+	}
+	if duplicate > 0 {
+		return nil, fmt.Errorf("%w: SKU %s sudah digunakan", ErrInvalidVariant, request.SKU)
+	}
+
+	values, err := service.attributeValues(parent.CategoryID, request.Attributes, true)
+	if err != nil {
+		return nil, err
+	}
+
+	name := request.Name
+	if name == "" {
+		parts := []string{parent.Name}
+		for _, value := range values {
+			parts = append(parts, value.Value)
+		}
+		name = strings.Join(parts, " ")
+	}
+
+	// barcode dan tanggal terima tidak ada di request varian sehingga dipertahankan,
+	// stok hanya dipakai sebagai stok awal saat varian dibuat
+	productRequest := requests.ProductRequest{
+		ID:          request.ID,
+		Version:     request.Version,
+		StoreID:     parent.StoreID,
+		CategoryID:  parent.CategoryID,
+		Name:        name,
+		Description: parent.Description,
+		Price:       request.Price,
+		Margin:      request.Margin,
+		Currency:    parent.Currency,
+		Images:      request.Images,
+		ReceivedAt:  existing.ReceivedAt,
+	}
+	if existing.Barcode != nil {
+		productRequest.Barcode = *existing.Barcode
+	}
+	if request.ID == 0 {
+		productRequest.Stock = &request.Stock
+	}
+	// produk, stok awal, SKU dan atribut disimpan dalam satu transaksi sehingga
+	// kegagalan atribut tidak meninggalkan produk tanpa induk
+	var variantID uint
+	var uploaded []string
+	if err := facades.DB.Transaction(func(tx *gorm.DB) error {
+		product, images, err := service.productService.put(tx, ctx, store, productRequest)
+		uploaded = images
+		if err != nil {
+			return err
+		}
+		variantID = product.ID
+
+		if err := tx.Model(&models.Product{}).Where("id = ?", product.ID).
+			Updates(map[string]interface{}{"parent_id": parent.ID, "sku": request.SKU}).Error; err != nil {
+			return err
+		}
+		return service.replaceAttributes(tx, product.ID, values)
+	}); err != nil {
+		service.productService.fileService.DeleteFiles("products", uploaded)
+		return nil, err
+	}
+
+	var variant models.Product
+	if err := facades.DB.Preload("Store").Preload("Parent").Preload("Attributes.Attribute").First(&variant, variantID).Error; err != nil {
+		return nil, err
+	}
+	return &variant, nil
+}
+
+// SetAttributes mengganti seluruh nilai atribut pada produk atau varian.
+func (service *ProductVariantService) SetAttributes(store casts.StoreContext, productID string, request requests.ProductAttributesRequest) (*models.Product, error) {
+	var product models.Product
+	if err := facades.DB.Scopes(scopes.StoreScope(store, "store_id")).First(&product, productID).Error; err != nil {
+		return nil, err
+	}
+
+	// atribut wajib hanya diperiksa pada varian
+	values, err := service.attributeValues(product.CategoryID, request.Attributes, product.ParentID != nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := facades.DB.Transaction(func(tx *gorm.DB) error {
+		return service.replaceAttributes(tx, product.ID, values)
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := facades.DB.Preload("Store").Preload("Attributes.Attribute").First(&product, product.ID).Error; err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+// attributeValues memvalidasi nilai atribut terhadap definisi atribut kategori.
+func (service *ProductVariantService) attributeValues(categoryID uint, attributes []requests.ProductAttributeRequest, requireAll bool) ([]models.ProductAttributeValue, error) {
+	var definitions []models.CategoryAttribute
+	if err := facades.DB.Where("category_id = ?", categoryID).Order("sort_order asc, id asc").Find(&definitions).Error; err != nil {
+		return nil, err
+	}
+
+	given := make(map[uint]string, len(attributes))
+	for _, attribute := range attributes {
+		if _, ok := given[attribute.AttributeID]; ok {
+			return nil, fmt.Errorf("%w: atribut %d diisi lebih dari sekali", ErrInvalidVariant, attribute.AttributeID)
+		}
+		given[attribute.AttributeID] = strings.TrimSpace(attribute.Value)
+	}
+
+	var values []models.ProductAttributeValue
+	for i := range definitions {
+		definition := &definitions[i]
+		value, ok := given[definition.ID]
+		if !ok {
+			if requireAll && definition.Required {
+				return nil, fmt.Errorf("%w: atribut %s wajib diisi", ErrInvalidVariant, definition.Name)
+			}
+			continue
+		}
+		delete(given, definition.ID)
+		if err := definition.Validate(value); err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidVariant, err.Error())
+		}
+		values = append(values, models.ProductAttributeValue{CategoryAttributeID: definition.ID, Value: value})
+	}
+	for id := range given {
+		return nil, fmt.Errorf("%w: atribut %d bukan atribut kategori produk", ErrInvalidVariant, id)
+	}
+	return values, nil
+}
+
+func (service *ProductVariantService) replaceAttributes(tx *gorm.DB, productID uint, values []models.ProductAttributeValue) error {
+	if err := tx.Where("product_id = ?", productID).Delete(&models.ProductAttributeValue{}).Error; err != nil {
+		return err
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	for i := range values {
+		values[i].ProductID = productID
+	}
+	return tx.Create(&values).Error
+}
@@ -0,0 +1,355 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"golang_starter_kit_2025/app/casts"
+	"golang_starter_kit_2025/app/models"
+	"golang_starter_kit_2025/app/models/scopes"
+	"golang_starter_kit_2025/app/requests"
+	"golang_starter_kit_2025/facades"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrInvalidPurchaseOrder = errors.New("data purchase order tidak valid")
+	ErrPurchaseOrderStatus  = errors.New("aksi tidak dapat dilakukan pada status purchase order saat ini")
+)
+
+// PurchaseOrderService mengelola purchase order dan penerimaan barang. Stok
+// bertambah melalui StockMovementService saat barang diterima.
+type PurchaseOrderService struct {
+	stockMovementService *StockMovementService
+}
+
+func NewPurchaseOrderService() *PurchaseOrderService {
+	return &PurchaseOrderService{
+		stockMovementService: NewStockMovementService(),
+	}
+}
+
+func (service *PurchaseOrderService) GetAll(store casts.StoreContext, filters requests.PurchaseOrderFilterRequest) ([]models.PurchaseOrder, int64, error) {
+	var orders []models.PurchaseOrder
+	var total int64
+
+	query := facades.DB.Model(&models.PurchaseOrder{}).Scopes(scopes.StoreScope(store, "store_id"))
+	if filters.StoreID != nil {
+		query = query.Where("store_id = ?", *filters.StoreID)
+	}
+	if filters.SupplierID != nil {
+		query = query.Where("supplier_id = ?", *filters.SupplierID)
+	}
+	if filters.Status != nil {
+		query = query.Where("status = ?", *filters.Status)
+	}
+	if filters.Search != nil {
+		query = query.Where("reference LIKE ? OR note LIKE ?", "%"+*filters.Search+"%", "%"+*filters.Search+"%")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if filters.OrderBy != nil {
+		direction := "asc"
+		if filters.OrderDirection != nil {
+			direction = *filters.OrderDirection
+		}
+		query = query.Order(*filters.OrderBy + " " + direction)
+	} else {
+		query = query.Order("updated_at desc")
+	}
+
+	if err := query.Preload("Supplier").Scopes(scopes.Paginate(filters.FilterRequest)).Find(&orders).Error; err != nil {
+		return nil, 0, err
+	}
+	return orders, total, nil
+}
+
+func (service *PurchaseOrderService) GetByID(store casts.StoreContext, id string) (models.PurchaseOrder, error) {
+	return service.find(facades.DB, store, id)
+}
+
+func (service *PurchaseOrderService) find(db *gorm.DB, store casts.StoreContext, id any) (models.PurchaseOrder, error) {
+	var order models.PurchaseOrder
+	err := db.Scopes(scopes.StoreScope(store, "store_id")).
+		Preload("Store").
+		Preload("Supplier").
+		Preload("Items.Product").
+		Preload("Receipts.Items").
+		First(&order, id).Error
+	return order, err
+}
+
+// Put membuat atau mengupdate purchase order. Item hanya dapat diubah selama
+// belum ada barang yang diterima, item lama diganti seluruhnya.
+func (service *PurchaseOrderService) Put(store casts.StoreContext, request requests.PurchaseOrderRequestPut) (*models.PurchaseOrder, error) {
+	if !store.CanAccess(request.StoreID) {
+		return nil, ErrStoreForbidden
+	}
+	if request.ID != 0 {
+		existing, err := service.find(facades.DB, store, request.ID)
+		if err != nil {
+			return nil, err
+		}
+		if !existing.Editable() {
+			return nil, ErrPurchaseOrderStatus
+		}
+		if request.Version == 0 {
+			return nil, ErrVersionRequired
+		}
+	}
+
+	var supplier models.Supplier
+	if err := facades.DB.Where("store_id = ?", request.StoreID).Limit(1).Find(&supplier, request.SupplierID).Error; err != nil {
+		return nil, err
+	}
+	if supplier.ID == 0 {
+		return nil, fmt.Errorf("%w: supplier tidak terdaftar di toko", ErrInvalidPurchaseOrder)
+	}
+
+	order := models.PurchaseOrder{
+		ID:         request.ID,
+		StoreID:    request.StoreID,
+		SupplierID: supplier.ID,
+		Status:     models.PurchaseOrderOpen,
+		Currency:   casts.NormalizeCurrency(request.Currency),
+		Note:       request.Note,
+		Version:    request.Version,
+	}
+	if request.ExpectedAt != "" {
+		expectedAt, err := time.ParseInLocation(time.DateOnly, request.ExpectedAt, time.Local)
+		if err != nil {
+			return nil, err
+		}
+		order.ExpectedAt = &expectedAt
+	}
+
+	if err := facades.DB.Transaction(func(tx *gorm.DB) error {
+		items := make([]models.PurchaseOrderItem, 0, len(request.Items))
+		for _, item := range request.Items {
+			var product models.Product
+			if err := tx.Select("id", "name", "currency").Where("store_id = ?", request.StoreID).
+				Limit(1).Find(&product, item.ProductID).Error; err != nil {
+				return err
+			}
+			if product.ID == 0 {
+				return fmt.Errorf("%w: produk %d tidak ditemukan di toko", ErrInvalidPurchaseOrder, item.ProductID)
+			}
+			if casts.NormalizeCurrency(product.Currency) != order.Currency {
+				return fmt.Errorf("%w: %s", ErrCurrencyMismatch, product.Name)
+			}
+
+			subtotal := item.CostPrice.Mul(float64(item.Quantity))
+			items = append(items, models.PurchaseOrderItem{
+				ProductID: product.ID,
+				Quantity:  item.Quantity,
+				CostPrice: item.CostPrice,
+				Subtotal:  subtotal,
+			})
+			order.Total = order.Total.Add(subtotal)
+		}
+
+		if request.ID == 0 {
+			order.CreatedBy = &store.UserID
+			if err := tx.Create(&order).Error; err != nil {
+				return err
+			}
+		} else {
+			if err := tx.Model(&models.PurchaseOrder{}).Where("id = ?", request.ID).
+				Select("store_id", "supplier_id", "currency", "total", "expected_at", "note", "version").
+				Updates(&order).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("purchase_order_id = ?", order.ID).Delete(&models.PurchaseOrderItem{}).Error; err != nil {
+				return err
+			}
+		}
+
+		for i := range items {
+			items[i].PurchaseOrderID = order.ID
+		}
+		return tx.Create(&items).Error
+	}); err != nil {
+		return nil, err
+	}
+
+	result, err := service.find(facades.DB, store, order.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Receive mencatat penerimaan barang untuk sebagian atau seluruh item PO.
+// Setiap item menambah stok melalui ledger, memperbarui ReceivedAt produk dan
+// harga pokok rata-rata tertimbang (CostPrice) produk. Status PO menjadi
+// partially_received atau closed jika seluruh item sudah diterima.
+func (service *PurchaseOrderService) Receive(store casts.StoreContext, id string, request requests.GoodsReceiptRequest) (*models.PurchaseOrder, error) {
+	receivedAt := time.Now()
+	if request.ReceivedAt != "" {
+		parsed, err := time.ParseInLocation(time.DateTime, request.ReceivedAt, time.Local)
+		if err != nil {
+			return nil, err
+		}
+		receivedAt = parsed
+	}
+
+	var orderID uint
+	if err := facades.DB.Transaction(func(tx *gorm.DB) error {
+		order, err := service.find(tx.Clauses(clause.Locking{Strength: "UPDATE"}), store, id)
+		if err != nil {
+			return err
+		}
+		if !order.Receivable() {
+			return ErrPurchaseOrderStatus
+		}
+		orderID = order.ID
+
+		itemByID := make(map[uint]*models.PurchaseOrderItem, len(order.Items))
+		for i := range order.Items {
+			itemByID[order.Items[i].ID] = &order.Items[i]
+		}
+
+		receipt := models.GoodsReceipt{
+			PurchaseOrderID: order.ID,
+			ReceivedAt:      receivedAt,
+			Note:            request.Note,
+			ActorID:         &store.UserID,
+		}
+		for _, line := range request.Items {
+			item, ok := itemByID[line.PurchaseOrderItemID]
+			if !ok {
+				return fmt.Errorf("%w: item %d bukan bagian dari purchase order", ErrInvalidPurchaseOrder, line.PurchaseOrderItemID)
+			}
+			if line.Quantity > item.Outstanding() {
+				return fmt.Errorf("%w: quantity diterima melebihi sisa pesanan item %d", ErrInvalidPurchaseOrder, item.ID)
+			}
+			item.ReceivedQuantity += line.Quantity
+
+			costPrice := line.CostPrice
+			if costPrice == 0 {
+				costPrice = item.CostPrice
+			}
+			receipt.Items = append(receipt.Items, models.GoodsReceiptItem{
+				PurchaseOrderItemID: item.ID,
+				ProductID:           item.ProductID,
+				Quantity:            line.Quantity,
+				CostPrice:           costPrice,
+			})
+		}
+		if err := tx.Create(&receipt).Error; err != nil {
+			return err
+		}
+
+		// produk dikunci berurutan berdasarkan ID agar tidak deadlock dengan transaksi stok lain
+		lines := append([]models.GoodsReceiptItem(nil), receipt.Items...)
+		sort.SliceStable(lines, func(i, j int) bool { return lines[i].ProductID < lines[j].ProductID })
+		for _, line := range lines {
+			if err := service.receiveProduct(tx, receipt, line); err != nil {
+				return err
+			}
+			if err := tx.Model(&models.PurchaseOrderItem{}).Where("id = ?", line.PurchaseOrderItemID).
+				UpdateColumn("received_quantity", gorm.Expr("received_quantity + ?", line.Quantity)).Error; err != nil {
+				return err
+			}
+		}
+
+		updates := map[string]any{"status": models.PurchaseOrderClosed, "closed_at": receivedAt}
+		for _, item := range order.Items {
+			if item.Outstanding() > 0 {
+				updates = map[string]any{"status": models.PurchaseOrderPartiallyReceived}
+				break
+			}
+		}
+		return tx.Model(&models.PurchaseOrder{}).Where("id = ?", order.ID).Updates(updates).Error
+	}); err != nil {
+		return nil, err
+	}
+
+	result, err := service.find(facades.DB, store, orderID)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// receiveProduct menambah stok produk dari satu item penerimaan dan
+// menghitung ulang harga pokok rata-rata tertimbang dari stok sebelum penerimaan.
+func (service *PurchaseOrderService) receiveProduct(tx *gorm.DB, receipt models.GoodsReceipt, line models.GoodsReceiptItem) error {
+	var product models.Product
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "stock", "cost_price").First(&product, line.ProductID).Error; err != nil {
+		return err
+	}
+
+	costPrice := weightedCostPrice(product.Stock, product.CostPrice, line.Quantity, line.CostPrice)
+
+	movement := models.StockMovement{
+		ProductID:         line.ProductID,
+		Type:              models.StockMovementReceipt,
+		Quantity:          line.Quantity,
+		Reason:            "Penerimaan barang",
+		ActorID:           receipt.ActorID,
+		ReferenceDocument: receipt.Reference,
+	}
+	if err := service.stockMovementService.Record(tx, &movement); err != nil {
+		return err
+	}
+
+	return tx.Model(&models.Product{}).Where("id = ?", line.ProductID).UpdateColumns(map[string]any{
+		"cost_price":  costPrice,
+		"received_at": receipt.ReceivedAt,
+	}).Error
+}
+
+// weightedCostPrice menghitung harga pokok rata-rata tertimbang setelah
+// quantity unit dengan harga incoming masuk ke stok yang berharga pokok cost.
+func weightedCostPrice(stock int, cost casts.Money, quantity int, incoming casts.Money) casts.Money {
+	if stock <= 0 {
+		return incoming
+	}
+	value := cost.Mul(float64(stock)).Add(incoming.Mul(float64(quantity)))
+	return value.MulDiv(1, int64(stock+quantity))
+}
+
+// Cancel membatalkan purchase order yang belum menerima barang.
+func (service *PurchaseOrderService) Cancel(store casts.StoreContext, id string) (*models.PurchaseOrder, error) {
+	return service.finish(store, id, models.PurchaseOrderCancelled, models.PurchaseOrderOpen)
+}
+
+// Close menutup purchase order yang sudah diterima sebagian, sisa pesanan
+// tidak akan dikirim supplier.
+func (service *PurchaseOrderService) Close(store casts.StoreContext, id string) (*models.PurchaseOrder, error) {
+	return service.finish(store, id, models.PurchaseOrderClosed, models.PurchaseOrderPartiallyReceived)
+}
+
+func (service *PurchaseOrderService) finish(store casts.StoreContext, id string, status string, from string) (*models.PurchaseOrder, error) {
+	var orderID uint
+	if err := facades.DB.Transaction(func(tx *gorm.DB) error {
+		var order models.PurchaseOrder
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Scopes(scopes.StoreScope(store, "store_id")).
+			First(&order, id).Error; err != nil {
+			return err
+		}
+		if order.Status != from {
+			return ErrPurchaseOrderStatus
+		}
+		orderID = order.ID
+
+		return tx.Model(&order).Updates(map[string]any{"status": status, "closed_at": time.Now()}).Error
+	}); err != nil {
+		return nil, err
+	}
+
+	result, err := service.find(facades.DB, store, orderID)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
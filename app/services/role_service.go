@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"golang_starter_kit_2025/app/models"
+	"golang_starter_kit_2025/app/requests"
 	"golang_starter_kit_2025/facades"
 )
 
@@ -17,23 +18,42 @@ func (*RoleService) GetAll() ([]models.Role, error) {
 	return roles, nil
 }
 
-func (*RoleService) Put(updatedRole models.Role) (models.Role, error) {
+func (*RoleService) Find(id string) (models.Role, error) {
 	var role models.Role
+	if err := facades.DB.First(&role, id).Error; err != nil {
+		return role, err
+	}
+	return role, nil
+}
 
-	if count := facades.DB.Model(&models.Role{}).Where("id = ?", updatedRole.ID).Find(&map[string]interface{}{}).RowsAffected; count == 0 {
-		if err := facades.DB.Create(&updatedRole).Error; err != nil {
-			return role, err
-		}
-	} else {
-		if err := facades.DB.Where("id = ?", updatedRole.ID).Updates(&updatedRole).Error; err != nil {
-			return role, err
-		}
+// Put membuat role baru jika ID kosong atau mengganti seluruh field role yang sudah ada.
+func (*RoleService) Put(request requests.RoleRequestPut) (models.Role, error) {
+	role := models.Role{
+		ID:      request.ID,
+		Name:    request.Name,
+		Group:   request.Group,
+		Version: request.Version,
+	}
 
-		if err := facades.DB.First(&role, updatedRole.ID).Error; err != nil {
+	if request.ID == 0 {
+		if err := facades.DB.Create(&role).Error; err != nil {
 			return role, err
 		}
+		return role, nil
 	}
 
+	if err := facades.DB.Select("id").First(&models.Role{}, request.ID).Error; err != nil {
+		return role, err
+	}
+	if request.Version == 0 {
+		return role, ErrVersionRequired
+	}
+	if err := facades.DB.Model(&models.Role{}).Where("id = ?", request.ID).Select("name", "group", "version").Updates(&role).Error; err != nil {
+		return role, err
+	}
+	if err := facades.DB.First(&role, request.ID).Error; err != nil {
+		return role, err
+	}
 	return role, nil
 }
 
@@ -53,7 +73,7 @@ func (*RoleService) AssignPermissionsToRole(roleId string, permissions []uint) e
 
 	// Validasi permissions sebelum diassign
 	var validPermissions []uint
-	facades.DB.Table("permissions").Where("id IN ?", permissions).Pluck("id", &validPermissions)
+	facades.DB.Model(&models.Permission{}).Where("id IN ?", permissions).Pluck("id", &validPermissions)
 
 	if len(validPermissions) != len(permissions) {
 		return errors.New("one or more permission IDs are invalid")
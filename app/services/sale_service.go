@@ -0,0 +1,394 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"golang_starter_kit_2025/app/casts"
+	"golang_starter_kit_2025/app/helpers"
+	"golang_starter_kit_2025/app/models"
+	"golang_starter_kit_2025/app/models/scopes"
+	"golang_starter_kit_2025/app/requests"
+	"golang_starter_kit_2025/facades"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrInvalidSale         = errors.New("data transaksi tidak valid")
+	ErrInsufficientPayment = errors.New("pembayaran kurang dari total transaksi")
+	ErrSaleNotVoidable     = errors.New("transaksi tidak dapat dibatalkan")
+	ErrSaleNotReturnable   = errors.New("transaksi tidak dapat diretur")
+)
+
+// SaleService mencatat transaksi penjualan. Stok produk hanya berubah melalui
+// StockMovementService sehingga ledger stok selalu memuat nomor struk.
+type SaleService struct {
+	memberService        *MemberService
+	stockMovementService *StockMovementService
+}
+
+func NewSaleService() *SaleService {
+	return &SaleService{
+		memberService:        NewMemberService(),
+		stockMovementService: NewStockMovementService(),
+	}
+}
+
+func (service *SaleService) GetAll(store casts.StoreContext, filters requests.SaleFilterRequest) ([]models.Sale, int64, error) {
+	var sales []models.Sale
+	var total int64
+
+	query := facades.DB.Model(&models.Sale{}).Scopes(scopes.StoreScope(store, "store_id"))
+	if filters.StoreID != nil {
+		query = query.Where("store_id = ?", *filters.StoreID)
+	}
+	if filters.MemberID != nil {
+		query = query.Where("member_id = ?", *filters.MemberID)
+	}
+	if filters.Status != nil {
+		query = query.Where("status = ?", *filters.Status)
+	}
+	if filters.PaymentMethod != nil {
+		query = query.Where("payment_method = ?", *filters.PaymentMethod)
+	}
+	if filters.From != nil {
+		from, err := time.ParseInLocation(time.DateOnly, *filters.From, time.Local)
+		if err != nil {
+			return nil, 0, err
+		}
+		query = query.Where("created_at >= ?", from)
+	}
+	if filters.To != nil {
+		to, err := time.ParseInLocation(time.DateOnly, *filters.To, time.Local)
+		if err != nil {
+			return nil, 0, err
+		}
+		query = query.Where("created_at < ?", to.AddDate(0, 0, 1))
+	}
+	if filters.Search != nil {
+		query = query.Where("reference LIKE ? OR note LIKE ?", "%"+*filters.Search+"%", "%"+*filters.Search+"%")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if filters.OrderBy != nil {
+		direction := "asc"
+		if filters.OrderDirection != nil {
+			direction = *filters.OrderDirection
+		}
+		query = query.Order(*filters.OrderBy + " " + direction)
+	} else {
+		query = query.Order("created_at desc")
+	}
+
+	if err := query.Preload("Member").Preload("Cashier").Scopes(scopes.Paginate(filters.FilterRequest)).Find(&sales).Error; err != nil {
+		return nil, 0, err
+	}
+	return sales, total, nil
+}
+
+func (service *SaleService) GetByID(store casts.StoreContext, id string) (models.Sale, error) {
+	return service.find(facades.DB, store, id)
+}
+
+func (service *SaleService) find(db *gorm.DB, store casts.StoreContext, id any) (models.Sale, error) {
+	var sale models.Sale
+	err := db.Scopes(scopes.StoreScope(store, "store_id")).
+		Preload("Store").
+		Preload("Member").
+		Preload("Cashier").
+		Preload("Items.Product").
+		Preload("Returns.Items").
+		First(&sale, id).Error
+	return sale, err
+}
+
+// taxPercent mengembalikan persentase pajak dari request atau SALE_TAX_PERCENT.
+func (service *SaleService) taxPercent(request requests.SaleRequest) float64 {
+	if request.TaxPercent != nil {
+		return *request.TaxPercent
+	}
+	percent, err := strconv.ParseFloat(helpers.GetEnv("SALE_TAX_PERCENT", "0"), 64)
+	if err != nil {
+		return 0
+	}
+	return percent
+}
+
+// Checkout membuat transaksi penjualan beserta itemnya, mengurangi stok dan
+// menambah Sold produk dalam satu transaksi database. Harga diambil dari harga
+// produk saat ini. Baris produk dikunci berurutan berdasarkan ID sehingga dua
+// checkout yang bersamaan tidak dapat menjual stok yang sama.
+func (service *SaleService) Checkout(store casts.StoreContext, request requests.SaleRequest) (*models.Sale, error) {
+	if !store.CanAccess(request.StoreID) {
+		return nil, ErrStoreForbidden
+	}
+	if request.MemberID != nil {
+		if _, err := service.memberService.find(store, *request.MemberID); err != nil {
+			return nil, err
+		}
+	}
+
+	sale := models.Sale{
+		StoreID:       request.StoreID,
+		MemberID:      request.MemberID,
+		CashierID:     &store.UserID,
+		Status:        models.SaleCompleted,
+		PaymentMethod: request.PaymentMethod,
+		Currency:      casts.NormalizeCurrency(request.Currency),
+		Discount:      request.Discount,
+		TaxPercent:    service.taxPercent(request),
+		Note:          request.Note,
+	}
+
+	if err := facades.DB.Transaction(func(tx *gorm.DB) error {
+		productIDs := make([]uint, 0, len(request.Items))
+		for _, item := range request.Items {
+			productIDs = append(productIDs, item.ProductID)
+		}
+
+		var products []models.Product
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id IN ? AND store_id = ?", productIDs, request.StoreID).
+			Order("id asc").
+			Find(&products).Error; err != nil {
+			return err
+		}
+		productByID := make(map[uint]models.Product, len(products))
+		for _, product := range products {
+			productByID[product.ID] = product
+		}
+
+		for _, item := range request.Items {
+			product, ok := productByID[item.ProductID]
+			if !ok {
+				return fmt.Errorf("%w: produk %d tidak ditemukan di toko", ErrInvalidSale, item.ProductID)
+			}
+			if casts.NormalizeCurrency(product.Currency) != sale.Currency {
+				return fmt.Errorf("%w: %s", ErrCurrencyMismatch, product.Name)
+			}
+
+			gross := product.Price.Mul(float64(item.Quantity))
+			if item.Discount > gross {
+				return fmt.Errorf("%w: diskon %s melebihi harga", ErrInvalidSale, product.Name)
+			}
+			line := models.SaleItem{
+				ProductID: product.ID,
+				Quantity:  item.Quantity,
+				Price:     product.Price,
+				Discount:  item.Discount,
+				Subtotal:  gross.Sub(item.Discount),
+			}
+			sale.Items = append(sale.Items, line)
+			sale.Subtotal = sale.Subtotal.Add(line.Subtotal)
+		}
+
+		if sale.Discount > sale.Subtotal {
+			return fmt.Errorf("%w: diskon melebihi subtotal", ErrInvalidSale)
+		}
+		taxable := sale.Subtotal.Sub(sale.Discount)
+		sale.Tax = taxable.MulDiv(int64(math.Round(sale.TaxPercent*100)), 10000)
+		sale.Total = taxable.Add(sale.Tax)
+
+		sale.Paid = request.Paid
+		if sale.Paid == 0 {
+			sale.Paid = sale.Total
+		}
+		if sale.Paid < sale.Total {
+			return ErrInsufficientPayment
+		}
+		if sale.PaymentMethod != models.PaymentCash && sale.Paid != sale.Total {
+			return fmt.Errorf("%w: pembayaran non tunai harus sama dengan total", ErrInvalidSale)
+		}
+		sale.ChangeAmount = sale.Paid.Sub(sale.Total)
+
+		if err := tx.Create(&sale).Error; err != nil {
+			return err
+		}
+
+		for _, item := range sale.Items {
+			movement := models.StockMovement{
+				ProductID:         item.ProductID,
+				Type:              models.StockMovementSale,
+				Quantity:          -item.Quantity,
+				Reason:            "Penjualan",
+				ActorID:           &store.UserID,
+				ReferenceDocument: sale.Reference,
+			}
+			if err := service.stockMovementService.Record(tx, &movement); err != nil {
+				if errors.Is(err, ErrInsufficientStock) {
+					return fmt.Errorf("%w: %s", err, productByID[item.ProductID].Name)
+				}
+				return err
+			}
+			if err := service.addSold(tx, item.ProductID, item.Quantity); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	result, err := service.find(facades.DB, store, sale.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// addSold menambah (atau mengurangi jika quantity negatif) jumlah terjual produk.
+func (service *SaleService) addSold(tx *gorm.DB, productID uint, quantity int) error {
+	return tx.Model(&models.Product{}).Where("id = ?", productID).
+		UpdateColumn("sold", gorm.Expr("COALESCE(sold, 0) + ?", quantity)).Error
+}
+
+// restock mengembalikan quantity item ke stok produk dan mengurangi Sold.
+func (service *SaleService) restock(tx *gorm.DB, sale models.Sale, item models.SaleItem, quantity int, reason string, actorID uint) error {
+	movement := models.StockMovement{
+		ProductID:         item.ProductID,
+		Type:              models.StockMovementReturn,
+		Quantity:          quantity,
+		Reason:            reason,
+		ActorID:           &actorID,
+		ReferenceDocument: sale.Reference,
+	}
+	if err := service.stockMovementService.Record(tx, &movement); err != nil {
+		return err
+	}
+	return service.addSold(tx, item.ProductID, -quantity)
+}
+
+// Void membatalkan seluruh transaksi yang belum pernah diretur dan
+// mengembalikan stok seluruh item.
+func (service *SaleService) Void(store casts.StoreContext, id string, request requests.SaleVoidRequest) (*models.Sale, error) {
+	var saleID uint
+	if err := facades.DB.Transaction(func(tx *gorm.DB) error {
+		sale, err := service.find(tx.Clauses(clause.Locking{Strength: "UPDATE"}), store, id)
+		if err != nil {
+			return err
+		}
+		if !sale.Voidable() {
+			return ErrSaleNotVoidable
+		}
+		saleID = sale.ID
+
+		for _, item := range sale.Items {
+			if err := service.restock(tx, sale, item, item.Returnable(), "Void transaksi: "+request.Reason, store.UserID); err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		return tx.Model(&models.Sale{}).Where("id = ?", sale.ID).Updates(map[string]any{
+			"status":      models.SaleVoided,
+			"voided_at":   now,
+			"voided_by":   store.UserID,
+			"void_reason": request.Reason,
+		}).Error
+	}); err != nil {
+		return nil, err
+	}
+
+	result, err := service.find(facades.DB, store, saleID)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Return meretur sebagian atau seluruh item transaksi. Dana yang dikembalikan
+// dihitung proporsional terhadap total transaksi (setelah diskon transaksi dan
+// pajak), retur terakhir mengembalikan sisa total sehingga jumlah seluruh
+// retur sama dengan total transaksi.
+func (service *SaleService) Return(store casts.StoreContext, id string, request requests.SaleReturnRequest) (*models.Sale, error) {
+	var saleID uint
+	if err := facades.DB.Transaction(func(tx *gorm.DB) error {
+		sale, err := service.find(tx.Clauses(clause.Locking{Strength: "UPDATE"}), store, id)
+		if err != nil {
+			return err
+		}
+		if !sale.Returnable() {
+			return ErrSaleNotReturnable
+		}
+		saleID = sale.ID
+
+		quantities := map[uint]int{}
+		for _, item := range request.Items {
+			quantities[item.SaleItemID] += item.Quantity
+		}
+
+		saleReturn := models.SaleReturn{
+			SaleID:  sale.ID,
+			Reason:  request.Reason,
+			ActorID: &store.UserID,
+		}
+		remaining := 0
+		for _, item := range sale.Items {
+			quantity, ok := quantities[item.ID]
+			if !ok {
+				remaining += item.Returnable()
+				continue
+			}
+			delete(quantities, item.ID)
+			if quantity > item.Returnable() {
+				return fmt.Errorf("%w: quantity retur melebihi sisa item %d", ErrInvalidSale, item.ID)
+			}
+			remaining += item.Returnable() - quantity
+
+			amount := item.Subtotal.MulDiv(int64(quantity), int64(item.Quantity))
+			if sale.Subtotal != 0 {
+				amount = amount.MulDiv(int64(sale.Total), int64(sale.Subtotal))
+			}
+			saleReturn.Items = append(saleReturn.Items, models.SaleReturnItem{
+				SaleItemID: item.ID,
+				Quantity:   quantity,
+				Amount:     amount,
+			})
+			saleReturn.Amount = saleReturn.Amount.Add(amount)
+
+			if err := tx.Model(&models.SaleItem{}).Where("id = ?", item.ID).
+				UpdateColumn("returned_quantity", gorm.Expr("returned_quantity + ?", quantity)).Error; err != nil {
+				return err
+			}
+			if err := service.restock(tx, sale, item, quantity, "Retur: "+request.Reason, store.UserID); err != nil {
+				return err
+			}
+		}
+		if len(quantities) > 0 {
+			return fmt.Errorf("%w: item retur bukan bagian dari transaksi", ErrInvalidSale)
+		}
+
+		status := models.SalePartiallyReturned
+		if remaining == 0 {
+			// selisih pembulatan dibebankan ke item terakhir
+			status = models.SaleReturned
+			last := &saleReturn.Items[len(saleReturn.Items)-1]
+			last.Amount = last.Amount.Add(sale.Total.Sub(sale.Refunded).Sub(saleReturn.Amount))
+			saleReturn.Amount = sale.Total.Sub(sale.Refunded)
+		}
+		if err := tx.Create(&saleReturn).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&models.Sale{}).Where("id = ?", sale.ID).Updates(map[string]any{
+			"status":   status,
+			"refunded": sale.Refunded.Add(saleReturn.Amount),
+		}).Error
+	}); err != nil {
+		return nil, err
+	}
+
+	result, err := service.find(facades.DB, store, saleID)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,50 @@
+package services_test
+
+import (
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"golang_starter_kit_2025/facades"
+
+	"github.com/joho/godotenv"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const envTestPath = "../../.env.test"
+
+func TestServicesSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Services Test Suite")
+}
+
+// connectTestDB menghubungkan facades.DB ke database pada .env.test. Spec yang
+// membutuhkan database dilewati jika database test tidak dapat dijangkau.
+func connectTestDB() {
+	if err := godotenv.Load(envTestPath); err != nil {
+		Skip("file .env.test tidak ditemukan")
+	}
+	address := net.JoinHostPort(os.Getenv("MYSQL_HOST"), os.Getenv("MYSQL_PORT"))
+	conn, err := net.DialTimeout("tcp", address, time.Second)
+	if err != nil {
+		Skip("database test tidak dapat dijangkau: " + err.Error())
+	}
+	conn.Close()
+
+	facades.ConnectDB(envTestPath)
+}
+
+// migrate menjalankan run (RunMigration atau RollbackMigration) untuk setiap
+// file migrasi. Path migrasi relatif terhadap root project.
+func migrate(run func(filename string) error, filenames ...string) {
+	wd, err := os.Getwd()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(os.Chdir("../..")).To(Succeed())
+	defer os.Chdir(wd)
+
+	for _, filename := range filenames {
+		Expect(run(filename)).To(Succeed())
+	}
+}
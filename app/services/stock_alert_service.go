@@ -0,0 +1,382 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sync"
+	"time"
+
+	"golang_starter_kit_2025/app/casts"
+	"golang_starter_kit_2025/app/helpers"
+	"golang_starter_kit_2025/app/models"
+	"golang_starter_kit_2025/app/models/scopes"
+	"golang_starter_kit_2025/app/requests"
+	"golang_starter_kit_2025/facades"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrInvalidStockLevel = errors.New("batas stok maksimum tidak boleh lebih kecil dari batas minimum")
+
+const NotificationStockAlert = "stock_alert"
+
+// StockAlertHook dipanggil setiap kali alert stok baru dibuat, setelah alert
+// tersimpan. Implementasi dapat mengirim alert ke kanal lain, misalnya
+// notifikasi aplikasi atau publish ke broker MQTT. Error hook hanya dicatat ke
+// log dan tidak membatalkan alert.
+type StockAlertHook interface {
+	StockAlertCreated(alert models.StockAlert) error
+}
+
+var (
+	stockAlertHooksMu sync.RWMutex
+	stockAlertHooks   []StockAlertHook
+)
+
+// RegisterStockAlertHook mendaftarkan hook yang dipanggil untuk setiap alert
+// stok baru. Dipanggil saat aplikasi dimulai.
+func RegisterStockAlertHook(hook StockAlertHook) {
+	stockAlertHooksMu.Lock()
+	defer stockAlertHooksMu.Unlock()
+	stockAlertHooks = append(stockAlertHooks, hook)
+}
+
+// NotificationStockAlertHook mengirim alert stok sebagai notifikasi aplikasi
+// ke seluruh user toko.
+type NotificationStockAlertHook struct {
+	notificationService *NotificationService
+}
+
+func NewNotificationStockAlertHook() *NotificationStockAlertHook {
+	return &NotificationStockAlertHook{
+		notificationService: NewNotificationService(),
+	}
+}
+
+func (hook *NotificationStockAlertHook) StockAlertCreated(alert models.StockAlert) error {
+	var userIDs []uint
+	if err := facades.DB.Model(&models.StoreUser{}).Where("store_id = ?", alert.StoreID).Pluck("user_id", &userIDs).Error; err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("#%d", alert.ProductID)
+	if alert.Product != nil {
+		name = alert.Product.Name
+	}
+	return hook.notificationService.Send(facades.DB, userIDs, models.Notification{
+		Type:  NotificationStockAlert,
+		Title: "Stok menipis",
+		Body:  fmt.Sprintf("Stok %s tersisa %d (minimum %d), saran pemesanan %d", name, alert.Stock, alert.MinStock, alert.SuggestedQuantity),
+		Data: map[string]any{
+			"stock_alert_id":     alert.ID,
+			"store_id":           alert.StoreID,
+			"product_id":         alert.ProductID,
+			"stock":              alert.Stock,
+			"min_stock":          alert.MinStock,
+			"suggested_quantity": alert.SuggestedQuantity,
+		},
+	})
+}
+
+// StockAlertService mengelola batas stok minimum/maksimum dan alert stok
+// menipis. Batas produk mengikuti default toko jika tidak diisi.
+type StockAlertService struct{}
+
+func NewStockAlertService() *StockAlertService {
+	return &StockAlertService{}
+}
+
+func validateStockLevel(request requests.StockLevelRequest) error {
+	if request.MinStock != nil && request.MaxStock != nil && *request.MaxStock < *request.MinStock {
+		return ErrInvalidStockLevel
+	}
+	return nil
+}
+
+// SetProductLevels mengatur batas stok produk, nilai kosong menghapus batas
+// sehingga produk mengikuti default toko.
+func (service *StockAlertService) SetProductLevels(store casts.StoreContext, id string, request requests.StockLevelRequest) (*models.Product, error) {
+	if err := validateStockLevel(request); err != nil {
+		return nil, err
+	}
+
+	var product models.Product
+	if err := facades.DB.Scopes(scopes.StoreScope(store, "store_id")).First(&product, id).Error; err != nil {
+		return nil, err
+	}
+	if err := facades.DB.Model(&product).Updates(map[string]any{
+		"min_stock": request.MinStock,
+		"max_stock": request.MaxStock,
+	}).Error; err != nil {
+		return nil, err
+	}
+
+	if err := facades.DB.Preload("Store").First(&product, product.ID).Error; err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+// SetStoreLevels mengatur batas stok default seluruh produk toko.
+func (service *StockAlertService) SetStoreLevels(store casts.StoreContext, id string, request requests.StockLevelRequest) (*models.Store, error) {
+	if err := validateStockLevel(request); err != nil {
+		return nil, err
+	}
+
+	var target models.Store
+	if err := facades.DB.First(&target, id).Error; err != nil {
+		return nil, err
+	}
+	if !store.CanAccess(target.ID) {
+		return nil, ErrStoreForbidden
+	}
+	if err := facades.DB.Model(&target).Updates(map[string]any{
+		"default_min_stock": request.MinStock,
+		"default_max_stock": request.MaxStock,
+	}).Error; err != nil {
+		return nil, err
+	}
+
+	if err := facades.DB.First(&target, target.ID).Error; err != nil {
+		return nil, err
+	}
+	return &target, nil
+}
+
+func (service *StockAlertService) GetAll(store casts.StoreContext, filters requests.StockAlertFilterRequest) ([]models.StockAlert, int64, error) {
+	var alerts []models.StockAlert
+	var total int64
+
+	query := facades.DB.Model(&models.StockAlert{}).Scopes(scopes.StoreScope(store, "store_id"))
+	if filters.StoreID != nil {
+		query = query.Where("store_id = ?", *filters.StoreID)
+	}
+	if filters.ProductID != nil {
+		query = query.Where("product_id = ?", *filters.ProductID)
+	}
+	if filters.Status != nil {
+		query = query.Where("status = ?", *filters.Status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if filters.OrderBy != nil {
+		direction := "asc"
+		if filters.OrderDirection != nil {
+			direction = *filters.OrderDirection
+		}
+		query = query.Order(*filters.OrderBy + " " + direction)
+	} else {
+		query = query.Order("created_at desc, id desc")
+	}
+
+	if err := query.Preload("Product").Scopes(scopes.Paginate(filters.FilterRequest)).Find(&alerts).Error; err != nil {
+		return nil, 0, err
+	}
+	return alerts, total, nil
+}
+
+// stockLevel adalah stok produk beserta batas efektifnya.
+type stockLevel struct {
+	ProductID uint
+	StoreID   uint
+	Stock     int
+	MinStock  *int
+	MaxStock  *int
+}
+
+// suggestedReorderQuantity menghitung saran jumlah pemesanan ulang. Stok
+// diisi sampai batas maksimum, atau jika tidak ada batas maksimum sampai
+// batas minimum ditambah kebutuhan penjualan selama coverDays hari.
+func suggestedReorderQuantity(stock int, minStock int, maxStock *int, dailySales float64, coverDays int) int {
+	target := minStock + int(math.Ceil(dailySales*float64(coverDays)))
+	if maxStock != nil {
+		target = *maxStock
+	}
+	if target <= stock {
+		return 0
+	}
+	return target - stock
+}
+
+// Check membandingkan stok seluruh produk dengan batas minimumnya. Alert
+// dibuat untuk produk yang stoknya di bawah minimum dan belum memiliki alert
+// open, alert open produk yang stoknya sudah pulih diselesaikan.
+func (service *StockAlertService) Check(now time.Time) (int, int, error) {
+	var levels []stockLevel
+	if err := facades.DB.Table("products").
+		Select("products.id AS product_id, products.store_id, products.stock, " +
+			"COALESCE(products.min_stock, stores.default_min_stock) AS min_stock, " +
+			"COALESCE(products.max_stock, stores.default_max_stock) AS max_stock").
+		Joins("JOIN stores ON stores.id = products.store_id").
+		Where("products.deleted_at IS NULL").
+		Scan(&levels).Error; err != nil {
+		return 0, 0, err
+	}
+
+	var open []models.StockAlert
+	if err := facades.DB.Select("id", "product_id").Where("status = ?", models.StockAlertOpen).Find(&open).Error; err != nil {
+		return 0, 0, err
+	}
+	opened := make(map[uint]uint, len(open))
+	for _, alert := range open {
+		opened[alert.ProductID] = alert.ID
+	}
+
+	velocityDays := helpers.GetEnvInt("STOCK_ALERT_VELOCITY_DAYS", 30)
+	coverDays := helpers.GetEnvInt("STOCK_ALERT_COVER_DAYS", 14)
+	dailySales, err := service.dailySales(now, velocityDays)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	created, resolved := 0, 0
+	var resolve []uint
+	for _, level := range levels {
+		alertID, hasAlert := opened[level.ProductID]
+		delete(opened, level.ProductID)
+
+		below := level.MinStock != nil && level.Stock < *level.MinStock
+		if !below {
+			if hasAlert {
+				resolve = append(resolve, alertID)
+			}
+			continue
+		}
+		if hasAlert {
+			continue
+		}
+
+		alert := models.StockAlert{
+			StoreID:    level.StoreID,
+			ProductID:  level.ProductID,
+			Status:     models.StockAlertOpen,
+			Stock:      level.Stock,
+			MinStock:   *level.MinStock,
+			MaxStock:   level.MaxStock,
+			DailySales: dailySales[level.ProductID],
+		}
+		alert.SuggestedQuantity = suggestedReorderQuantity(level.Stock, *level.MinStock, level.MaxStock, alert.DailySales, coverDays)
+
+		ok, err := service.open(&alert)
+		if err != nil {
+			return created, resolved, err
+		}
+		if ok {
+			created++
+			service.notify(alert)
+		}
+	}
+	// alert open milik produk yang sudah dihapus juga diselesaikan
+	for _, alertID := range opened {
+		resolve = append(resolve, alertID)
+	}
+
+	if len(resolve) > 0 {
+		result := facades.DB.Model(&models.StockAlert{}).
+			Where("id IN ? AND status = ?", resolve, models.StockAlertOpen).
+			Updates(map[string]any{"status": models.StockAlertResolved, "resolved_at": now})
+		if result.Error != nil {
+			return created, resolved, result.Error
+		}
+		resolved = int(result.RowsAffected)
+	}
+	return created, resolved, nil
+}
+
+// open menyimpan alert baru jika produk belum memiliki alert open. Baris
+// produk dikunci sehingga beberapa worker tidak membuat alert ganda.
+func (service *StockAlertService) open(alert *models.StockAlert) (bool, error) {
+	created := false
+	err := facades.DB.Transaction(func(tx *gorm.DB) error {
+		var product models.Product
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&product, alert.ProductID).Error; err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&models.StockAlert{}).
+			Where("product_id = ? AND status = ?", alert.ProductID, models.StockAlertOpen).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+
+		if err := tx.Create(alert).Error; err != nil {
+			return err
+		}
+		created = true
+		return tx.Preload("Product").First(alert, alert.ID).Error
+	})
+	return created, err
+}
+
+// dailySales menghitung rata-rata penjualan harian bersih (penjualan dikurangi
+// retur) setiap produk selama days hari terakhir dari ledger stok.
+func (service *StockAlertService) dailySales(now time.Time, days int) (map[uint]float64, error) {
+	result := map[uint]float64{}
+	if days <= 0 {
+		return result, nil
+	}
+
+	var rows []struct {
+		ProductID uint
+		Sold      int
+	}
+	if err := facades.DB.Model(&models.StockMovement{}).
+		Select("product_id, SUM(-quantity) AS sold").
+		Where("type IN ? AND created_at >= ?", []string{models.StockMovementSale, models.StockMovementReturn}, now.AddDate(0, 0, -days)).
+		Group("product_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		if row.Sold > 0 {
+			result[row.ProductID] = float64(row.Sold) / float64(days)
+		}
+	}
+	return result, nil
+}
+
+func (service *StockAlertService) notify(alert models.StockAlert) {
+	stockAlertHooksMu.RLock()
+	hooks := append([]StockAlertHook(nil), stockAlertHooks...)
+	stockAlertHooksMu.RUnlock()
+
+	for _, hook := range hooks {
+		if err := hook.StockAlertCreated(alert); err != nil {
+			log.Printf("Gagal mengirim alert stok %d: %v\n", alert.ID, err)
+		}
+	}
+}
+
+// RunScheduler menjalankan Check secara berkala setiap
+// STOCK_ALERT_INTERVAL_SECONDS detik. Dijalankan sebagai goroutine oleh server
+// HTTP, nilai interval 0 menonaktifkan worker.
+func (service *StockAlertService) RunScheduler() {
+	interval := helpers.GetEnvInt("STOCK_ALERT_INTERVAL_SECONDS", 3600)
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(time.Duration(interval) * time.Second)
+	defer ticker.Stop()
+	for range ticker.C {
+		created, resolved, err := service.Check(time.Now())
+		if err != nil {
+			log.Println("Gagal memeriksa stok menipis:", err)
+			continue
+		}
+		if created > 0 || resolved > 0 {
+			log.Printf("%d alert stok dibuat, %d diselesaikan\n", created, resolved)
+		}
+	}
+}
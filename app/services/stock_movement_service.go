@@ -0,0 +1,159 @@
+package services
+
+import (
+	"errors"
+
+	"golang_starter_kit_2025/app/casts"
+	"golang_starter_kit_2025/app/models"
+	"golang_starter_kit_2025/app/models/scopes"
+	"golang_starter_kit_2025/app/requests"
+	"golang_starter_kit_2025/app/responses"
+	"golang_starter_kit_2025/facades"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrInsufficientStock = errors.New("stok tidak mencukupi")
+
+type StockMovementService struct{}
+
+func NewStockMovementService() *StockMovementService {
+	return &StockMovementService{}
+}
+
+// findProduct memastikan produk berada di toko yang dapat diakses user.
+func (service *StockMovementService) findProduct(store casts.StoreContext, productID any) (models.Product, error) {
+	var product models.Product
+	err := facades.DB.Scopes(scopes.StoreScope(store, "store_id")).First(&product, productID).Error
+	return product, err
+}
+
+func (service *StockMovementService) Post(store casts.StoreContext, productID uint, actorID uint, request requests.StockMovementRequest) (*models.StockMovement, error) {
+	if _, err := service.findProduct(store, productID); err != nil {
+		return nil, err
+	}
+
+	quantity, err := models.SignedStockQuantity(request.Type, request.Quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	movement := models.StockMovement{
+		ProductID:         productID,
+		Type:              request.Type,
+		Quantity:          quantity,
+		Reason:            request.Reason,
+		ActorID:           &actorID,
+		ReferenceDocument: request.ReferenceDocument,
+	}
+
+	if err := facades.DB.Transaction(func(tx *gorm.DB) error {
+		return service.Record(tx, &movement)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &movement, nil
+}
+
+// Record mencatat movement ke ledger dan memperbarui stok produk dalam
+// transaksi tx. Baris produk dikunci (SELECT ... FOR UPDATE) sehingga movement
+// yang berjalan bersamaan untuk produk yang sama diproses berurutan.
+// Quantity movement harus sudah bertanda, lihat models.SignedStockQuantity.
+func (service *StockMovementService) Record(tx *gorm.DB, movement *models.StockMovement) error {
+	var product models.Product
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&product, movement.ProductID).Error; err != nil {
+		return err
+	}
+
+	movement.StockBefore = product.Stock
+	movement.StockAfter = product.Stock + movement.Quantity
+	if movement.StockAfter < 0 {
+		return ErrInsufficientStock
+	}
+
+	if err := tx.Model(&product).UpdateColumn("stock", movement.StockAfter).Error; err != nil {
+		return err
+	}
+
+	return tx.Create(movement).Error
+}
+
+func (service *StockMovementService) History(store casts.StoreContext, productID string, filters requests.FilterRequest) ([]models.StockMovement, int64, error) {
+	var movements []models.StockMovement
+	var total int64
+
+	if _, err := service.findProduct(store, productID); err != nil {
+		return nil, 0, err
+	}
+
+	query := facades.DB.Model(&models.StockMovement{}).Where("product_id = ?", productID)
+	if filters.Search != nil {
+		query = query.Where("reason LIKE ? OR reference_document LIKE ? OR reference LIKE ?",
+			"%"+*filters.Search+"%", "%"+*filters.Search+"%", "%"+*filters.Search+"%")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Preload("Actor").
+		Order("created_at desc, id desc").
+		Scopes(scopes.Paginate(filters)).
+		Find(&movements).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return movements, total, nil
+}
+
+// Reconcile menghitung ulang stok produk dari ledger. Jika productID bernilai
+// 0 seluruh produk diperiksa. Dengan dryRun, selisih hanya dilaporkan tanpa
+// memperbarui kolom stock.
+func (service *StockMovementService) Reconcile(productID uint, dryRun bool) ([]responses.StockReconciliation, error) {
+	var results []responses.StockReconciliation
+
+	query := facades.DB.Table("products").
+		Select("products.id AS product_id, COALESCE(products.stock, 0) AS stock_before, COALESCE(SUM(stock_movements.quantity), 0) AS stock_after").
+		Joins("LEFT JOIN stock_movements ON stock_movements.product_id = products.id").
+		Where("products.deleted_at IS NULL").
+		Group("products.id, products.stock").
+		Having("COALESCE(products.stock, 0) <> COALESCE(SUM(stock_movements.quantity), 0)")
+	if productID != 0 {
+		query = query.Where("products.id = ?", productID)
+	}
+
+	if err := query.Scan(&results).Error; err != nil {
+		return nil, err
+	}
+
+	if dryRun {
+		return results, nil
+	}
+
+	for i, result := range results {
+		if err := facades.DB.Transaction(func(tx *gorm.DB) error {
+			var product models.Product
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&product, result.ProductID).Error; err != nil {
+				return err
+			}
+
+			var ledger int
+			if err := tx.Model(&models.StockMovement{}).
+				Where("product_id = ?", result.ProductID).
+				Select("COALESCE(SUM(quantity), 0)").
+				Scan(&ledger).Error; err != nil {
+				return err
+			}
+
+			results[i].StockBefore = product.Stock
+			results[i].StockAfter = ledger
+			return tx.Model(&product).UpdateColumn("stock", ledger).Error
+		}); err != nil {
+			return results, err
+		}
+	}
+
+	return results, nil
+}
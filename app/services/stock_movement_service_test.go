@@ -0,0 +1,71 @@
+package services_test
+
+import (
+	"golang_starter_kit_2025/app/database"
+	"golang_starter_kit_2025/app/models"
+	"golang_starter_kit_2025/app/services"
+	"golang_starter_kit_2025/facades"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StockMovementService", Ordered, func() {
+	legacy := []string{
+		"20250426184432_create_users_table",
+		"20250426184608_create_categories_table",
+		"20250426184615_create_products_table",
+	}
+	ledger := "20261019090000_create_stock_movements_table"
+	var productID uint
+
+	BeforeAll(func() {
+		connectTestDB()
+		migrate(database.RunMigration, legacy...)
+
+		// produk yang sudah memiliki stok sebelum tabel ledger dibuat
+		Expect(facades.DB.Exec("INSERT INTO products (reference, name, stock) VALUES (?, ?, ?)", "PRD-LEGACY", "Pupuk NPK", 25).Error).To(Succeed())
+		Expect(facades.DB.Raw("SELECT id FROM products WHERE reference = ?", "PRD-LEGACY").Scan(&productID).Error).To(Succeed())
+
+		migrate(database.RunMigration, ledger)
+	})
+
+	AfterAll(func() {
+		if facades.DB == nil {
+			return
+		}
+		migrate(database.RollbackMigration, ledger, legacy[2], legacy[1], legacy[0])
+		facades.DB.Exec("DELETE FROM migrations WHERE filename IN ?", append(legacy, ledger))
+	})
+
+	Context("when product was created before the ledger", func() {
+		It("should record the existing stock as opening balance", func() {
+			var movements []models.StockMovement
+			Expect(facades.DB.Where("product_id = ?", productID).Find(&movements).Error).To(Succeed())
+			Expect(movements).To(HaveLen(1))
+			Expect(movements[0].Type).To(Equal(models.StockMovementAdjustment))
+			Expect(movements[0].Quantity).To(Equal(25))
+			Expect(movements[0].StockAfter).To(Equal(25))
+		})
+
+		It("should keep the stock when reconciling", func() {
+			results, err := services.NewStockMovementService().Reconcile(productID, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(BeEmpty())
+
+			var stock int
+			Expect(facades.DB.Raw("SELECT stock FROM products WHERE id = ?", productID).Scan(&stock).Error).To(Succeed())
+			Expect(stock).To(Equal(25))
+		})
+
+		It("should restore the ledger stock when the stock column drifted", func() {
+			Expect(facades.DB.Exec("UPDATE products SET stock = 30 WHERE id = ?", productID).Error).To(Succeed())
+
+			results, err := services.NewStockMovementService().Reconcile(productID, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(HaveLen(1))
+			Expect(results[0].StockBefore).To(Equal(30))
+			Expect(results[0].StockAfter).To(Equal(25))
+		})
+	})
+})
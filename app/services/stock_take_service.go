@@ -0,0 +1,461 @@
+package services
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang_starter_kit_2025/app/casts"
+	"golang_starter_kit_2025/app/models"
+	"golang_starter_kit_2025/app/models/scopes"
+	"golang_starter_kit_2025/app/requests"
+	"golang_starter_kit_2025/facades"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrInvalidStockTake = errors.New("data stock opname tidak valid")
+	ErrStockTakeStatus  = errors.New("aksi tidak dapat dilakukan pada status stock opname saat ini")
+)
+
+// StockTakeService mengelola sesi stock opname: snapshot stok sistem,
+// pencatatan hasil hitung, approval dan posting adjustment ke ledger stok.
+type StockTakeService struct {
+	stockMovementService *StockMovementService
+	userService          UserService
+}
+
+func NewStockTakeService() *StockTakeService {
+	return &StockTakeService{
+		stockMovementService: NewStockMovementService(),
+		userService:          UserService{},
+	}
+}
+
+func (service *StockTakeService) can(store casts.StoreContext, permission string) (bool, error) {
+	if store.IsAdmin {
+		return true, nil
+	}
+	return service.userService.HasPermission(store.UserID, permission)
+}
+
+func (service *StockTakeService) GetAll(store casts.StoreContext, filters requests.StockTakeFilterRequest) ([]models.StockTake, int64, error) {
+	var takes []models.StockTake
+	var total int64
+
+	query := facades.DB.Model(&models.StockTake{}).Scopes(scopes.StoreScope(store, "store_id"))
+	if filters.StoreID != nil {
+		query = query.Where("store_id = ?", *filters.StoreID)
+	}
+	if filters.Status != nil {
+		query = query.Where("status = ?", *filters.Status)
+	}
+	if filters.Search != nil {
+		query = query.Where("reference LIKE ? OR note LIKE ?", "%"+*filters.Search+"%", "%"+*filters.Search+"%")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if filters.OrderBy != nil {
+		direction := "asc"
+		if filters.OrderDirection != nil {
+			direction = *filters.OrderDirection
+		}
+		query = query.Order(*filters.OrderBy + " " + direction)
+	} else {
+		query = query.Order("created_at desc")
+	}
+
+	if err := query.Preload("Store").Scopes(scopes.Paginate(filters.FilterRequest)).Find(&takes).Error; err != nil {
+		return nil, 0, err
+	}
+	return takes, total, nil
+}
+
+func (service *StockTakeService) GetByID(store casts.StoreContext, id string) (models.StockTake, error) {
+	return service.find(facades.DB, store, id)
+}
+
+func (service *StockTakeService) find(db *gorm.DB, store casts.StoreContext, id any) (models.StockTake, error) {
+	var take models.StockTake
+	err := db.Scopes(scopes.StoreScope(store, "store_id")).
+		Preload("Store").
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("product_id asc") }).
+		Preload("Items.Product").
+		First(&take, id).Error
+	return take, err
+}
+
+// lock mengunci sesi stock opname dalam transaksi tx dan memastikan statusnya
+// salah satu dari statuses.
+func (service *StockTakeService) lock(tx *gorm.DB, store casts.StoreContext, id any, statuses ...string) (models.StockTake, error) {
+	var take models.StockTake
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Scopes(scopes.StoreScope(store, "store_id")).
+		First(&take, id).Error; err != nil {
+		return take, err
+	}
+	for _, status := range statuses {
+		if take.Status == status {
+			return take, nil
+		}
+	}
+	return take, ErrStockTakeStatus
+}
+
+// Create membuka sesi stock opname dan membekukan stok sistem seluruh produk
+// toko sebagai snapshot. Satu toko hanya boleh memiliki satu sesi aktif, baris
+// toko dikunci agar dua request bersamaan tidak sama-sama membuka sesi.
+func (service *StockTakeService) Create(store casts.StoreContext, request requests.StockTakeRequest) (*models.StockTake, error) {
+	if !store.CanAccess(request.StoreID) {
+		return nil, ErrStoreForbidden
+	}
+
+	take := models.StockTake{
+		StoreID:   request.StoreID,
+		Status:    models.StockTakeCounting,
+		Note:      request.Note,
+		CreatedBy: &store.UserID,
+	}
+	if err := facades.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&models.Store{}, request.StoreID).Error; err != nil {
+			return err
+		}
+
+		var active int64
+		if err := tx.Model(&models.StockTake{}).
+			Where("store_id = ? AND status IN ?", request.StoreID, []string{models.StockTakeCounting, models.StockTakeSubmitted}).
+			Count(&active).Error; err != nil {
+			return err
+		}
+		if active > 0 {
+			return fmt.Errorf("%w: toko masih memiliki sesi stock opname aktif", ErrStockTakeStatus)
+		}
+
+		if err := tx.Create(&take).Error; err != nil {
+			return err
+		}
+
+		var products []models.Product
+		if err := tx.Select("id", "stock", "cost_price").Where("store_id = ?", request.StoreID).Find(&products).Error; err != nil {
+			return err
+		}
+		if len(products) == 0 {
+			return fmt.Errorf("%w: toko belum memiliki produk", ErrInvalidStockTake)
+		}
+
+		items := make([]models.StockTakeItem, 0, len(products))
+		for _, product := range products {
+			items = append(items, models.StockTakeItem{
+				StockTakeID:    take.ID,
+				ProductID:      product.ID,
+				SystemQuantity: product.Stock,
+				CostPrice:      product.CostPrice,
+			})
+		}
+		return tx.CreateInBatches(&items, 500).Error
+	}); err != nil {
+		return nil, err
+	}
+
+	result, err := service.find(facades.DB, store, take.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// count memperbarui hasil hitung satu produk. quantity menerima hasil hitung
+// saat ini (nil jika belum dihitung) dan mengembalikan hasil hitung baru.
+func (service *StockTakeService) count(tx *gorm.DB, store casts.StoreContext, take models.StockTake, productID uint, quantity func(current *int) int) error {
+	var item models.StockTakeItem
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("stock_take_id = ? AND product_id = ?", take.ID, productID).
+		Limit(1).Find(&item).Error; err != nil {
+		return err
+	}
+	if item.ID == 0 {
+		return fmt.Errorf("%w: produk %d tidak termasuk dalam stock opname", ErrInvalidStockTake, productID)
+	}
+
+	counted := quantity(item.CountedQuantity)
+	if counted < 0 {
+		return fmt.Errorf("%w: hasil hitung produk %d tidak boleh negatif", ErrInvalidStockTake, productID)
+	}
+	return tx.Model(&item).UpdateColumns(map[string]any{
+		"counted_quantity": counted,
+		"variance":         counted - item.SystemQuantity,
+		"counted_by":       store.UserID,
+		"counted_at":       time.Now(),
+	}).Error
+}
+
+// Count menyimpan hasil hitung beberapa produk sekaligus, hasil hitung
+// sebelumnya ditimpa.
+func (service *StockTakeService) Count(store casts.StoreContext, id string, request requests.StockTakeCountRequest) (*models.StockTake, error) {
+	var takeID uint
+	if err := facades.DB.Transaction(func(tx *gorm.DB) error {
+		take, err := service.lock(tx, store, id, models.StockTakeCounting)
+		if err != nil {
+			return err
+		}
+		takeID = take.ID
+
+		for _, line := range request.Items {
+			counted := *line.CountedQuantity
+			if err := service.count(tx, store, take, line.ProductID, func(*int) int { return counted }); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	result, err := service.find(facades.DB, store, takeID)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Upload membaca hasil hitung dari file CSV dengan kolom produk (ID atau
+// reference) dan quantity. Baris pertama diabaikan jika berupa header.
+func (service *StockTakeService) Upload(store casts.StoreContext, id string, file io.Reader) (*models.StockTake, error) {
+	var take models.StockTake
+	if err := facades.DB.Select("id", "store_id").Scopes(scopes.StoreScope(store, "store_id")).First(&take, id).Error; err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidStockTake, err.Error())
+	}
+
+	var request requests.StockTakeCountRequest
+	for i, record := range records {
+		if len(record) < 2 {
+			return nil, fmt.Errorf("%w: baris %d harus berisi produk dan quantity", ErrInvalidStockTake, i+1)
+		}
+		counted, err := strconv.Atoi(strings.TrimSpace(record[1]))
+		if err != nil {
+			if i == 0 {
+				continue
+			}
+			return nil, fmt.Errorf("%w: quantity baris %d tidak valid", ErrInvalidStockTake, i+1)
+		}
+
+		productID, err := service.resolveProduct(take.StoreID, strings.TrimSpace(record[0]))
+		if err != nil {
+			return nil, fmt.Errorf("%w (baris %d)", err, i+1)
+		}
+		request.Items = append(request.Items, requests.StockTakeCountItemRequest{ProductID: productID, CountedQuantity: &counted})
+	}
+	if len(request.Items) == 0 {
+		return nil, fmt.Errorf("%w: file tidak berisi hasil hitung", ErrInvalidStockTake)
+	}
+
+	return service.Count(store, id, request)
+}
+
+// resolveProduct mencari ID produk toko berdasarkan ID atau reference.
+func (service *StockTakeService) resolveProduct(storeID uint, code string) (uint, error) {
+	var product models.Product
+	query := facades.DB.Select("id").Where("store_id = ?", storeID).Limit(1)
+	if productID, err := strconv.ParseUint(code, 10, 64); err == nil {
+		query = query.Where("id = ?", productID)
+	} else {
+		query = query.Where("reference = ?", code)
+	}
+	if err := query.Find(&product).Error; err != nil {
+		return 0, err
+	}
+	if product.ID == 0 {
+		return 0, fmt.Errorf("%w: produk %s tidak ditemukan", ErrInvalidStockTake, code)
+	}
+	return product.ID, nil
+}
+
+// Scan menambah hasil hitung satu produk secara bertahap, misalnya dari
+// pemindai. Quantity default 1.
+func (service *StockTakeService) Scan(store casts.StoreContext, id string, request requests.StockTakeScanRequest) (*models.StockTakeItem, error) {
+	quantity := request.Quantity
+	if quantity == 0 {
+		quantity = 1
+	}
+
+	var item models.StockTakeItem
+	if err := facades.DB.Transaction(func(tx *gorm.DB) error {
+		take, err := service.lock(tx, store, id, models.StockTakeCounting)
+		if err != nil {
+			return err
+		}
+
+		productID := request.ProductID
+		if productID == 0 {
+			if productID, err = service.resolveProduct(take.StoreID, request.Reference); err != nil {
+				return err
+			}
+		}
+
+		if err := service.count(tx, store, take, productID, func(current *int) int {
+			if current == nil {
+				return quantity
+			}
+			return *current + quantity
+		}); err != nil {
+			return err
+		}
+		return tx.Preload("Product").Where("stock_take_id = ? AND product_id = ?", take.ID, productID).First(&item).Error
+	}); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// Submit mengajukan hasil hitung untuk disetujui. Produk yang belum dihitung
+// tidak ikut disesuaikan.
+func (service *StockTakeService) Submit(store casts.StoreContext, id string) (*models.StockTake, error) {
+	return service.transition(store, id, func(tx *gorm.DB, take models.StockTake) (map[string]any, error) {
+		var counted int64
+		if err := tx.Model(&models.StockTakeItem{}).
+			Where("stock_take_id = ? AND counted_quantity IS NOT NULL", take.ID).
+			Count(&counted).Error; err != nil {
+			return nil, err
+		}
+		if counted == 0 {
+			return nil, fmt.Errorf("%w: belum ada produk yang dihitung", ErrInvalidStockTake)
+		}
+		return map[string]any{
+			"status":       models.StockTakeSubmitted,
+			"submitted_by": store.UserID,
+			"submitted_at": time.Now(),
+		}, nil
+	}, models.StockTakeCounting)
+}
+
+// Approve menyetujui hasil hitung dan memposting selisih hasil hitung terhadap
+// stok saat approval sebagai movement adjustment, sehingga penjualan atau
+// penerimaan selama penghitungan tidak tercatat dua kali. Stok sistem dan
+// selisih item diperbarui sesuai yang diposting. User yang mengajukan hasil
+// hitung tidak boleh menyetujuinya sendiri.
+func (service *StockTakeService) Approve(store casts.StoreContext, id string) (*models.StockTake, error) {
+	if allowed, err := service.can(store, models.PermissionStockTakeApprove); err != nil {
+		return nil, err
+	} else if !allowed {
+		return nil, ErrPermissionDenied
+	}
+
+	return service.transition(store, id, func(tx *gorm.DB, take models.StockTake) (map[string]any, error) {
+		if take.SubmittedBy != nil && *take.SubmittedBy == store.UserID {
+			return nil, fmt.Errorf("%w: hasil hitung tidak dapat disetujui oleh user yang mengajukannya", ErrPermissionDenied)
+		}
+
+		var items []models.StockTakeItem
+		if err := tx.Where("stock_take_id = ? AND counted_quantity IS NOT NULL", take.ID).
+			Find(&items).Error; err != nil {
+			return nil, err
+		}
+
+		// produk dikunci berurutan berdasarkan ID agar tidak deadlock dengan transaksi stok lain
+		sort.Slice(items, func(i, j int) bool { return items[i].ProductID < items[j].ProductID })
+		for _, item := range items {
+			var product models.Product
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "stock").First(&product, item.ProductID).Error; err != nil {
+				return nil, err
+			}
+
+			variance := *item.CountedQuantity - product.Stock
+			if err := tx.Model(&item).UpdateColumns(map[string]any{
+				"system_quantity": product.Stock,
+				"variance":        variance,
+			}).Error; err != nil {
+				return nil, err
+			}
+			if variance == 0 {
+				continue
+			}
+
+			movement := models.StockMovement{
+				ProductID:         item.ProductID,
+				Type:              models.StockMovementAdjustment,
+				Quantity:          variance,
+				Reason:            "Stock opname",
+				ActorID:           &store.UserID,
+				ReferenceDocument: take.Reference,
+			}
+			if err := service.stockMovementService.Record(tx, &movement); err != nil {
+				return nil, err
+			}
+		}
+
+		return map[string]any{
+			"status":      models.StockTakeApproved,
+			"approved_by": store.UserID,
+			"approved_at": time.Now(),
+		}, nil
+	}, models.StockTakeSubmitted)
+}
+
+// Reject mengembalikan sesi ke tahap penghitungan dengan komentar approver.
+func (service *StockTakeService) Reject(store casts.StoreContext, id string, request requests.StockTakeTransitionRequest) (*models.StockTake, error) {
+	if request.Comment == "" {
+		return nil, fmt.Errorf("%w: komentar wajib diisi saat menolak stock opname", ErrInvalidStockTake)
+	}
+	if allowed, err := service.can(store, models.PermissionStockTakeApprove); err != nil {
+		return nil, err
+	} else if !allowed {
+		return nil, ErrPermissionDenied
+	}
+
+	return service.transition(store, id, func(tx *gorm.DB, take models.StockTake) (map[string]any, error) {
+		return map[string]any{
+			"status":         models.StockTakeCounting,
+			"review_comment": request.Comment,
+		}, nil
+	}, models.StockTakeSubmitted)
+}
+
+// Cancel membatalkan sesi yang belum disetujui tanpa mengubah stok.
+func (service *StockTakeService) Cancel(store casts.StoreContext, id string) (*models.StockTake, error) {
+	return service.transition(store, id, func(tx *gorm.DB, take models.StockTake) (map[string]any, error) {
+		return map[string]any{"status": models.StockTakeCancelled}, nil
+	}, models.StockTakeCounting, models.StockTakeSubmitted)
+}
+
+func (service *StockTakeService) transition(store casts.StoreContext, id string, apply func(tx *gorm.DB, take models.StockTake) (map[string]any, error), from ...string) (*models.StockTake, error) {
+	var takeID uint
+	if err := facades.DB.Transaction(func(tx *gorm.DB) error {
+		take, err := service.lock(tx, store, id, from...)
+		if err != nil {
+			return err
+		}
+		takeID = take.ID
+
+		updates, err := apply(tx, take)
+		if err != nil {
+			return err
+		}
+		return tx.Model(&models.StockTake{}).Where("id = ?", take.ID).Updates(updates).Error
+	}); err != nil {
+		return nil, err
+	}
+
+	result, err := service.find(facades.DB, store, takeID)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,406 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"golang_starter_kit_2025/app/casts"
+	"golang_starter_kit_2025/app/models"
+	"golang_starter_kit_2025/app/models/scopes"
+	"golang_starter_kit_2025/app/requests"
+	"golang_starter_kit_2025/app/responses"
+	"golang_starter_kit_2025/facades"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrInvalidStockTransfer = errors.New("data transfer stok tidak valid")
+	ErrStockTransferStatus  = errors.New("aksi tidak dapat dilakukan pada status transfer saat ini")
+)
+
+// StockTransferService mengelola transfer stok antar toko. Setiap langkah
+// mensyaratkan user terdaftar di toko yang bersangkutan: toko asal untuk
+// membuat dan mengirim, toko tujuan untuk menerima.
+type StockTransferService struct {
+	stockMovementService *StockMovementService
+}
+
+func NewStockTransferService() *StockTransferService {
+	return &StockTransferService{
+		stockMovementService: NewStockMovementService(),
+	}
+}
+
+func (service *StockTransferService) GetAll(store casts.StoreContext, filters requests.StockTransferFilterRequest) ([]models.StockTransfer, int64, error) {
+	var transfers []models.StockTransfer
+	var total int64
+
+	query := facades.DB.Model(&models.StockTransfer{}).Scopes(scopes.StoreScopeAny(store, "from_store_id", "to_store_id"))
+	if filters.StoreID != nil {
+		query = query.Where("from_store_id = ? OR to_store_id = ?", *filters.StoreID, *filters.StoreID)
+	}
+	if filters.Status != nil {
+		query = query.Where("status = ?", *filters.Status)
+	}
+	if filters.Search != nil {
+		query = query.Where("reference LIKE ? OR note LIKE ?", "%"+*filters.Search+"%", "%"+*filters.Search+"%")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if filters.OrderBy != nil {
+		direction := "asc"
+		if filters.OrderDirection != nil {
+			direction = *filters.OrderDirection
+		}
+		query = query.Order(*filters.OrderBy + " " + direction)
+	} else {
+		query = query.Order("updated_at desc")
+	}
+
+	if err := query.Preload("FromStore").Preload("ToStore").
+		Scopes(scopes.Paginate(filters.FilterRequest)).
+		Find(&transfers).Error; err != nil {
+		return nil, 0, err
+	}
+	return transfers, total, nil
+}
+
+func (service *StockTransferService) GetByID(store casts.StoreContext, id string) (models.StockTransfer, error) {
+	return service.find(facades.DB, store, id)
+}
+
+func (service *StockTransferService) find(db *gorm.DB, store casts.StoreContext, id any) (models.StockTransfer, error) {
+	var transfer models.StockTransfer
+	err := db.Scopes(scopes.StoreScopeAny(store, "from_store_id", "to_store_id")).
+		Preload("FromStore").
+		Preload("ToStore").
+		Preload("Items.Product").
+		Preload("Items.ToProduct").
+		First(&transfer, id).Error
+	return transfer, err
+}
+
+// Put membuat atau mengupdate transfer berstatus draft. Produk tujuan yang
+// tidak diisi dicari berdasarkan nama produk asal di toko tujuan.
+func (service *StockTransferService) Put(store casts.StoreContext, request requests.StockTransferRequestPut) (*models.StockTransfer, error) {
+	if !store.CanAccess(request.FromStoreID) || !store.CanAccess(request.ToStoreID) {
+		return nil, ErrStoreForbidden
+	}
+	if request.ID != 0 {
+		existing, err := service.find(facades.DB, store, request.ID)
+		if err != nil {
+			return nil, err
+		}
+		if existing.Status != models.StockTransferDraft {
+			return nil, ErrStockTransferStatus
+		}
+		if request.Version == 0 {
+			return nil, ErrVersionRequired
+		}
+	}
+
+	transfer := models.StockTransfer{
+		ID:          request.ID,
+		FromStoreID: request.FromStoreID,
+		ToStoreID:   request.ToStoreID,
+		Status:      models.StockTransferDraft,
+		Note:        request.Note,
+		Version:     request.Version,
+	}
+
+	if err := facades.DB.Transaction(func(tx *gorm.DB) error {
+		items := make([]models.StockTransferItem, 0, len(request.Items))
+		for _, item := range request.Items {
+			var product models.Product
+			if err := tx.Select("id", "name", "currency").Where("store_id = ?", request.FromStoreID).
+				Limit(1).Find(&product, item.ProductID).Error; err != nil {
+				return err
+			}
+			if product.ID == 0 {
+				return fmt.Errorf("%w: produk %d tidak ditemukan di toko asal", ErrInvalidStockTransfer, item.ProductID)
+			}
+
+			var target models.Product
+			query := tx.Select("id", "name", "currency").Where("store_id = ?", request.ToStoreID).Limit(1)
+			if item.ToProductID != 0 {
+				query = query.Where("id = ?", item.ToProductID)
+			} else {
+				query = query.Where("name = ?", product.Name).Order("id asc")
+			}
+			if err := query.Find(&target).Error; err != nil {
+				return err
+			}
+			if target.ID == 0 {
+				return fmt.Errorf("%w: produk %s tidak ditemukan di toko tujuan", ErrInvalidStockTransfer, product.Name)
+			}
+			if casts.NormalizeCurrency(product.Currency) != casts.NormalizeCurrency(target.Currency) {
+				return fmt.Errorf("%w: %s", ErrCurrencyMismatch, product.Name)
+			}
+
+			items = append(items, models.StockTransferItem{
+				ProductID:   product.ID,
+				ToProductID: target.ID,
+				Quantity:    item.Quantity,
+			})
+		}
+
+		if request.ID == 0 {
+			transfer.CreatedBy = &store.UserID
+			if err := tx.Create(&transfer).Error; err != nil {
+				return err
+			}
+		} else {
+			if err := tx.Model(&models.StockTransfer{}).Where("id = ?", request.ID).
+				Select("from_store_id", "to_store_id", "note", "version").
+				Updates(&transfer).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("stock_transfer_id = ?", transfer.ID).Delete(&models.StockTransferItem{}).Error; err != nil {
+				return err
+			}
+		}
+
+		for i := range items {
+			items[i].StockTransferID = transfer.ID
+		}
+		return tx.Create(&items).Error
+	}); err != nil {
+		return nil, err
+	}
+
+	result, err := service.find(facades.DB, store, transfer.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Dispatch mengirim transfer: stok toko asal berkurang melalui ledger dan
+// harga pokok produk asal dicatat pada item. Barang berstatus in transit
+// sampai diterima toko tujuan.
+func (service *StockTransferService) Dispatch(store casts.StoreContext, id string) (*models.StockTransfer, error) {
+	var transferID uint
+	if err := facades.DB.Transaction(func(tx *gorm.DB) error {
+		transfer, err := service.find(tx.Clauses(clause.Locking{Strength: "UPDATE"}), store, id)
+		if err != nil {
+			return err
+		}
+		if !store.CanAccess(transfer.FromStoreID) {
+			return ErrStoreForbidden
+		}
+		if transfer.Status != models.StockTransferDraft {
+			return ErrStockTransferStatus
+		}
+		transferID = transfer.ID
+
+		// produk dikunci berurutan berdasarkan ID agar tidak deadlock dengan transaksi stok lain
+		items := append([]models.StockTransferItem(nil), transfer.Items...)
+		sort.SliceStable(items, func(i, j int) bool { return items[i].ProductID < items[j].ProductID })
+		for _, item := range items {
+			movement := models.StockMovement{
+				ProductID:         item.ProductID,
+				Type:              models.StockMovementTransfer,
+				Quantity:          -item.Quantity,
+				Reason:            "Transfer keluar ke " + transfer.ToStore.Name,
+				ActorID:           &store.UserID,
+				ReferenceDocument: transfer.Reference,
+			}
+			if err := service.stockMovementService.Record(tx, &movement); err != nil {
+				return err
+			}
+
+			var product models.Product
+			if err := tx.Select("id", "cost_price").First(&product, item.ProductID).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&models.StockTransferItem{}).Where("id = ?", item.ID).
+				UpdateColumn("cost_price", product.CostPrice).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&models.StockTransfer{}).Where("id = ?", transfer.ID).Updates(map[string]any{
+			"status":        models.StockTransferInTransit,
+			"dispatched_by": store.UserID,
+			"dispatched_at": time.Now(),
+		}).Error
+	}); err != nil {
+		return nil, err
+	}
+
+	result, err := service.find(facades.DB, store, transferID)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Receive menerima transfer di toko tujuan. Item yang tidak dicantumkan pada
+// request dianggap diterima penuh. Quantity diterima tidak boleh melebihi
+// quantity dikirim, selisih kurang wajib diberi catatan dan dianggap hilang
+// dalam perjalanan (tidak dikembalikan ke toko asal).
+func (service *StockTransferService) Receive(store casts.StoreContext, id string, request requests.StockTransferReceiveRequest) (*models.StockTransfer, error) {
+	var transferID uint
+	if err := facades.DB.Transaction(func(tx *gorm.DB) error {
+		transfer, err := service.find(tx.Clauses(clause.Locking{Strength: "UPDATE"}), store, id)
+		if err != nil {
+			return err
+		}
+		if !store.CanAccess(transfer.ToStoreID) {
+			return ErrStoreForbidden
+		}
+		if transfer.Status != models.StockTransferInTransit {
+			return ErrStockTransferStatus
+		}
+		transferID = transfer.ID
+
+		itemIDs := make(map[uint]bool, len(transfer.Items))
+		for _, item := range transfer.Items {
+			itemIDs[item.ID] = true
+		}
+		lines := make(map[uint]requests.StockTransferReceiveItemRequest, len(request.Items))
+		for _, line := range request.Items {
+			if !itemIDs[line.StockTransferItemID] {
+				return fmt.Errorf("%w: item %d bukan bagian dari transfer", ErrInvalidStockTransfer, line.StockTransferItemID)
+			}
+			lines[line.StockTransferItemID] = line
+		}
+
+		hasDiscrepancy := false
+		items := append([]models.StockTransferItem(nil), transfer.Items...)
+		for i := range items {
+			received := items[i].Quantity
+			if line, ok := lines[items[i].ID]; ok {
+				received = *line.ReceivedQuantity
+				items[i].DiscrepancyNote = line.DiscrepancyNote
+			}
+			if received > items[i].Quantity {
+				return fmt.Errorf("%w: quantity diterima melebihi quantity dikirim item %d", ErrInvalidStockTransfer, items[i].ID)
+			}
+			if received != items[i].Quantity {
+				if items[i].DiscrepancyNote == "" {
+					return fmt.Errorf("%w: catatan selisih wajib diisi untuk item %d", ErrInvalidStockTransfer, items[i].ID)
+				}
+				hasDiscrepancy = true
+			}
+			items[i].ReceivedQuantity = &received
+		}
+
+		receivedAt := time.Now()
+		sort.SliceStable(items, func(i, j int) bool { return items[i].ToProductID < items[j].ToProductID })
+		for _, item := range items {
+			if *item.ReceivedQuantity > 0 {
+				if err := service.receiveProduct(tx, store, transfer, item, receivedAt); err != nil {
+					return err
+				}
+			}
+			if err := tx.Model(&models.StockTransferItem{}).Where("id = ?", item.ID).UpdateColumns(map[string]any{
+				"received_quantity": *item.ReceivedQuantity,
+				"discrepancy_note":  item.DiscrepancyNote,
+			}).Error; err != nil {
+				return err
+			}
+		}
+
+		note := transfer.Note
+		if request.Note != "" {
+			note = request.Note
+		}
+		return tx.Model(&models.StockTransfer{}).Where("id = ?", transfer.ID).Updates(map[string]any{
+			"status":          models.StockTransferReceived,
+			"has_discrepancy": hasDiscrepancy,
+			"note":            note,
+			"received_by":     store.UserID,
+			"received_at":     receivedAt,
+		}).Error
+	}); err != nil {
+		return nil, err
+	}
+
+	result, err := service.find(facades.DB, store, transferID)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// receiveProduct menambah stok produk tujuan dan menghitung ulang harga
+// pokok rata-rata tertimbangnya dengan harga pokok produk asal.
+func (service *StockTransferService) receiveProduct(tx *gorm.DB, store casts.StoreContext, transfer models.StockTransfer, item models.StockTransferItem, receivedAt time.Time) error {
+	var product models.Product
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "stock", "cost_price").First(&product, item.ToProductID).Error; err != nil {
+		return err
+	}
+	costPrice := weightedCostPrice(product.Stock, product.CostPrice, *item.ReceivedQuantity, item.CostPrice)
+
+	movement := models.StockMovement{
+		ProductID:         item.ToProductID,
+		Type:              models.StockMovementTransfer,
+		Quantity:          *item.ReceivedQuantity,
+		Reason:            "Transfer masuk dari " + transfer.FromStore.Name,
+		ActorID:           &store.UserID,
+		ReferenceDocument: transfer.Reference,
+	}
+	if err := service.stockMovementService.Record(tx, &movement); err != nil {
+		return err
+	}
+
+	return tx.Model(&models.Product{}).Where("id = ?", item.ToProductID).UpdateColumns(map[string]any{
+		"cost_price":  costPrice,
+		"received_at": receivedAt,
+	}).Error
+}
+
+// Cancel membatalkan transfer yang belum dikirim.
+func (service *StockTransferService) Cancel(store casts.StoreContext, id string) (*models.StockTransfer, error) {
+	transfer, err := service.find(facades.DB, store, id)
+	if err != nil {
+		return nil, err
+	}
+	if !store.CanAccess(transfer.FromStoreID) {
+		return nil, ErrStoreForbidden
+	}
+
+	result := facades.DB.Model(&models.StockTransfer{}).
+		Where("id = ? AND status = ?", transfer.ID, models.StockTransferDraft).
+		Update("status", models.StockTransferCancelled)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrStockTransferStatus
+	}
+
+	transfer, err = service.find(facades.DB, store, transfer.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &transfer, nil
+}
+
+// InTransit mengembalikan jumlah stok yang sedang dikirim per produk tujuan.
+func (service *StockTransferService) InTransit(store casts.StoreContext, filters requests.StockInTransitFilterRequest) ([]responses.StockInTransit, error) {
+	var results []responses.StockInTransit
+
+	query := facades.DB.Table("stock_transfer_items").
+		Select("stock_transfers.to_store_id AS store_id, stock_transfer_items.to_product_id AS product_id, products.name AS name, SUM(stock_transfer_items.quantity) AS quantity").
+		Joins("JOIN stock_transfers ON stock_transfers.id = stock_transfer_items.stock_transfer_id").
+		Joins("JOIN products ON products.id = stock_transfer_items.to_product_id").
+		Where("stock_transfers.status = ?", models.StockTransferInTransit).
+		Scopes(scopes.StoreScope(store, "stock_transfers.to_store_id")).
+		Group("stock_transfers.to_store_id, stock_transfer_items.to_product_id, products.name").
+		Order("products.name asc")
+	if filters.StoreID != nil {
+		query = query.Where("stock_transfers.to_store_id = ?", *filters.StoreID)
+	}
+
+	err := query.Scan(&results).Error
+	return results, err
+}
@@ -0,0 +1,199 @@
+package services
+
+import (
+	"errors"
+
+	"golang_starter_kit_2025/app/casts"
+	"golang_starter_kit_2025/app/helpers"
+	"golang_starter_kit_2025/app/models"
+	"golang_starter_kit_2025/app/models/scopes"
+	"golang_starter_kit_2025/app/requests"
+	"golang_starter_kit_2025/facades"
+
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrStoreForbidden = errors.New("anda tidak memiliki akses ke toko ini")
+	ErrStoreNotFound  = errors.New("toko tidak ditemukan")
+)
+
+type StoreService struct {
+	userService UserService
+}
+
+func NewStoreService() *StoreService {
+	return &StoreService{
+		userService: UserService{},
+	}
+}
+
+// ResolveContext menyusun casts.StoreContext untuk userID dengan toko aktif storeID.
+func (service *StoreService) ResolveContext(userID uint, storeID uint) (casts.StoreContext, error) {
+	store := casts.StoreContext{UserID: userID, StoreID: storeID}
+
+	isAdmin, err := service.userService.HasRole(userID, helpers.GetEnv("ADMIN_ROLE", "admin"))
+	if err != nil {
+		return store, err
+	}
+	store.IsAdmin = isAdmin
+
+	if err := facades.DB.Model(&models.StoreUser{}).
+		Where("user_id = ?", userID).
+		Pluck("store_id", &store.StoreIDs).Error; err != nil {
+		return store, err
+	}
+
+	return store, nil
+}
+
+func (service *StoreService) GetAll(store casts.StoreContext, filters requests.FilterRequest) ([]models.Store, int64, error) {
+	var stores []models.Store
+	var total int64
+
+	// daftar toko tidak dibatasi toko aktif, user tetap melihat seluruh tokonya
+	query := facades.DB.Model(&models.Store{}).
+		Scopes(scopes.StoreScope(casts.StoreContext{StoreIDs: store.StoreIDs, IsAdmin: store.IsAdmin}, "id"))
+	if filters.Search != nil {
+		query = query.Where("name LIKE ? OR city LIKE ? OR address LIKE ?",
+			"%"+*filters.Search+"%", "%"+*filters.Search+"%", "%"+*filters.Search+"%")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if filters.OrderBy != nil {
+		direction := "asc"
+		if filters.OrderDirection != nil {
+			direction = *filters.OrderDirection
+		}
+		query = query.Order(*filters.OrderBy + " " + direction)
+	} else {
+		query = query.Order("name asc")
+	}
+
+	if err := query.Scopes(scopes.Paginate(filters)).Find(&stores).Error; err != nil {
+		return nil, 0, err
+	}
+	return stores, total, nil
+}
+
+func (service *StoreService) GetByID(access casts.StoreContext, id string) (models.Store, error) {
+	var store models.Store
+	if err := facades.DB.First(&store, id).Error; err != nil {
+		return store, err
+	}
+	if !access.CanAccess(store.ID) {
+		return store, ErrStoreForbidden
+	}
+	return store, nil
+}
+
+// Put membuat toko baru (khusus admin) atau mengganti seluruh data toko yang
+// dapat diakses user.
+func (service *StoreService) Put(access casts.StoreContext, request requests.StoreRequestPut) (*models.Store, error) {
+	if (request.ID == 0 && !access.IsAdmin) || (request.ID != 0 && !access.CanAccess(request.ID)) {
+		return nil, ErrStoreForbidden
+	}
+
+	store := models.Store{
+		ID:      request.ID,
+		Version: request.Version,
+		Name:    request.Name,
+		Phone:   request.Phone,
+		Address: request.Address,
+		City:    request.City,
+		State:   request.State,
+		Country: request.Country,
+		Zip:     request.Zip,
+	}
+
+	if request.ID == 0 {
+		if err := facades.DB.Create(&store).Error; err != nil {
+			return &store, err
+		}
+	} else {
+		if err := facades.DB.Select("id").First(&models.Store{}, request.ID).Error; err != nil {
+			return nil, err
+		}
+		if request.Version == 0 {
+			return nil, ErrVersionRequired
+		}
+		if err := facades.DB.Model(&models.Store{}).Where("id = ?", request.ID).
+			Select("name", "phone", "address", "city", "state", "country", "zip", "version").Updates(&store).Error; err != nil {
+			return &store, err
+		}
+		if err := facades.DB.First(&store, request.ID).Error; err != nil {
+			return &store, err
+		}
+	}
+
+	return &store, nil
+}
+
+func (service *StoreService) Delete(access casts.StoreContext, id string) error {
+	if !access.IsAdmin {
+		return ErrStoreForbidden
+	}
+
+	var store models.Store
+	if err := facades.DB.First(&store, id).Error; err != nil {
+		return err
+	}
+	return facades.DB.Delete(&store).Error
+}
+
+func (service *StoreService) GetUsers(access casts.StoreContext, storeID string) ([]models.StoreUser, error) {
+	store, err := service.GetByID(access, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	var storeUsers []models.StoreUser
+	if err := facades.DB.Preload("User").Where("store_id = ?", store.ID).Find(&storeUsers).Error; err != nil {
+		return nil, err
+	}
+	return storeUsers, nil
+}
+
+// AttachUsers menambahkan user ke toko (khusus admin), user yang sudah
+// terdaftar diabaikan. Anggota toko tidak boleh menambahkan user lain karena
+// user tersebut ikut mendapat akses ke seluruh data toko.
+func (service *StoreService) AttachUsers(access casts.StoreContext, storeID string, userIDs []uint) error {
+	if !access.IsAdmin {
+		return ErrStoreForbidden
+	}
+
+	store, err := service.GetByID(access, storeID)
+	if err != nil {
+		return err
+	}
+
+	var validUsers []uint
+	facades.DB.Model(&models.User{}).Where("id IN ?", userIDs).Pluck("id", &validUsers)
+	if len(validUsers) != len(userIDs) {
+		return errors.New("one or more user IDs are invalid")
+	}
+
+	storeUsers := make([]models.StoreUser, 0, len(validUsers))
+	for _, userID := range validUsers {
+		storeUsers = append(storeUsers, models.StoreUser{StoreID: store.ID, UserID: userID})
+	}
+
+	return facades.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&storeUsers).Error
+}
+
+// DetachUser mengeluarkan user dari toko (khusus admin).
+func (service *StoreService) DetachUser(access casts.StoreContext, storeID string, userID string) error {
+	if !access.IsAdmin {
+		return ErrStoreForbidden
+	}
+
+	store, err := service.GetByID(access, storeID)
+	if err != nil {
+		return err
+	}
+
+	return facades.DB.Where("store_id = ? AND user_id = ?", store.ID, userID).Delete(&models.StoreUser{}).Error
+}
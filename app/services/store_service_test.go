@@ -0,0 +1,25 @@
+package services_test
+
+import (
+	"golang_starter_kit_2025/app/casts"
+	"golang_starter_kit_2025/app/services"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StoreService", func() {
+	Context("when a store member manages store membership", func() {
+		member := casts.StoreContext{UserID: 2, StoreID: 1, StoreIDs: []uint{1}}
+
+		It("should forbid attaching users", func() {
+			err := services.NewStoreService().AttachUsers(member, "1", []uint{3})
+			Expect(err).To(MatchError(services.ErrStoreForbidden))
+		})
+
+		It("should forbid detaching users", func() {
+			err := services.NewStoreService().DetachUser(member, "1", "3")
+			Expect(err).To(MatchError(services.ErrStoreForbidden))
+		})
+	})
+})
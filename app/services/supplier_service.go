@@ -0,0 +1,104 @@
+package services
+
+import (
+	"golang_starter_kit_2025/app/casts"
+	"golang_starter_kit_2025/app/models"
+	"golang_starter_kit_2025/app/models/scopes"
+	"golang_starter_kit_2025/app/requests"
+	"golang_starter_kit_2025/facades"
+)
+
+type SupplierService struct{}
+
+func NewSupplierService() *SupplierService {
+	return &SupplierService{}
+}
+
+func (service *SupplierService) GetAll(store casts.StoreContext, filters requests.SupplierFilterRequest) ([]models.Supplier, int64, error) {
+	var suppliers []models.Supplier
+	var total int64
+
+	query := facades.DB.Model(&models.Supplier{}).Scopes(scopes.StoreScope(store, "store_id"))
+	if filters.StoreID != nil {
+		query = query.Where("store_id = ?", *filters.StoreID)
+	}
+	if filters.Search != nil {
+		query = query.Where("name LIKE ? OR contact_person LIKE ? OR phone LIKE ? OR reference LIKE ?",
+			"%"+*filters.Search+"%", "%"+*filters.Search+"%", "%"+*filters.Search+"%", "%"+*filters.Search+"%")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if filters.OrderBy != nil {
+		direction := "asc"
+		if filters.OrderDirection != nil {
+			direction = *filters.OrderDirection
+		}
+		query = query.Order(*filters.OrderBy + " " + direction)
+	} else {
+		query = query.Order("name asc")
+	}
+
+	if err := query.Scopes(scopes.Paginate(filters.FilterRequest)).Find(&suppliers).Error; err != nil {
+		return nil, 0, err
+	}
+	return suppliers, total, nil
+}
+
+func (service *SupplierService) GetByID(store casts.StoreContext, id any) (models.Supplier, error) {
+	var supplier models.Supplier
+	err := facades.DB.Preload("Store").Scopes(scopes.StoreScope(store, "store_id")).First(&supplier, id).Error
+	return supplier, err
+}
+
+func (service *SupplierService) Put(store casts.StoreContext, request requests.SupplierRequestPut) (*models.Supplier, error) {
+	if !store.CanAccess(request.StoreID) {
+		return nil, ErrStoreForbidden
+	}
+	if request.ID != 0 {
+		if _, err := service.GetByID(store, request.ID); err != nil {
+			return nil, err
+		}
+		if request.Version == 0 {
+			return nil, ErrVersionRequired
+		}
+	}
+
+	supplier := models.Supplier{
+		ID:            request.ID,
+		Version:       request.Version,
+		StoreID:       request.StoreID,
+		Name:          request.Name,
+		ContactPerson: request.ContactPerson,
+		Phone:         request.Phone,
+		Email:         request.Email,
+		Address:       request.Address,
+	}
+
+	if request.ID == 0 {
+		if err := facades.DB.Create(&supplier).Error; err != nil {
+			return nil, err
+		}
+	} else {
+		if err := facades.DB.Model(&models.Supplier{}).Where("id = ?", request.ID).
+			Select("store_id", "name", "contact_person", "phone", "email", "address", "version").Updates(&supplier).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := service.GetByID(store, supplier.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (service *SupplierService) Delete(store casts.StoreContext, id string) error {
+	supplier, err := service.GetByID(store, id)
+	if err != nil {
+		return err
+	}
+	return facades.DB.Delete(&supplier).Error
+}
@@ -0,0 +1,255 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"golang_starter_kit_2025/app/casts"
+	"golang_starter_kit_2025/app/helpers"
+	"golang_starter_kit_2025/app/models"
+	"golang_starter_kit_2025/app/models/scopes"
+	"golang_starter_kit_2025/app/requests"
+	"golang_starter_kit_2025/facades"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrTrashResource = errors.New("resource tidak mendukung trash")
+	ErrTrashConflict = errors.New("data tidak dapat dipulihkan atau dihapus permanen")
+)
+
+const (
+	TrashProducts    = "products"
+	TrashCategories  = "categories"
+	TrashUsers       = "users"
+	TrashRoles       = "roles"
+	TrashPermissions = "permissions"
+)
+
+// trashResource mendeskripsikan model soft delete yang dapat dilihat,
+// dipulihkan dan dihapus permanen melalui TrashService.
+type trashResource struct {
+	model       func() interface{}
+	storeColumn string // kosong untuk resource global yang hanya dapat dikelola admin
+	search      string // kondisi LIKE untuk filter search
+	find        func(db *gorm.DB) ([]any, error)
+	// restorable memeriksa data terkait sebelum dipulihkan, contoh parent kategori
+	restorable func(tx *gorm.DB, id string) error
+	// deletable memeriksa riwayat yang ikut terhapus oleh ON DELETE CASCADE sebelum dihapus permanen
+	deletable func(tx *gorm.DB, id string) error
+}
+
+func newTrashResource[T any](storeColumn string, search string) *trashResource {
+	return &trashResource{
+		model:       func() interface{} { return new(T) },
+		storeColumn: storeColumn,
+		search:      search,
+		find: func(db *gorm.DB) ([]any, error) {
+			var rows []T
+			if err := db.Find(&rows).Error; err != nil {
+				return nil, err
+			}
+			items := make([]any, len(rows))
+			for i := range rows {
+				items[i] = rows[i]
+			}
+			return items, nil
+		},
+	}
+}
+
+var trashResources = map[string]*trashResource{
+	TrashProducts:    newTrashResource[models.Product]("store_id", "name LIKE ? OR reference LIKE ?"),
+	TrashCategories:  newTrashResource[models.Category]("", "category LIKE ? OR slug LIKE ?"),
+	TrashUsers:       newTrashResource[models.User]("", "username LIKE ? OR email LIKE ?"),
+	TrashRoles:       newTrashResource[models.Role]("", "name LIKE ? OR `group` LIKE ?"),
+	TrashPermissions: newTrashResource[models.Permission]("", "name LIKE ? OR `group` LIKE ?"),
+}
+
+func init() {
+	// kategori hanya dapat dipulihkan jika parent-nya masih ada
+	trashResources[TrashCategories].restorable = func(tx *gorm.DB, id string) error {
+		var category models.Category
+		if err := tx.Unscoped().Select("id", "parent_id").First(&category, id).Error; err != nil {
+			return err
+		}
+		if category.ParentID == nil {
+			return nil
+		}
+		var parent int64
+		if err := tx.Model(&models.Category{}).Where("id = ?", *category.ParentID).Count(&parent).Error; err != nil {
+			return err
+		}
+		if parent == 0 {
+			return fmt.Errorf("%w: parent kategori %d masih berada di trash", ErrTrashConflict, *category.ParentID)
+		}
+		return nil
+	}
+
+	// produk dengan ledger stok atau riwayat harga tidak dihapus permanen agar
+	// riwayat audit tidak ikut terhapus oleh foreign key cascade
+	trashResources[TrashProducts].deletable = func(tx *gorm.DB, id string) error {
+		for _, model := range []any{&models.StockMovement{}, &models.ProductPrice{}} {
+			var count int64
+			if err := tx.Model(model).Where("product_id = ?", id).Count(&count).Error; err != nil {
+				return err
+			}
+			if count > 0 {
+				return fmt.Errorf("%w: produk %s memiliki riwayat stok atau harga", ErrTrashConflict, id)
+			}
+		}
+		return nil
+	}
+}
+
+type TrashService struct{}
+
+func NewTrashService() *TrashService {
+	return &TrashService{}
+}
+
+// query membatasi data yang sudah dihapus sesuai hak akses toko user.
+func (service *TrashService) query(db *gorm.DB, store casts.StoreContext, resource string) (*trashResource, *gorm.DB, error) {
+	definition, ok := trashResources[resource]
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %s", ErrTrashResource, resource)
+	}
+
+	query := db.Unscoped().Model(definition.model()).Where("deleted_at IS NOT NULL")
+	if definition.storeColumn == "" {
+		if !store.IsAdmin {
+			return nil, nil, ErrStoreForbidden
+		}
+		return definition, query, nil
+	}
+	return definition, query.Scopes(scopes.StoreScope(store, definition.storeColumn)), nil
+}
+
+func (service *TrashService) GetAll(store casts.StoreContext, resource string, filters requests.FilterRequest) ([]any, int64, error) {
+	definition, query, err := service.query(facades.DB, store, resource)
+	if err != nil {
+		return nil, 0, err
+	}
+	if filters.Search != nil {
+		query = query.Where(definition.search, "%"+*filters.Search+"%", "%"+*filters.Search+"%")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if filters.OrderBy != nil {
+		direction := "asc"
+		if filters.OrderDirection != nil {
+			direction = *filters.OrderDirection
+		}
+		query = query.Order(*filters.OrderBy + " " + direction)
+	} else {
+		query = query.Order("deleted_at desc")
+	}
+
+	items, err := definition.find(query.Scopes(scopes.Paginate(filters)))
+	if err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+func (service *TrashService) Restore(store casts.StoreContext, resource string, id string) error {
+	return facades.DB.Transaction(func(tx *gorm.DB) error {
+		definition, query, err := service.query(tx, store, resource)
+		if err != nil {
+			return err
+		}
+		if definition.restorable != nil {
+			if err := definition.restorable(tx, id); err != nil {
+				return err
+			}
+		}
+		result := query.Where("id = ?", id).Update("deleted_at", nil)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+// ForceDelete menghapus permanen data yang sudah berada di trash.
+func (service *TrashService) ForceDelete(store casts.StoreContext, resource string, id string) error {
+	definition, query, err := service.query(facades.DB, store, resource)
+	if err != nil {
+		return err
+	}
+
+	var count int64
+	if err := query.Where("id = ?", id).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return service.forceDelete(definition, id)
+}
+
+// forceDelete menghapus permanen satu data setelah lolos pemeriksaan deletable.
+func (service *TrashService) forceDelete(definition *trashResource, id string) error {
+	if definition.deletable != nil {
+		if err := definition.deletable(facades.DB, id); err != nil {
+			return err
+		}
+	}
+	return translateTrashError(facades.DB.Unscoped().Where("id = ?", id).Delete(definition.model()).Error)
+}
+
+// Purge menghapus permanen data di trash yang dihapus sebelum waktu before.
+// Data yang masih direferensikan tabel lain atau memiliki riwayat, misalnya
+// produk dengan ledger stok, dilewati dan dihitung sebagai skipped, data terbaru dihapus lebih dulu agar sub kategori terhapus sebelum parent.
+func (service *TrashService) Purge(before time.Time) (map[string]int64, int64, error) {
+	purged := make(map[string]int64, len(trashResources))
+	var skipped int64
+	for name, definition := range trashResources {
+		var ids []uint
+		if err := facades.DB.Unscoped().Model(definition.model()).
+			Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+			Order("id desc").Pluck("id", &ids).Error; err != nil {
+			return purged, skipped, err
+		}
+		for _, id := range ids {
+			err := service.forceDelete(definition, strconv.FormatUint(uint64(id), 10))
+			if errors.Is(err, ErrTrashConflict) {
+				skipped++
+				continue
+			}
+			if err != nil {
+				return purged, skipped, err
+			}
+			purged[name]++
+		}
+	}
+	return purged, skipped, nil
+}
+
+// PurgeBefore menghitung batas waktu purge dari TRASH_RETENTION_DAYS.
+func (service *TrashService) PurgeBefore(now time.Time) time.Time {
+	return now.AddDate(0, 0, -helpers.GetEnvInt("TRASH_RETENTION_DAYS", 30))
+}
+
+// translateTrashError mengubah pelanggaran foreign key menjadi ErrTrashConflict.
+func translateTrashError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if translator, ok := facades.DB.Dialector.(gorm.ErrorTranslator); ok {
+		if errors.Is(translator.Translate(err), gorm.ErrForeignKeyViolated) {
+			return fmt.Errorf("%w: data masih digunakan oleh data lain", ErrTrashConflict)
+		}
+	}
+	return err
+}
@@ -0,0 +1,19 @@
+package services_test
+
+import (
+	"golang_starter_kit_2025/app/requests"
+	"golang_starter_kit_2025/app/services"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UserService", func() {
+	Context("when creating a user with an empty password", func() {
+		It("should reject the user before inserting", func() {
+			password := ""
+			_, err := (&services.UserService{}).Put(requests.UserRequest{Username: "budi", Email: "budi@example.com", Password: &password})
+			Expect(err).To(MatchError(services.ErrPasswordRequired))
+		})
+	})
+})
@@ -1,12 +1,16 @@
 package services
 
 import (
+	"errors"
+
+	"golang_starter_kit_2025/app/helpers"
 	"golang_starter_kit_2025/app/models"
+	"golang_starter_kit_2025/app/requests"
 	"golang_starter_kit_2025/facades"
-
-	"gorm.io/gorm/clause"
 )
 
+var ErrPasswordRequired = errors.New("password wajib diisi saat membuat user")
+
 type UserService struct{}
 
 func (*UserService) GetAllUsers() ([]models.User, error) {
@@ -25,15 +29,70 @@ func (*UserService) Find(id string) (models.User, error) {
 	return user, nil
 }
 
-func (*UserService) Put(user models.User) (models.User, error) {
+// Put membuat user baru jika ID kosong atau mengganti seluruh field user yang
+// sudah ada. Password dan PIN yang kosong tidak diubah.
+func (*UserService) Put(request requests.UserRequest) (models.User, error) {
+	// string kosong diperlakukan sama dengan tidak dikirim agar tidak tersimpan sebagai hash password kosong
+	if request.Password != nil && *request.Password == "" {
+		request.Password = nil
+	}
+	if request.Pin != nil && *request.Pin == "" {
+		request.Pin = nil
+	}
 
-	if err := facades.DB.Clauses(clause.OnConflict{
-		Columns:   []clause.Column{{Name: "id"}},
-		DoUpdates: clause.AssignmentColumns([]string{"username", "email", "password", "fcm_token", "updated_at"}),
-	}).Create(&user).Error; err != nil {
-		return user, err
+	var user models.User
+	if request.ID == 0 {
+		if request.Password == nil {
+			return user, ErrPasswordRequired
+		}
+		user = models.User{
+			Username: request.Username,
+			Email:    request.Email,
+			Password: *request.Password,
+			FcmToken: request.FcmToken,
+		}
+		if request.Pin != nil {
+			user.Pin = *request.Pin
+		}
+		// password dan PIN di-hash oleh hook BeforeCreate
+		if err := facades.DB.Create(&user).Error; err != nil {
+			return user, err
+		}
+		return user, nil
 	}
 
+	if err := facades.DB.Select("id").First(&user, request.ID).Error; err != nil {
+		return user, err
+	}
+	if request.Version == 0 {
+		return user, ErrVersionRequired
+	}
+	values := map[string]interface{}{
+		"username":  request.Username,
+		"email":     request.Email,
+		"fcm_token": request.FcmToken,
+		"version":   request.Version,
+	}
+	if request.Password != nil {
+		password, err := helpers.HashPasswordArgon2(*request.Password, helpers.DefaultParams)
+		if err != nil {
+			return user, err
+		}
+		values["password"] = password
+	}
+	if request.Pin != nil {
+		pin, err := helpers.HashPasswordArgon2(*request.Pin, helpers.DefaultParams)
+		if err != nil {
+			return user, err
+		}
+		values["pin"] = pin
+	}
+	if err := facades.DB.Model(&models.User{}).Where("id = ?", request.ID).Updates(values).Error; err != nil {
+		return user, err
+	}
+	if err := facades.DB.First(&user, request.ID).Error; err != nil {
+		return user, err
+	}
 	return user, nil
 }
 
@@ -78,3 +137,37 @@ func (*UserService) GetRolesByUserId(userId string) ([]models.Role, error) {
 	}
 	return roles, nil
 }
+
+// HasPermission mengecek apakah user memiliki permission, baik langsung
+// maupun melalui role.
+func (*UserService) HasPermission(userID uint, permission string) (bool, error) {
+	viaRoles := facades.DB.Model(&models.RoleHasPermissions{}).
+		Select("role_has_permissions.permission_id").
+		Joins("join user_has_roles on user_has_roles.role_id = role_has_permissions.role_id").
+		Joins("join roles on roles.id = role_has_permissions.role_id AND roles.deleted_at IS NULL").
+		Where("user_has_roles.user_id = ?", userID)
+	direct := facades.DB.Model(&models.UserHasPermissions{}).
+		Select("permission_id").
+		Where("user_id = ?", userID)
+
+	var count int64
+	if err := facades.DB.Model(&models.Permission{}).
+		Where("name = ?", permission).
+		Where("id IN (?) OR id IN (?)", viaRoles, direct).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// HasRole mengecek apakah user memiliki role dengan nama role.
+func (*UserService) HasRole(userID uint, role string) (bool, error) {
+	var count int64
+	if err := facades.DB.Table("roles").
+		Joins("join user_has_roles on roles.id = user_has_roles.role_id").
+		Where("user_has_roles.user_id = ? AND roles.name = ? AND roles.deleted_at IS NULL", userID, role).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,33 @@
+package validators
+
+import (
+	"golang_starter_kit_2025/app/helpers"
+	"golang_starter_kit_2025/app/requests"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// NIK memvalidasi struktur Nomor Induk Kependudukan, lihat helpers.DecodeNIK.
+func NIK(fl validator.FieldLevel) bool {
+	_, err := helpers.DecodeNIK(fl.Field().String())
+	return err == nil
+}
+
+func registerMemberValidations(v *validator.Validate) {
+	v.RegisterStructValidation(memberGender, requests.MemberRequestPut{})
+}
+
+// memberGender mencocokkan jenis_kelamin dengan jenis kelamin yang tercantum pada NIK.
+func memberGender(sl validator.StructLevel) {
+	request := sl.Current().Interface().(requests.MemberRequestPut)
+
+	data, err := helpers.DecodeNIK(request.NIK)
+	if err != nil {
+		// NIK tidak valid sudah dilaporkan oleh tag nik
+		return
+	}
+
+	if helpers.NormalizeGender(request.JenisKelamin) != data.Gender {
+		sl.ReportError(request.JenisKelamin, "JenisKelamin", "jenis_kelamin", "nik_gender", data.Gender)
+	}
+}
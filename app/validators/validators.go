@@ -0,0 +1,18 @@
+package validators
+
+import (
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// Register mendaftarkan custom validator ke validator bawaan Gin sehingga dapat
+// dipakai lewat tag binding, contoh `binding:"required,nik"`.
+func Register() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+
+	v.RegisterValidation("nik", NIK)
+	registerMemberValidations(v)
+}
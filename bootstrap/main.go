@@ -6,6 +6,8 @@ import (
 	"os"
 
 	"golang_starter_kit_2025/app/helpers"
+	"golang_starter_kit_2025/app/services"
+	"golang_starter_kit_2025/app/validators"
 	"golang_starter_kit_2025/cmd"
 	"golang_starter_kit_2025/docs"
 	"golang_starter_kit_2025/facades"
@@ -27,6 +29,9 @@ func Init() {
 	facades.ConnectDB()
 	defer facades.CloseDB()
 
+	// alert stok menipis dikirim sebagai notifikasi aplikasi, hook lain (misalnya MQTT) didaftarkan di sini
+	services.RegisterStockAlertHook(services.NewNotificationStockAlertHook())
+
 	app := &cli.App{
 		Name:  "Golang Starter Kit",
 		Usage: "CLI tool for managing migrations",
@@ -41,6 +46,12 @@ func Init() {
 			cmd.MakeSeederCommand,
 			cmd.DBSeedCommand,
 			cmd.RollbackSeederCommand,
+			cmd.StockReconcileCommand,
+			cmd.CostBudgetPlanPurgeWizardsCommand,
+			cmd.ProductApplyPricesCommand,
+			cmd.StockCheckAlertsCommand,
+			cmd.ProductGenerateBarcodesCommand,
+			cmd.TrashPurgeCommand,
 		},
 	}
 
@@ -57,6 +68,12 @@ func Init() {
 	defer facades.CloseDB()
 
 	r = Router()
+
+	// worker penerapan jadwal harga produk
+	go services.NewProductPriceService().RunScheduler()
+	// worker pemeriksaan stok menipis
+	go services.NewStockAlertService().RunScheduler()
+
 	fmt.Println("Server is running on port 8080")
 	r.Run(":8080")
 }
@@ -65,11 +82,13 @@ func Router() *gin.Engine {
 	route := gin.Default()
 
 	route.Use(cors.New(cors.Config{
-		AllowOrigins: []string{"*"},
-		AllowMethods: []string{"GET", "PUT", "DELETE"},
-		AllowHeaders: []string{"*"},
+		AllowOrigins:  []string{"*"},
+		AllowMethods:  []string{"GET", "POST", "PUT", "PATCH", "DELETE"},
+		AllowHeaders:  []string{"*"},
+		ExposeHeaders: []string{"ETag"},
 	}))
 
+	validators.Register()
 	routes.RegisterRoutes(route)
 
 	appName := helpers.GetEnv("APP_NAME", "My App")
@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"fmt"
+
+	"golang_starter_kit_2025/app/services"
+
+	"github.com/urfave/cli/v2"
+)
+
+var CostBudgetPlanPurgeWizardsCommand = &cli.Command{
+	Name:  "cost-budget-plan:purge-wizards",
+	Usage: "Delete expired cost budget plan wizard drafts",
+	Action: func(c *cli.Context) error {
+		fmt.Println("🧹 Purge expired cost budget plan wizards")
+
+		deleted, err := services.NewCostBudgetPlanWizardService().Purge()
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ %d wizard(s) deleted\n", deleted)
+		return nil
+	},
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"golang_starter_kit_2025/app/services"
+
+	"github.com/urfave/cli/v2"
+)
+
+var ProductApplyPricesCommand = &cli.Command{
+	Name:  "product:apply-prices",
+	Usage: "Apply scheduled product price changes that are due",
+	Action: func(c *cli.Context) error {
+		fmt.Println("💲 Apply scheduled product prices")
+
+		applied, err := services.NewProductPriceService().ApplyDue(time.Now())
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ %d price change(s) applied\n", applied)
+		return nil
+	},
+}
+
+var ProductGenerateBarcodesCommand = &cli.Command{
+	Name:  "product:generate-barcodes",
+	Usage: "Generate barcodes for products that do not have one",
+	Action: func(c *cli.Context) error {
+		fmt.Println("🏷️  Generate product barcodes")
+
+		generated, err := services.NewProductBarcodeService().Generate()
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ %d barcode(s) generated\n", generated)
+		return nil
+	},
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"golang_starter_kit_2025/app/services"
+
+	"github.com/urfave/cli/v2"
+)
+
+var StockReconcileCommand = &cli.Command{
+	Name:  "stock:reconcile",
+	Usage: "Recompute product stock from the stock movement ledger",
+	Flags: []cli.Flag{
+		&cli.UintFlag{Name: "product", Usage: "Product ID to reconcile (default all products)"},
+		&cli.BoolFlag{Name: "dry-run", Usage: "Only report differences without updating stock"},
+	},
+	Action: func(c *cli.Context) error {
+		dryRun := c.Bool("dry-run")
+		fmt.Println("🔄 Reconcile stock from ledger")
+
+		results, err := services.NewStockMovementService().Reconcile(c.Uint("product"), dryRun)
+		if err != nil {
+			return err
+		}
+
+		for _, r := range results {
+			fmt.Printf("   product %d: %d -> %d\n", r.ProductID, r.StockBefore, r.StockAfter)
+		}
+		if dryRun {
+			fmt.Printf("✅ %d product(s) out of sync (dry run)\n", len(results))
+		} else {
+			fmt.Printf("✅ %d product(s) reconciled\n", len(results))
+		}
+		return nil
+	},
+}
+
+var StockCheckAlertsCommand = &cli.Command{
+	Name:  "stock:check-alerts",
+	Usage: "Create low-stock alerts for products below their minimum level",
+	Action: func(c *cli.Context) error {
+		fmt.Println("🔄 Check low-stock alerts")
+
+		created, resolved, err := services.NewStockAlertService().Check(time.Now())
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ %d alert(s) created, %d alert(s) resolved\n", created, resolved)
+		return nil
+	},
+}
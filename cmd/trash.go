@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"golang_starter_kit_2025/app/services"
+
+	"github.com/urfave/cli/v2"
+)
+
+var TrashPurgeCommand = &cli.Command{
+	Name:  "trash:purge",
+	Usage: "Permanently delete trashed records older than the retention period",
+	Flags: []cli.Flag{
+		&cli.IntFlag{Name: "days", Usage: "Retention in days (default TRASH_RETENTION_DAYS)"},
+	},
+	Action: func(c *cli.Context) error {
+		service := services.NewTrashService()
+		before := service.PurgeBefore(time.Now())
+		if c.IsSet("days") {
+			before = time.Now().AddDate(0, 0, -c.Int("days"))
+		}
+		fmt.Printf("🗑️  Purge records trashed before %s\n", before.Format(time.DateTime))
+
+		purged, skipped, err := service.Purge(before)
+		if err != nil {
+			return err
+		}
+
+		for resource, count := range purged {
+			fmt.Printf("   %s: %d\n", resource, count)
+		}
+		fmt.Printf("✅ purge finished, %d record(s) skipped because they are still referenced\n", skipped)
+		return nil
+	},
+}
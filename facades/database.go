@@ -8,6 +8,8 @@ import (
 	"sync"
 	"time"
 
+	"golang_starter_kit_2025/app/database/plugins"
+
 	"github.com/joho/godotenv"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
@@ -71,6 +73,11 @@ func ConnectDB(envFiles ...string) *gorm.DB {
 			log.Fatalf("Error: failed to connect to the database: %v", err)
 		}
 
+		// Optimistic locking untuk model yang memiliki kolom version
+		if err := DB.Use(plugins.OptimisticLock{}); err != nil {
+			log.Fatalf("Error: failed to register optimistic lock plugin: %v", err)
+		}
+
 		// Get the underlying SQL DB object for connection pooling configuration
 		SqlDB, err = DB.DB()
 		if err != nil {
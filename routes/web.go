@@ -32,57 +32,278 @@ func RegisterRoutes(route *gin.Engine) {
 	// Routes untuk categories (protected by AuthMiddleware)
 	categoryService := services.CategoryService{}
 	categoryController := controllers.NewCategoryController(categoryService)
+	categoryAttributeController := controllers.NewCategoryAttributeController()
+	categoryTrashController := controllers.NewTrashController(services.TrashCategories)
 	categoryRoutes := route.Group("/categories", middleware.AuthMiddleware()) // Protect category routes
 	{
-		categoryRoutes.GET("/", categoryController.List)         // List categories
-		categoryRoutes.GET("/:id", categoryController.Get)       // Show/Edit category (GET by ID)
-		categoryRoutes.PUT("/", categoryController.Put)          // Create/Update category
-		categoryRoutes.DELETE("/:id", categoryController.Delete) // Delete category by ID
+		categoryRoutes.GET("/", categoryController.List)            // List categories
+		categoryRoutes.GET("/tree", categoryController.Tree)        // Category tree with product counts
+		categoryRoutes.POST("/reorder", categoryController.Reorder) // Reorder sibling categories
+		categoryRoutes.POST("/:id/move", categoryController.Move)   // Move category to another parent
+		categoryRoutes.GET("/:id", categoryController.Get)          // Show/Edit category (GET by ID)
+		categoryRoutes.POST("/", categoryController.Create)         // Create category
+		categoryRoutes.PUT("/:id", categoryController.Replace)      // Replace category
+		categoryRoutes.PATCH("/:id", categoryController.Patch)      // Partial update (JSON Merge Patch)
+		categoryRoutes.PUT("/", categoryController.Put)             // Create/Update category (deprecated)
+		categoryRoutes.DELETE("/:id", categoryController.Delete)    // Delete category by ID
+		categoryRoutes.GET("/trash", middleware.StoreMiddleware(), categoryTrashController.List)
+		categoryRoutes.POST("/:id/restore", middleware.StoreMiddleware(), categoryTrashController.Restore)
+		categoryRoutes.DELETE("/:id/force", middleware.StoreMiddleware(), categoryTrashController.ForceDelete)
+		categoryRoutes.GET("/:id/attributes", categoryAttributeController.List)
+		categoryRoutes.PUT("/:id/attributes", categoryAttributeController.Put)
+		categoryRoutes.DELETE("/:id/attributes/:attribute_id", categoryAttributeController.Delete)
 	}
 
 	// Routes untuk products (protected by AuthMiddleware)
 	productController := controllers.NewProductController()
-	productRoutes := route.Group("/products", middleware.AuthMiddleware()) // Protect product routes
+	stockMovementController := controllers.NewStockMovementController()
+	productPriceController := controllers.NewProductPriceController()
+	stockAlertController := controllers.NewStockAlertController()
+	productVariantController := controllers.NewProductVariantController()
+	productBarcodeController := controllers.NewProductBarcodeController()
+	productTrashController := controllers.NewTrashController(services.TrashProducts)
+	productRoutes := route.Group("/products", middleware.AuthMiddleware(), middleware.StoreMiddleware()) // Protect product routes
 	{
-		productRoutes.GET("/", productController.GetAll)       // List all products
-		productRoutes.GET("/:id", productController.GetByID)   // Show/Edit product by ID
-		productRoutes.PUT("/", productController.Put)          // Create/Update product
-		productRoutes.DELETE("/:id", productController.Delete) // Delete product by ID
+		productRoutes.GET("/", productController.GetAll)                             // List all products
+		productRoutes.GET("/labels", productBarcodeController.Labels)                // Printable label sheet (PDF)
+		productRoutes.GET("/trash", productTrashController.List)                     // Trashed products
+		productRoutes.POST("/:id/restore", productTrashController.Restore)           // Restore trashed product
+		productRoutes.DELETE("/:id/force", productTrashController.ForceDelete)       // Permanently delete product
+		productRoutes.GET("/by-barcode/:code", productBarcodeController.ByBarcode)   // Lookup by barcode/SKU/reference
+		productRoutes.GET("/:id", productController.GetByID)                         // Show/Edit product by ID
+		productRoutes.GET("/:id/barcode", productBarcodeController.Barcode)          // Barcode image (PNG/SVG)
+		productRoutes.GET("/:id/qrcode", productBarcodeController.QRCode)            // QR code image (PNG/SVG)
+		productRoutes.POST("/", productController.Create)                            // Create product
+		productRoutes.PUT("/:id", productController.Replace)                         // Replace product
+		productRoutes.PATCH("/:id", productController.Patch)                         // Partial update (JSON Merge Patch)
+		productRoutes.PUT("/", productController.Put)                                // Create/Update product (deprecated)
+		productRoutes.DELETE("/:id", productController.Delete)                       // Delete product by ID
+		productRoutes.GET("/:id/movements", stockMovementController.History)         // Stock movement history
+		productRoutes.POST("/:id/movements", stockMovementController.Store)          // Post stock movement
+		productRoutes.GET("/:id/prices", productPriceController.History)             // Price history
+		productRoutes.POST("/:id/prices", productPriceController.Schedule)           // Schedule price change
+		productRoutes.DELETE("/:id/prices/:price_id", productPriceController.Cancel) // Cancel scheduled price
+		productRoutes.PUT("/:id/stock-levels", stockAlertController.ProductLevels)   // Set min/max stock
+		productRoutes.GET("/:id/variants", productVariantController.List)            // List variants
+		productRoutes.PUT("/:id/variants", productVariantController.Put)             // Create/Update variant
+		productRoutes.PUT("/:id/attributes", productVariantController.Attributes)    // Set attribute values
+	}
+
+	// Routes untuk transaksi penjualan (protected by AuthMiddleware)
+	saleController := controllers.NewSaleController()
+	saleRoutes := route.Group("/sales", middleware.AuthMiddleware(), middleware.StoreMiddleware())
+	{
+		saleRoutes.GET("", saleController.List)
+		saleRoutes.GET("/:id", saleController.Get)
+		saleRoutes.POST("", saleController.Checkout)
+		saleRoutes.POST("/:id/void", saleController.Void)
+		saleRoutes.POST("/:id/returns", saleController.Return)
+	}
+
+	// Routes untuk supplier dan purchase order (protected by AuthMiddleware)
+	supplierController := controllers.NewSupplierController()
+	supplierRoutes := route.Group("/suppliers", middleware.AuthMiddleware(), middleware.StoreMiddleware())
+	{
+		supplierRoutes.GET("", supplierController.List)
+		supplierRoutes.GET("/:id", supplierController.Get)
+		supplierRoutes.POST("", supplierController.Create)
+		supplierRoutes.PUT("/:id", supplierController.Replace)
+		supplierRoutes.PATCH("/:id", supplierController.Patch)
+		supplierRoutes.PUT("", supplierController.Put) // deprecated, gunakan POST atau PUT /:id
+		supplierRoutes.DELETE("/:id", supplierController.Delete)
+	}
+
+	purchaseOrderController := controllers.NewPurchaseOrderController()
+	purchaseOrderRoutes := route.Group("/purchase-orders", middleware.AuthMiddleware(), middleware.StoreMiddleware())
+	{
+		purchaseOrderRoutes.GET("", purchaseOrderController.List)
+		purchaseOrderRoutes.GET("/:id", purchaseOrderController.Get)
+		purchaseOrderRoutes.PUT("", purchaseOrderController.Put)
+		purchaseOrderRoutes.POST("/:id/cancel", purchaseOrderController.Cancel)
+		purchaseOrderRoutes.POST("/:id/close", purchaseOrderController.Close)
+		purchaseOrderRoutes.POST("/:id/receipts", purchaseOrderController.Receive)
+	}
+
+	// Routes untuk transfer stok antar toko (protected by AuthMiddleware)
+	stockTransferController := controllers.NewStockTransferController()
+	stockTransferRoutes := route.Group("/stock-transfers", middleware.AuthMiddleware(), middleware.StoreMiddleware())
+	{
+		stockTransferRoutes.GET("", stockTransferController.List)
+		stockTransferRoutes.GET("/in-transit", stockTransferController.InTransit)
+		stockTransferRoutes.GET("/:id", stockTransferController.Get)
+		stockTransferRoutes.PUT("", stockTransferController.Put)
+		stockTransferRoutes.POST("/:id/dispatch", stockTransferController.Dispatch)
+		stockTransferRoutes.POST("/:id/receive", stockTransferController.Receive)
+		stockTransferRoutes.POST("/:id/cancel", stockTransferController.Cancel)
+	}
+
+	// Routes untuk stock opname (protected by AuthMiddleware)
+	stockTakeController := controllers.NewStockTakeController()
+	stockTakeRoutes := route.Group("/stock-takes", middleware.AuthMiddleware(), middleware.StoreMiddleware())
+	{
+		stockTakeRoutes.GET("", stockTakeController.List)
+		stockTakeRoutes.GET("/:id", stockTakeController.Get)
+		stockTakeRoutes.POST("", stockTakeController.Create)
+		stockTakeRoutes.PUT("/:id/counts", stockTakeController.Count)
+		stockTakeRoutes.POST("/:id/counts/upload", stockTakeController.Upload)
+		stockTakeRoutes.POST("/:id/scans", stockTakeController.Scan)
+		stockTakeRoutes.POST("/:id/submit", stockTakeController.Submit)
+		stockTakeRoutes.POST("/:id/approve", stockTakeController.Approve)
+		stockTakeRoutes.POST("/:id/reject", stockTakeController.Reject)
+		stockTakeRoutes.POST("/:id/cancel", stockTakeController.Cancel)
+	}
+
+	// Routes untuk alert stok menipis (protected by AuthMiddleware)
+	route.GET("/stock-alerts", middleware.AuthMiddleware(), middleware.StoreMiddleware(), stockAlertController.List)
+
+	// Routes untuk stores (protected by AuthMiddleware)
+	storeController := controllers.NewStoreController()
+	memberController := controllers.NewMemberController()
+	memberLandController := controllers.NewMemberLandController()
+	costBudgetPlanController := controllers.NewCostBudgetPlanController()
+	storeRoutes := route.Group("/stores", middleware.AuthMiddleware(), middleware.StoreMiddleware())
+	{
+		storeRoutes.GET("", storeController.List)
+		storeRoutes.GET("/:id", storeController.Get)
+		storeRoutes.POST("", storeController.Create)
+		storeRoutes.PUT("/:id", storeController.Replace)
+		storeRoutes.PATCH("/:id", storeController.Patch)
+		storeRoutes.PUT("", storeController.Put) // deprecated, gunakan POST atau PUT /:id
+		storeRoutes.DELETE("/:id", storeController.Delete)
+		storeRoutes.GET("/:id/users", storeController.Users)
+		storeRoutes.POST("/:id/users", storeController.AttachUsers)
+		storeRoutes.DELETE("/:id/users/:user_id", storeController.DetachUser)
+		storeRoutes.POST("/:id/members", memberController.AttachStore)
+		storeRoutes.DELETE("/:id/members/:member_id", memberController.DetachStore)
+		storeRoutes.GET("/:id/approval-levels", costBudgetPlanController.ApprovalLevels)
+		storeRoutes.PUT("/:id/approval-levels", costBudgetPlanController.PutApprovalLevels)
+		storeRoutes.PUT("/:id/stock-levels", stockAlertController.StoreLevels)
+	}
+
+	// Routes untuk members (protected by AuthMiddleware)
+	memberRoutes := route.Group("/members", middleware.AuthMiddleware(), middleware.StoreMiddleware())
+	{
+		memberRoutes.GET("", memberController.List)
+		memberRoutes.GET("/:id", memberController.Get)
+		memberRoutes.POST("", memberController.Create)
+		memberRoutes.PUT("/:id", memberController.Replace)
+		memberRoutes.PATCH("/:id", memberController.Patch)
+		memberRoutes.PUT("", memberController.Put) // deprecated, gunakan POST atau PUT /:id
+		memberRoutes.DELETE("/:id", memberController.Delete)
+		memberRoutes.POST("/check-nik", memberController.CheckNIK)
+		memberRoutes.GET("/:id/lands", memberLandController.List)
+		memberRoutes.PUT("/:id/lands", memberLandController.Put)
+	}
+
+	// Routes untuk lahan member (protected by AuthMiddleware)
+	memberLandRoutes := route.Group("", middleware.AuthMiddleware(), middleware.StoreMiddleware())
+	{
+		memberLandRoutes.GET("/member-lands", memberLandController.Search)
+		memberLandRoutes.GET("/member-lands.geojson", memberLandController.GeoJSON)
+	}
+
+	// Routes untuk katalog product offering (protected by AuthMiddleware)
+	productOfferingController := controllers.NewProductOfferingController()
+	productOfferingRoutes := route.Group("/product-offerings", middleware.AuthMiddleware(), middleware.StoreMiddleware())
+	{
+		productOfferingRoutes.GET("", productOfferingController.List)
+		productOfferingRoutes.GET("/:id", productOfferingController.Get)
+		productOfferingRoutes.PUT("", productOfferingController.Put)
+		productOfferingRoutes.DELETE("/:id", productOfferingController.Delete)
+		productOfferingRoutes.PUT("/:id/status", productOfferingController.Status)
+		productOfferingRoutes.GET("/:id/prices", productOfferingController.Prices)
+		productOfferingRoutes.POST("/:id/prices", productOfferingController.SchedulePrice)
+	}
+
+	// Routes untuk RAB (protected by AuthMiddleware)
+	route.GET("/phases", middleware.AuthMiddleware(), costBudgetPlanController.Phases)
+	costBudgetPlanRoutes := route.Group("/cost-budget-plans", middleware.AuthMiddleware(), middleware.StoreMiddleware())
+	{
+		costBudgetPlanRoutes.GET("", costBudgetPlanController.List)
+		costBudgetPlanRoutes.GET("/:id", costBudgetPlanController.Get)
+		costBudgetPlanRoutes.PUT("", costBudgetPlanController.Put)
+		costBudgetPlanRoutes.DELETE("/:id", costBudgetPlanController.Delete)
+		costBudgetPlanRoutes.PUT("/:id/items", costBudgetPlanController.PutItem)
+		costBudgetPlanRoutes.DELETE("/:id/items/:item_id", costBudgetPlanController.DeleteItem)
+		costBudgetPlanRoutes.GET("/:id/transitions", costBudgetPlanController.Transitions)
+		costBudgetPlanRoutes.POST("/:id/transitions", costBudgetPlanController.Transition)
+		costBudgetPlanRoutes.GET("/:id/items/:item_id/histories", costBudgetPlanController.Histories)
+		costBudgetPlanRoutes.PUT("/:id/items/:item_id/histories", costBudgetPlanController.PutHistory)
+		costBudgetPlanRoutes.GET("/:id/realisation", costBudgetPlanController.Realisation)
+	}
+
+	costBudgetPlanWizardController := controllers.NewCostBudgetPlanWizardController()
+	costBudgetPlanWizardRoutes := route.Group("/cost-budget-plan-wizards", middleware.AuthMiddleware(), middleware.StoreMiddleware())
+	{
+		costBudgetPlanWizardRoutes.GET("", costBudgetPlanWizardController.List)
+		costBudgetPlanWizardRoutes.GET("/:id", costBudgetPlanWizardController.Get)
+		costBudgetPlanWizardRoutes.PUT("", costBudgetPlanWizardController.Put)
+		costBudgetPlanWizardRoutes.DELETE("/:id", costBudgetPlanWizardController.Delete)
+		costBudgetPlanWizardRoutes.POST("/:id/finalize", costBudgetPlanWizardController.Finalize)
+	}
+
+	// Routes untuk notifikasi user (protected by AuthMiddleware)
+	notificationController := controllers.NewNotificationController()
+	notificationRoutes := route.Group("/notifications", middleware.AuthMiddleware())
+	{
+		notificationRoutes.GET("", notificationController.List)
+		notificationRoutes.PUT("/:id/read", notificationController.Read)
 	}
 
 	// Routes untuk users (protected by AuthMiddleware)
 	userService := services.UserService{}
 	userController := controllers.NewUserController(userService)
+	userTrashController := controllers.NewTrashController(services.TrashUsers)
 	userRoutes := route.Group("/users", middleware.AuthMiddleware()) // Protect user routes
 	{
 		userRoutes.GET("", userController.List)
 		userRoutes.GET("/:id", userController.Get)
-		userRoutes.PUT("", userController.Put)
+		userRoutes.POST("", userController.Create)
+		userRoutes.PUT("/:id", userController.Replace)
+		userRoutes.PATCH("/:id", userController.Patch)
+		userRoutes.PUT("", userController.Put) // deprecated, gunakan POST atau PUT /:id
 		userRoutes.DELETE("/:id", userController.Delete)
 		userRoutes.POST("/:id/roles", userController.AssignRoles)
 		userRoutes.GET("/:id/roles", userController.GetRoles)
+		userRoutes.GET("/trash", middleware.StoreMiddleware(), userTrashController.List)
+		userRoutes.POST("/:id/restore", middleware.StoreMiddleware(), userTrashController.Restore)
+		userRoutes.DELETE("/:id/force", middleware.StoreMiddleware(), userTrashController.ForceDelete)
 	}
 
 	// Routes untuk roles (protected by AuthMiddleware)
 	roleService := services.RoleService{}
 	roleController := controllers.NewRoleController(roleService)
+	roleTrashController := controllers.NewTrashController(services.TrashRoles)
 	roleRoutes := route.Group("/roles", middleware.AuthMiddleware()) // Protect role routes
 	{
 		roleRoutes.GET("", roleController.List)                               // List roles
-		roleRoutes.PUT("", roleController.Put)                                // Create/Update role
+		roleRoutes.POST("", roleController.Create)                            // Create role
+		roleRoutes.PUT("/:id", roleController.Replace)                        // Replace role
+		roleRoutes.PATCH("/:id", roleController.Patch)                        // Partial update (JSON Merge Patch)
+		roleRoutes.PUT("", roleController.Put)                                // Create/Update role (deprecated)
 		roleRoutes.DELETE("/:id", roleController.Delete)                      // Delete role by ID
 		roleRoutes.POST("/:id/permissions", roleController.AssignPermissions) // Assign permissions to role
 		roleRoutes.GET("/:id/permissions", roleController.GetPermissions)     // Get permissions for role
+		roleRoutes.GET("/trash", middleware.StoreMiddleware(), roleTrashController.List)
+		roleRoutes.POST("/:id/restore", middleware.StoreMiddleware(), roleTrashController.Restore)
+		roleRoutes.DELETE("/:id/force", middleware.StoreMiddleware(), roleTrashController.ForceDelete)
 	}
 
 	// Routes untuk permissions (protected by AuthMiddleware)
 	permissionService := services.PermissionService{}
 	permissionController := controllers.NewPermissionController(permissionService)
+	permissionTrashController := controllers.NewTrashController(services.TrashPermissions)
 	permissionRoutes := route.Group("/permissions", middleware.AuthMiddleware()) // Protect permission routes
 	{
 		permissionRoutes.GET("", permissionController.List)          // List all permissions
-		permissionRoutes.PUT("", permissionController.Put)           // Create/Update permission
+		permissionRoutes.POST("", permissionController.Create)       // Create permission
+		permissionRoutes.PUT("/:id", permissionController.Replace)   // Replace permission
+		permissionRoutes.PATCH("/:id", permissionController.Patch)   // Partial update (JSON Merge Patch)
+		permissionRoutes.PUT("", permissionController.Put)           // Create/Update permission (deprecated)
 		permissionRoutes.DELETE("/:id", permissionController.Delete) // Delete permission by ID
+		permissionRoutes.GET("/trash", middleware.StoreMiddleware(), permissionTrashController.List)
+		permissionRoutes.POST("/:id/restore", middleware.StoreMiddleware(), permissionTrashController.Restore)
+		permissionRoutes.DELETE("/:id/force", middleware.StoreMiddleware(), permissionTrashController.ForceDelete)
 	}
 
 	fileController := controllers.NewFileController()